@@ -0,0 +1,127 @@
+package silgotel
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// AttributeKey converts key to the attribute naming convention of the
+// package: surrounding space is trimmed and letters are lowercased, so
+// "Patient.ID" becomes "patient.id". Dots, dashes and underscores are kept
+// as they are, leaving semantic convention keys such as
+// "http.request.header.content-type" intact.
+func AttributeKey(key string) attribute.Key {
+	return attribute.Key(strings.ToLower(strings.TrimSpace(key)))
+}
+
+// String returns a string attribute with a key normalized by AttributeKey.
+func String(key, value string) attribute.KeyValue {
+	return AttributeKey(key).String(value)
+}
+
+// Strings returns a string slice attribute with a key normalized by
+// AttributeKey.
+func Strings(key string, values []string) attribute.KeyValue {
+	return AttributeKey(key).StringSlice(values)
+}
+
+// Int returns an int attribute with a key normalized by AttributeKey.
+func Int(key string, value int) attribute.KeyValue {
+	return AttributeKey(key).Int(value)
+}
+
+// Int64 returns an int64 attribute with a key normalized by AttributeKey.
+func Int64(key string, value int64) attribute.KeyValue {
+	return AttributeKey(key).Int64(value)
+}
+
+// Float64 returns a float64 attribute with a key normalized by
+// AttributeKey.
+func Float64(key string, value float64) attribute.KeyValue {
+	return AttributeKey(key).Float64(value)
+}
+
+// Bool returns a bool attribute with a key normalized by AttributeKey.
+func Bool(key string, value bool) attribute.KeyValue {
+	return AttributeKey(key).Bool(value)
+}
+
+// Duration returns an attribute holding d in seconds, the unit of the
+// semantic conventions, with a key normalized by AttributeKey.
+func Duration(key string, d time.Duration) attribute.KeyValue {
+	return AttributeKey(key).Float64(d.Seconds())
+}
+
+// Namespace builds attributes whose keys are prefixed with the namespace and
+// a dot, so that a team's attributes share one prefix:
+//
+//	patient := silgotel.Namespace("patient")
+//	silgotel.SetAttrs(span, patient.String("id", id), patient.Int("age", age))
+//
+// Both the namespace and the keys are normalized by AttributeKey, and keys
+// that already carry the prefix are not prefixed again.
+type Namespace string
+
+// Key returns key normalized and prefixed with the namespace.
+func (n Namespace) Key(key string) attribute.Key {
+	prefix := string(AttributeKey(string(n)))
+	if prefix == "" {
+		return AttributeKey(key)
+	}
+
+	normalized := AttributeKey(key)
+	if strings.HasPrefix(string(normalized), prefix+".") {
+		return normalized
+	}
+
+	return attribute.Key(prefix + "." + string(normalized))
+}
+
+// String returns a string attribute in the namespace.
+func (n Namespace) String(key, value string) attribute.KeyValue {
+	return n.Key(key).String(value)
+}
+
+// Strings returns a string slice attribute in the namespace.
+func (n Namespace) Strings(key string, values []string) attribute.KeyValue {
+	return n.Key(key).StringSlice(values)
+}
+
+// Int returns an int attribute in the namespace.
+func (n Namespace) Int(key string, value int) attribute.KeyValue {
+	return n.Key(key).Int(value)
+}
+
+// Int64 returns an int64 attribute in the namespace.
+func (n Namespace) Int64(key string, value int64) attribute.KeyValue {
+	return n.Key(key).Int64(value)
+}
+
+// Float64 returns a float64 attribute in the namespace.
+func (n Namespace) Float64(key string, value float64) attribute.KeyValue {
+	return n.Key(key).Float64(value)
+}
+
+// Bool returns a bool attribute in the namespace.
+func (n Namespace) Bool(key string, value bool) attribute.KeyValue {
+	return n.Key(key).Bool(value)
+}
+
+// Duration returns an attribute in the namespace holding d in seconds.
+func (n Namespace) Duration(key string, d time.Duration) attribute.KeyValue {
+	return n.Key(key).Float64(d.Seconds())
+}
+
+// SetAttrs sets attrs on span, typically built with String, Int and the
+// other helpers of the package. It is a no-op when the span is not
+// recording. Use AddSpanAttributes to target the span held in a context.
+func SetAttrs(span otelTrace.Span, attrs ...attribute.KeyValue) {
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attrs...)
+}
@@ -0,0 +1,109 @@
+package silgotel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestAttributeKey(t *testing.T) {
+	tests := map[string]struct {
+		key  string
+		want attribute.Key
+	}{
+		"lowercase":         {key: "patient.id", want: "patient.id"},
+		"case folding":      {key: "Patient.ID", want: "patient.id"},
+		"trimming":          {key: "  order.total\t", want: "order.total"},
+		"trimming and case": {key: " Facility.Code\n", want: "facility.code"},
+		"separators kept":   {key: "HTTP.Request.Header.Content-Type", want: "http.request.header.content-type"},
+		"underscores kept":  {key: "national_ID", want: "national_id"},
+		"inner spaces kept": {key: "visit type", want: "visit type"},
+		"empty":             {key: "   ", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := silgotel.AttributeKey(tt.key); got != tt.want {
+				t.Errorf("AttributeKey(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceKey(t *testing.T) {
+	tests := map[string]struct {
+		namespace silgotel.Namespace
+		key       string
+		want      attribute.Key
+	}{
+		"joined":           {namespace: "patient", key: "id", want: "patient.id"},
+		"both normalized":  {namespace: " Patient ", key: " ID ", want: "patient.id"},
+		"already prefixed": {namespace: "patient", key: "Patient.ID", want: "patient.id"},
+		"prefix of a word": {namespace: "patient", key: "patients.count", want: "patient.patients.count"},
+		"nested namespace": {namespace: "clinic.visit", key: "Type", want: "clinic.visit.type"},
+		"empty namespace":  {namespace: "  ", key: "Order.ID", want: "order.id"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.namespace.Key(tt.key); got != tt.want {
+				t.Errorf("Namespace(%q).Key(%q) = %q, want %q", tt.namespace, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributeHelpers(t *testing.T) {
+	patient := silgotel.Namespace("Patient")
+
+	tests := map[string]struct {
+		got  attribute.KeyValue
+		want attribute.KeyValue
+	}{
+		"String":           {got: silgotel.String(" Order.ID", "o-1"), want: attribute.String("order.id", "o-1")},
+		"Strings":          {got: silgotel.Strings("Tags", nil), want: attribute.StringSlice("tags", nil)},
+		"Int":              {got: silgotel.Int("Retries", 2), want: attribute.Int("retries", 2)},
+		"Int64":            {got: silgotel.Int64("Bytes", 9), want: attribute.Int64("bytes", 9)},
+		"Float64":          {got: silgotel.Float64("Total", 1.5), want: attribute.Float64("total", 1.5)},
+		"Bool":             {got: silgotel.Bool("Paid", true), want: attribute.Bool("paid", true)},
+		"Duration":         {got: silgotel.Duration("Wait", 1500*time.Millisecond), want: attribute.Float64("wait", 1.5)},
+		"Namespace String": {got: patient.String("ID", "p-1"), want: attribute.String("patient.id", "p-1")},
+		"Namespace Int":    {got: patient.Int("Age", 40), want: attribute.Int("patient.age", 40)},
+		"Namespace Bool":   {got: patient.Bool("Admitted", true), want: attribute.Bool("patient.admitted", true)},
+		"Namespace Duration": {
+			got:  patient.Duration("Stay", 2*time.Second),
+			want: attribute.Float64("patient.stay", 2),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAttrs(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	patient := silgotel.Namespace("patient")
+
+	_, span := silgotel.Trace(t.Context(), "attrs", "admit")
+	silgotel.SetAttrs(span, patient.String("ID", "p-1"), silgotel.Int("Ward.Beds", 12))
+	span.End()
+
+	attrs := spanAttrs(sdk.Spans()[0])
+	if attrs["patient.id"] != "p-1" || attrs["ward.beds"] != "12" {
+		t.Errorf("span attributes = %v, want patient.id and ward.beds", attrs)
+	}
+
+	// A span that is not recording is left alone.
+	silgotel.SetAttrs(otelTrace.SpanFromContext(context.Background()), silgotel.String("ignored", "x"))
+}
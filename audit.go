@@ -0,0 +1,167 @@
+package silgotel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// AuditScope is the instrumentation scope of the log records emitted by
+// AuditEvent, so backends can route audit events apart from other logs.
+const AuditScope = "silgotel.audit"
+
+// Attributes set on every audit event.
+const (
+	AuditActionKey   = attribute.Key("audit.action")
+	AuditResourceKey = attribute.Key("audit.resource")
+	AuditActorKey    = attribute.Key("audit.actor")
+	AuditSequenceKey = attribute.Key("audit.sequence")
+	AuditHashKey     = attribute.Key("audit.hash")
+)
+
+// auditChain numbers the audit events of the process and chains their
+// hashes, so a missing, reordered or altered event is detectable.
+//
+//nolint:gochecknoglobals
+var auditChain struct {
+	mu       sync.Mutex
+	sequence int64
+	lastHash string
+}
+
+// AuditEvent records that actor performed action on resource, for example
+// AuditEvent(ctx, "read", "patient/123", "user:42"). The event is emitted as
+// an info log record on the AuditScope logger of the global logger provider,
+// correlated with the span in ctx, and added as an event to that span. Each
+// record carries a per-process audit.sequence number and an audit.hash of the
+// event, attrs included, chained with the previous one. An error is returned, and nothing
+// emitted, when action, resource or actor is empty. Under
+// WithoutGlobalRegistration use Client.AuditEvent instead.
+func AuditEvent(ctx context.Context, action, resource, actor string, attrs ...attribute.KeyValue) error {
+	return auditEvent(ctx, global.GetLoggerProvider(), action, resource, actor, attrs...)
+}
+
+// AuditEvent is like the package-level AuditEvent but emits on the client's
+// logger provider. Audit records bypass the WithLogLevel filter and the
+// WithLogRedaction processor, so none are dropped and the exported fields
+// are those audit.hash was computed over.
+func (c *Client) AuditEvent(ctx context.Context, action, resource, actor string, attrs ...attribute.KeyValue) error {
+	return auditEvent(ctx, c.LoggerProvider(), action, resource, actor, attrs...)
+}
+
+func auditEvent(
+	ctx context.Context,
+	provider otelLog.LoggerProvider,
+	action, resource, actor string,
+	attrs ...attribute.KeyValue,
+) error {
+	for _, field := range []struct{ name, value string }{
+		{"action", action},
+		{"resource", resource},
+		{"actor", actor},
+	} {
+		if field.value == "" {
+			return fmt.Errorf("silgotel: audit event %s must not be empty", field.name) //nolint: err113
+		}
+	}
+
+	now := time.Now()
+	extra := canonicalAttrs(attrs)
+
+	auditChain.mu.Lock()
+	auditChain.sequence++
+	sequence := auditChain.sequence
+	hash := sha256.Sum256([]byte(auditChain.lastHash + "\x00" + strconv.FormatInt(sequence, 10) + "\x00" +
+		now.UTC().Format(time.RFC3339Nano) + "\x00" + action + "\x00" + resource + "\x00" + actor + extra))
+	auditChain.lastHash = hex.EncodeToString(hash[:])
+	digest := auditChain.lastHash
+	auditChain.mu.Unlock()
+
+	eventAttrs := make([]attribute.KeyValue, 0, len(attrs)+5)
+	eventAttrs = append(eventAttrs,
+		AuditActionKey.String(action),
+		AuditResourceKey.String(resource),
+		AuditActorKey.String(actor),
+		AuditSequenceKey.Int64(sequence),
+		AuditHashKey.String(digest),
+	)
+	eventAttrs = append(eventAttrs, attrs...)
+
+	var record otelLog.Record
+
+	record.SetTimestamp(now)
+	record.SetSeverity(otelLog.SeverityInfo)
+	record.SetSeverityText("AUDIT")
+	record.SetEventName("audit")
+	record.SetBody(otelLog.StringValue(actor + " " + action + " " + resource))
+
+	for _, attr := range eventAttrs {
+		record.AddAttributes(otelLog.KeyValueFromAttribute(attr))
+	}
+
+	provider.
+		Logger(AuditScope, otelLog.WithSchemaURL(semconv.SchemaURL)).
+		Emit(ctx, record)
+
+	otelTrace.SpanFromContext(ctx).AddEvent("audit."+action,
+		otelTrace.WithTimestamp(now),
+		otelTrace.WithAttributes(eventAttrs...),
+	)
+
+	return nil
+}
+
+// canonicalAttrs encodes attrs for the audit hash as NUL-prefixed key=value
+// pairs sorted by key, so the hash does not depend on the order the caller
+// passed them in.
+func canonicalAttrs(attrs []attribute.KeyValue) string {
+	sorted := slices.Clone(attrs)
+	slices.SortStableFunc(sorted, func(a, b attribute.KeyValue) int {
+		return strings.Compare(string(a.Key), string(b.Key))
+	})
+
+	var b strings.Builder
+	for _, kv := range sorted {
+		b.WriteString("\x00" + string(kv.Key) + "=" + kv.Value.Emit())
+	}
+
+	return b.String()
+}
+
+// auditRouter sends the records of the AuditScope logger to audit and every
+// other record to the embedded processor, so that the filters applied to
+// ordinary logs neither drop audit events nor alter their hashed fields.
+type auditRouter struct {
+	log.Processor
+
+	audit log.Processor
+}
+
+func (p *auditRouter) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	if param.InstrumentationScope.Name == AuditScope {
+		return p.audit.Enabled(ctx, param)
+	}
+
+	return p.Processor.Enabled(ctx, param)
+}
+
+func (p *auditRouter) OnEmit(ctx context.Context, record *log.Record) error {
+	if record.InstrumentationScope().Name == AuditScope {
+		return p.audit.OnEmit(ctx, record)
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
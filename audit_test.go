@@ -0,0 +1,147 @@
+package silgotel_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+)
+
+func TestAuditEventRequiredFields(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	for field, args := range map[string][3]string{
+		"action":   {"", "patient/123", "user:42"},
+		"resource": {"read", "", "user:42"},
+		"actor":    {"read", "patient/123", ""},
+	} {
+		err := sdk.client.AuditEvent(t.Context(), args[0], args[1], args[2])
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("AuditEvent() without %s error = %v, want one naming it", field, err)
+		}
+	}
+
+	if records := sdk.Logs(t); len(records) != 0 {
+		t.Errorf("exported %d records for invalid audit events, want none", len(records))
+	}
+}
+
+func TestAuditEvent(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	ctx, span := sdk.client.Tracer("audit").Start(t.Context(), "GET /patients/{id}")
+
+	for _, resource := range []string{"patient/123", "patient/456"} {
+		err := sdk.client.AuditEvent(ctx, "read", resource, "user:42",
+			attribute.String("purpose", "treatment"), attribute.String("client.address", "10.0.0.7"))
+		if err != nil {
+			t.Fatalf("AuditEvent() error = %v", err)
+		}
+	}
+
+	span.End()
+
+	records := sdk.Logs(t)
+	if len(records) != 2 {
+		t.Fatalf("exported %d audit records, want 2", len(records))
+	}
+
+	var previous string
+
+	for i, record := range records {
+		if scope := record.InstrumentationScope().Name; scope != silgotel.AuditScope {
+			t.Errorf("record %d scope = %q, want %q", i, scope, silgotel.AuditScope)
+		}
+
+		if record.Severity() != otelLog.SeverityInfo || record.TraceID() != span.SpanContext().TraceID() ||
+			record.SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("record %d severity, trace, span = %v, %s, %s, want info correlated with the request span",
+				i, record.Severity(), record.TraceID(), record.SpanID())
+		}
+
+		if address, _ := logAttr(record, "client.address"); address.AsString() != "10.0.0.7" {
+			t.Errorf("record %d client.address = %q, want the extra attributes kept", i, address.AsString())
+		}
+
+		sequence, _ := logAttr(record, string(silgotel.AuditSequenceKey))
+		hash, _ := logAttr(record, string(silgotel.AuditHashKey))
+		resource, _ := logAttr(record, string(silgotel.AuditResourceKey))
+
+		if i > 0 {
+			first, _ := logAttr(records[0], string(silgotel.AuditSequenceKey))
+			if sequence.AsInt64() != first.AsInt64()+1 {
+				t.Errorf("audit.sequence = %d after %d, want consecutive numbers", sequence.AsInt64(), first.AsInt64())
+			}
+		}
+
+		// Each hash covers the event, its extra attributes in key order, and
+		// the hash before it. The first event of this test chains onto
+		// whatever the process audited earlier.
+		if i > 0 {
+			digest := func(address string) string {
+				sum := sha256.Sum256([]byte(previous + "\x00" + strconv.FormatInt(sequence.AsInt64(), 10) + "\x00" +
+					record.Timestamp().UTC().Format(time.RFC3339Nano) + "\x00read\x00" + resource.AsString() +
+					"\x00user:42\x00client.address=" + address + "\x00purpose=treatment"))
+
+				return hex.EncodeToString(sum[:])
+			}
+
+			if want := digest("10.0.0.7"); hash.AsString() != want {
+				t.Errorf("audit.hash = %s, want %s chained onto the previous event", hash.AsString(), want)
+			}
+
+			if hash.AsString() == digest("10.0.0.8") {
+				t.Error("audit.hash is unchanged by a different client.address, want the attributes covered")
+			}
+		}
+
+		previous = hash.AsString()
+	}
+
+	spans := sdk.Spans(t)
+	if len(spans) != 1 || len(spans[0].Events) != 2 {
+		t.Fatalf("spans = %v, want the request span with 2 audit events", spans)
+	}
+
+	event := spans[0].Events[0]
+	if event.Name != "audit.read" {
+		t.Errorf("span event = %q, want audit.read", event.Name)
+	}
+
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range event.Attributes {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+
+	if attrs[silgotel.AuditActorKey] != "user:42" || attrs[silgotel.AuditResourceKey] != "patient/123" ||
+		attrs[silgotel.AuditSequenceKey] == "" {
+		t.Errorf("span event attributes = %v, want actor, resource and sequence", attrs)
+	}
+}
+
+func TestAuditEventBypassesLogFilters(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithLogLevel(slog.LevelError),
+		silgotel.WithLogRedaction(nil, []string{string(silgotel.AuditActorKey)}),
+	)
+
+	if err := sdk.client.AuditEvent(t.Context(), "read", "patient/123", "jane@example.com"); err != nil {
+		t.Fatalf("AuditEvent() error = %v", err)
+	}
+
+	records := sdk.Logs(t)
+	if len(records) != 1 {
+		t.Fatalf("exported %d audit records under WithLogLevel(Error), want 1", len(records))
+	}
+
+	if actor, _ := logAttr(records[0], string(silgotel.AuditActorKey)); actor.AsString() != "jane@example.com" {
+		t.Errorf("audit.actor = %q, want the actor left unredacted", actor.AsString())
+	}
+}
@@ -0,0 +1,155 @@
+package silgotel
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// cacheKey identifies a cached tracer, meter, logger or instrument. The
+// global provider is part of the key so that replacing it, as NewOtelSDK and
+// the siltest package do, yields fresh values instead of stale ones. Cached
+// scopes carry the version of the Client that registered the globals and
+// the package's semantic conventions schema URL, like Client.Tracer. Entries
+// are evicted when a Client shuts down the provider they were created from.
+type cacheKey struct {
+	provider any
+	scope    string
+	version  string
+	name     string
+}
+
+//nolint:gochecknoglobals
+var (
+	tracerCache sync.Map // cacheKey -> otelTrace.Tracer
+	meterCache  sync.Map // cacheKey -> otelMetric.Meter
+	loggerCache sync.Map // cacheKey -> *slog.Logger
+
+	// meterOwners maps the meters handed out by cachedMeter and Client.Meter
+	// to their provider, so that the instruments cached per meter can be
+	// evicted with it.
+	meterOwners sync.Map // otelMetric.Meter -> meter provider
+
+	// globalVersion is the Version of the Client that registered the
+	// globals, the instrumentation version of the cached scopes.
+	globalVersion atomic.Pointer[string]
+)
+
+// scopeVersion returns the instrumentation version of the cached scopes.
+func scopeVersion() string {
+	if version := globalVersion.Load(); version != nil {
+		return *version
+	}
+
+	return ""
+}
+
+// installScopeVersion makes the client's Version that of the cached scopes
+// and returns a function restoring the previous one.
+func (c *Client) installScopeVersion() func() {
+	if !c.registerGlobals() {
+		return func() {}
+	}
+
+	version := c.Version
+	previous := globalVersion.Swap(&version)
+
+	return func() {
+		globalVersion.Store(previous)
+	}
+}
+
+// cachedTracer returns the tracer named scope from the global tracer
+// provider.
+//
+//nolint:ireturn,forcetypeassert
+func cachedTracer(scope string) otelTrace.Tracer {
+	provider := otel.GetTracerProvider()
+	key := cacheKey{provider: provider, scope: scope, version: scopeVersion()}
+
+	if tracer, ok := tracerCache.Load(key); ok {
+		return tracer.(otelTrace.Tracer)
+	}
+
+	tracer, _ := tracerCache.LoadOrStore(key, provider.Tracer(scope,
+		otelTrace.WithInstrumentationVersion(key.version),
+		otelTrace.WithSchemaURL(semconv.SchemaURL),
+	))
+
+	return tracer.(otelTrace.Tracer)
+}
+
+// cachedMeter returns the meter named scope from the global meter provider.
+//
+//nolint:ireturn,forcetypeassert
+func cachedMeter(scope string) otelMetric.Meter {
+	provider := otel.GetMeterProvider()
+	key := cacheKey{provider: provider, scope: scope, version: scopeVersion()}
+
+	if meter, ok := meterCache.Load(key); ok {
+		return meter.(otelMetric.Meter)
+	}
+
+	meter, _ := meterCache.LoadOrStore(key, provider.Meter(scope,
+		otelMetric.WithInstrumentationVersion(key.version),
+		otelMetric.WithSchemaURL(semconv.SchemaURL),
+	))
+	meterOwners.LoadOrStore(meter, provider)
+
+	return meter.(otelMetric.Meter)
+}
+
+// cachedLogger returns the correlated slog.Logger named scope on the global
+// logger provider.
+//
+//nolint:forcetypeassert
+func cachedLogger(scope string) *slog.Logger {
+	provider := global.GetLoggerProvider()
+	key := cacheKey{provider: provider, scope: scope, version: scopeVersion()}
+
+	if logger, ok := loggerCache.Load(key); ok {
+		return logger.(*slog.Logger)
+	}
+
+	logger := slog.New(newSlogHandler(provider, scope, key.version))
+	actual, _ := loggerCache.LoadOrStore(key, logger)
+
+	return actual.(*slog.Logger)
+}
+
+// evictProviders drops the cached tracers, meters, loggers and instruments
+// created from providers, so that shut down providers and what they created
+// can be garbage collected. Instruments cached per meter are dropped with
+// the meters' provider.
+func evictProviders(providers ...any) {
+	owners := slices.Clone(providers)
+
+	meterOwners.Range(func(meter, provider any) bool {
+		if slices.Contains(providers, provider) {
+			owners = append(owners, meter)
+			meterOwners.Delete(meter)
+		}
+
+		return true
+	})
+
+	for _, cache := range []*sync.Map{
+		&tracerCache, &meterCache, &loggerCache,
+		&histogramCache, &upDownCache, &gaugeCache, &errorCounterCache,
+	} {
+		cache.Range(func(key, _ any) bool {
+			if slices.Contains(owners, key.(cacheKey).provider) { //nolint:forcetypeassert
+				cache.Delete(key)
+			}
+
+			return true
+		})
+	}
+}
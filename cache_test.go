@@ -0,0 +1,116 @@
+package silgotel_test
+
+import (
+	"context"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// useNoopProviders installs no-op global providers, so that measurements
+// only count what this package allocates, and restores the previous ones
+// when the test finishes.
+func useNoopProviders(tb testing.TB) {
+	tb.Helper()
+
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	loggerProvider := global.GetLoggerProvider()
+
+	otel.SetTracerProvider(tracenoop.NewTracerProvider())
+	otel.SetMeterProvider(metricnoop.NewMeterProvider())
+	global.SetLoggerProvider(lognoop.NewLoggerProvider())
+
+	tb.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+		global.SetLoggerProvider(loggerProvider)
+	})
+}
+
+func traceOnce(ctx context.Context) {
+	_, span := silgotel.Trace(ctx, "bench", "op")
+	span.End()
+}
+
+func logOnce(ctx context.Context) {
+	silgotel.LogInfo(ctx, "bench", "order placed", "order.id", 42)
+}
+
+func counterOnce(ctx context.Context) {
+	counter, _ := silgotel.Meter("bench").Int64Counter("orders")
+	counter.Add(ctx, 1)
+}
+
+func measureOnce(ctx context.Context) {
+	_ = silgotel.MeasureDuration(ctx, "bench", "op", func(context.Context) error { return nil })
+}
+
+// TestHotPathAllocations guards the allocations the helpers add on top of
+// the OTel API. The budgets are the counts measured when caching was
+// introduced: Trace's only allocation is the no-op span's context, and the
+// log helpers, which cost 3 allocations per call before the logger cache,
+// allocate nothing for a disabled record.
+func TestHotPathAllocations(t *testing.T) {
+	useNoopProviders(t)
+
+	tests := []struct {
+		name   string
+		fn     func(context.Context)
+		budget float64
+	}{
+		{"Trace", traceOnce, 1},
+		{"LogInfo", logOnce, 0},
+		{"Meter counter", counterOnce, 0},
+		{"MeasureDuration", measureOnce, 6},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		if allocs := testing.AllocsPerRun(100, func() { tt.fn(ctx) }); allocs > tt.budget {
+			t.Errorf("%s allocates %v times per call, want at most %v", tt.name, allocs, tt.budget)
+		}
+	}
+}
+
+func BenchmarkTrace(b *testing.B) {
+	useNoopProviders(b)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		traceOnce(b.Context())
+	}
+}
+
+func BenchmarkLogInfo(b *testing.B) {
+	useNoopProviders(b)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		logOnce(b.Context())
+	}
+}
+
+func BenchmarkMeterCounter(b *testing.B) {
+	useNoopProviders(b)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		counterOnce(b.Context())
+	}
+}
+
+func BenchmarkMeasureDuration(b *testing.B) {
+	useNoopProviders(b)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		measureOnce(b.Context())
+	}
+}
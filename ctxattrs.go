@@ -0,0 +1,93 @@
+package silgotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+type ctxAttributesKey struct{}
+
+// AppendCtxAttributes returns a copy of ctx carrying attrs on top of those
+// accumulated by earlier calls, with later values winning on key collisions.
+// The attributes are set on the span active in ctx straight away, and on
+// every span later started from the returned context, so details learned
+// deep in a call stack reach the enclosing span without passing it around.
+func AppendCtxAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	existing := CtxAttributes(ctx)
+	merged := make([]attribute.KeyValue, 0, len(existing)+len(attrs))
+
+	replaced := make(map[attribute.Key]struct{}, len(attrs))
+	for _, attr := range attrs {
+		replaced[attr.Key] = struct{}{}
+	}
+
+	for _, attr := range existing {
+		if _, ok := replaced[attr.Key]; !ok {
+			merged = append(merged, attr)
+		}
+	}
+
+	merged = append(merged, attrs...)
+
+	otelTrace.SpanFromContext(ctx).SetAttributes(attrs...)
+
+	return context.WithValue(ctx, ctxAttributesKey{}, merged)
+}
+
+// CtxAttributes returns the attributes accumulated in ctx by
+// AppendCtxAttributes.
+func CtxAttributes(ctx context.Context) []attribute.KeyValue {
+	attrs, _ := ctx.Value(ctxAttributesKey{}).([]attribute.KeyValue)
+
+	return attrs
+}
+
+// FlushCtxAttributesToSpan sets every attribute accumulated in ctx on the
+// span active in ctx, for spans started by tracers that neither the
+// package's helpers nor NewCtxAttributesProcessor cover.
+func FlushCtxAttributesToSpan(ctx context.Context) {
+	if attrs := CtxAttributes(ctx); len(attrs) > 0 {
+		otelTrace.SpanFromContext(ctx).SetAttributes(attrs...)
+	}
+}
+
+// ctxAttributesOption passes the attributes accumulated in ctx to a span
+// started by one of the package's helpers, so samplers see them too.
+func ctxAttributesOption(ctx context.Context) otelTrace.SpanStartOption {
+	return otelTrace.WithAttributes(CtxAttributes(ctx)...)
+}
+
+// NewCtxAttributesProcessor returns a span processor that sets the
+// attributes accumulated by AppendCtxAttributes on spans as they start. The
+// tracer provider built by NewOtelSDK already includes it; register it on
+// other providers to cover spans started there.
+//
+//nolint:ireturn
+func NewCtxAttributesProcessor() trace.SpanProcessor {
+	return ctxAttributesProcessor{}
+}
+
+type ctxAttributesProcessor struct{}
+
+func (ctxAttributesProcessor) OnStart(ctx context.Context, s trace.ReadWriteSpan) {
+	if attrs := CtxAttributes(ctx); len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+func (ctxAttributesProcessor) OnEnd(trace.ReadOnlySpan) {}
+
+func (ctxAttributesProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (ctxAttributesProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,102 @@
+package silgotel_test
+
+import (
+	"context"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttrs returns the attributes of span as strings keyed by name.
+func spanAttrs(span trace.ReadOnlySpan) map[string]string {
+	attrs := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return attrs
+}
+
+func loadUser(ctx context.Context) context.Context {
+	return silgotel.AppendCtxAttributes(ctx, attribute.String("user.id", "u-1"), attribute.String("order.status", "new"))
+}
+
+func chargeOrder(ctx context.Context) context.Context {
+	ctx = silgotel.AppendCtxAttributes(ctx, attribute.String("order.id", "o-7"))
+
+	return silgotel.AppendCtxAttributes(ctx, attribute.String("order.status", "paid"))
+}
+
+func TestAppendCtxAttributes(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "orders", "checkout")
+	ctx = chargeOrder(loadUser(ctx))
+
+	_, child := silgotel.Trace(ctx, "orders", "receipt")
+	child.End()
+	span.End()
+
+	want := map[string]string{"user.id": "u-1", "order.id": "o-7", "order.status": "paid"}
+
+	for _, recorded := range sdk.Spans() {
+		attrs := spanAttrs(recorded)
+
+		for key, value := range want {
+			if attrs[key] != value {
+				t.Errorf("%s %s = %q, want %q", recorded.Name(), key, attrs[key], value)
+			}
+		}
+	}
+
+	if got := silgotel.CtxAttributes(ctx); len(got) != len(want) {
+		t.Errorf("CtxAttributes() = %v, want one attribute per key", got)
+	}
+}
+
+func TestCtxAttributesProcessor(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	ctx := loadUser(t.Context())
+
+	_, span := sdk.client.Tracer("orders").Start(ctx, "lookup")
+	span.End()
+
+	spans := sdk.Spans(t)
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+
+	var userID string
+
+	for _, kv := range spans[0].Attributes {
+		if kv.Key == "user.id" {
+			userID = kv.Value.AsString()
+		}
+	}
+
+	if userID != "u-1" {
+		t.Errorf("user.id = %q on a span started by a raw tracer, want u-1", userID)
+	}
+}
+
+func TestFlushCtxAttributesToSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx := loadUser(t.Context())
+
+	ctx, span := provider.Tracer("elsewhere").Start(ctx, "lookup")
+	silgotel.FlushCtxAttributesToSpan(ctx)
+	span.End()
+
+	if attrs := spanAttrs(recorder.Ended()[0]); attrs["user.id"] != "u-1" || attrs["order.status"] != "new" {
+		t.Errorf("attributes = %v, want the context's user.id and order.status", attrs)
+	}
+}
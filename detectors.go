@@ -0,0 +1,294 @@
+package silgotel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+const (
+	ec2MetadataURL     = "http://169.254.169.254/latest"
+	awsMetadataTimeout = time.Second
+
+	k8sNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// WithResourceDetectors adds the attributes found by detectors to the
+// resource shared by every signal. Detected attributes rank below those set
+// through WithResourceAttributes, OTEL_RESOURCE_ATTRIBUTES and the Client.
+// A detector that fails is logged and skipped; the attributes of the others
+// are kept. It can be repeated.
+func WithResourceDetectors(detectors ...resource.Detector) Option {
+	return func(o *options) {
+		o.resourceDetectors = append(o.resourceDetectors, detectors...)
+	}
+}
+
+// WithCloudDetection runs GCPDetector, AWSDetector and KubernetesDetector so
+// that spans, metrics and logs carry the cloud.*, faas.*, host.* and k8s.*
+// attributes of the platform the service runs on. Off those platforms the
+// probes of the metadata servers delay startup by up to a few seconds.
+func WithCloudDetection() Option {
+	return WithResourceDetectors(GCPDetector(), AWSDetector(), KubernetesDetector())
+}
+
+// GCPDetector returns the OpenTelemetry detector for Google Cloud, covering
+// Cloud Run, Cloud Functions, App Engine, GKE and Compute Engine.
+//
+//nolint:ireturn
+func GCPDetector() resource.Detector {
+	return gcp.NewDetector()
+}
+
+// AWSDetector returns a detector for AWS Lambda, ECS and EC2. Lambda is
+// recognized from its environment, ECS from the task metadata endpoint and
+// EC2 from the instance metadata service, which also identifies EKS nodes.
+//
+//nolint:ireturn
+func AWSDetector() resource.Detector {
+	return awsDetector{client: &http.Client{Timeout: awsMetadataTimeout}, ec2URL: ec2MetadataURL}
+}
+
+// KubernetesDetector returns a detector for the k8s.* attributes of the pod
+// the service runs in. The namespace is read from the service account, the
+// pod name from HOSTNAME, and the pod UID and node name from the K8S_POD_UID
+// and K8S_NODE_NAME variables, which the pod spec should set through the
+// downward API.
+//
+//nolint:ireturn
+func KubernetesDetector() resource.Detector {
+	return k8sDetector{}
+}
+
+// detectedResource runs the configured detectors.
+func (c *Client) detectedResource(ctx context.Context) *resource.Resource {
+	if len(c.options.resourceDetectors) == 0 {
+		return resource.Empty()
+	}
+
+	res, err := resource.New(ctx, resource.WithDetectors(c.options.resourceDetectors...))
+	if err != nil {
+		slog.WarnContext(ctx, "silgotel: resource detection failed", "err", err)
+	}
+
+	if res == nil {
+		return resource.Empty()
+	}
+
+	return res
+}
+
+type awsDetector struct {
+	client *http.Client
+
+	// ec2URL is the base URL of the instance metadata service.
+	ec2URL string
+}
+
+func (d awsDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if name := os.Getenv("AWS_LAMBDA_FUNCTION_NAME"); name != "" {
+		return d.lambda(name), nil
+	}
+
+	if uri := os.Getenv("ECS_CONTAINER_METADATA_URI_V4"); uri != "" {
+		return d.ecs(ctx, uri)
+	}
+
+	return d.ec2(ctx)
+}
+
+func (awsDetector) lambda(name string) *resource.Resource {
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+		semconv.CloudRegion(os.Getenv("AWS_REGION")),
+		semconv.FaaSName(name),
+		semconv.FaaSVersion(os.Getenv("AWS_LAMBDA_FUNCTION_VERSION")),
+		semconv.FaaSInstance(os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME")),
+	}
+
+	memory, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE"))
+	if err == nil {
+		attrs = append(attrs, semconv.FaaSMaxMemory(memory<<20)) //nolint:mnd
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, nonEmpty(attrs)...)
+}
+
+func (d awsDetector) ecs(ctx context.Context, uri string) (*resource.Resource, error) {
+	var container struct {
+		DockerID string `json:"DockerId"`
+		Name     string `json:"Name"`
+	}
+
+	err := d.getJSON(ctx, uri, nil, &container)
+	if err != nil {
+		return nil, fmt.Errorf("silgotel: reading ECS container metadata: %w", err)
+	}
+
+	var task struct {
+		Cluster          string `json:"Cluster"`
+		TaskARN          string `json:"TaskARN"`
+		Family           string `json:"Family"`
+		Revision         string `json:"Revision"`
+		AvailabilityZone string `json:"AvailabilityZone"`
+		LaunchType       string `json:"LaunchType"`
+	}
+
+	err = d.getJSON(ctx, uri+"/task", nil, &task)
+	if err != nil {
+		return nil, fmt.Errorf("silgotel: reading ECS task metadata: %w", err)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.CloudAvailabilityZone(task.AvailabilityZone),
+		semconv.AWSECSClusterARN(task.Cluster),
+		semconv.AWSECSTaskARN(task.TaskARN),
+		semconv.AWSECSTaskFamily(task.Family),
+		semconv.AWSECSTaskRevision(task.Revision),
+		semconv.ContainerID(container.DockerID),
+		semconv.ContainerName(container.Name),
+	}
+
+	if task.LaunchType != "" {
+		attrs = append(attrs, semconv.AWSECSLaunchtypeKey.String(strings.ToLower(task.LaunchType)))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, nonEmpty(attrs)...), nil
+}
+
+// ec2 reads the instance identity document through IMDSv2. Failing to reach
+// the metadata service means the process does not run on EC2.
+func (d awsDetector) ec2(ctx context.Context) (*resource.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, awsMetadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.ec2URL+"/api/token", nil)
+	if err != nil {
+		return nil, fmt.Errorf("silgotel: requesting EC2 metadata token: %w", err)
+	}
+
+	req.Header.Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "60")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return resource.Empty(), nil //nolint:nilerr
+	}
+
+	defer resp.Body.Close()
+
+	token, err := io.ReadAll(io.LimitReader(resp.Body, 1024)) //nolint:mnd
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resource.Empty(), nil //nolint:nilerr
+	}
+
+	var identity struct {
+		AccountID        string `json:"accountId"`
+		AvailabilityZone string `json:"availabilityZone"`
+		Region           string `json:"region"`
+		InstanceID       string `json:"instanceId"`
+		InstanceType     string `json:"instanceType"`
+		ImageID          string `json:"imageId"`
+	}
+
+	err = d.getJSON(ctx, d.ec2URL+"/dynamic/instance-identity/document",
+		http.Header{"X-Aws-Ec2-Metadata-Token": {string(token)}}, &identity)
+	if err != nil {
+		return nil, fmt.Errorf("silgotel: reading EC2 instance identity: %w", err)
+	}
+
+	platform := semconv.CloudPlatformAWSEC2
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		platform = semconv.CloudPlatformAWSEKS
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, nonEmpty([]attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		platform,
+		semconv.CloudAccountID(identity.AccountID),
+		semconv.CloudRegion(identity.Region),
+		semconv.CloudAvailabilityZone(identity.AvailabilityZone),
+		semconv.HostID(identity.InstanceID),
+		semconv.HostType(identity.InstanceType),
+		semconv.HostImageID(identity.ImageID),
+	})...), nil
+}
+
+func (d awsDetector) getJSON(ctx context.Context, url string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status) //nolint: err113
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v) //nolint:mnd
+}
+
+// nonEmpty drops the string attributes of attrs that have no value, such as
+// metadata fields a platform leaves out.
+func nonEmpty(attrs []attribute.KeyValue) []attribute.KeyValue {
+	kept := attrs[:0]
+
+	for _, attr := range attrs {
+		if attr.Value.Type() != attribute.STRING || attr.Value.AsString() != "" {
+			kept = append(kept, attr)
+		}
+	}
+
+	return kept
+}
+
+type k8sDetector struct{}
+
+func (k8sDetector) Detect(context.Context) (*resource.Resource, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return resource.Empty(), nil
+	}
+
+	var attrs []attribute.KeyValue
+
+	namespace, err := os.ReadFile(k8sNamespaceFile)
+	if err == nil {
+		attrs = append(attrs, semconv.K8SNamespaceName(strings.TrimSpace(string(namespace))))
+	}
+
+	for key, env := range map[attribute.Key]string{
+		semconv.K8SPodNameKey:  "HOSTNAME",
+		semconv.K8SPodUIDKey:   "K8S_POD_UID",
+		semconv.K8SNodeNameKey: "K8S_NODE_NAME",
+	} {
+		if value := os.Getenv(env); value != "" {
+			attrs = append(attrs, key.String(value))
+		}
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
@@ -0,0 +1,231 @@
+package silgotel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// clearAWSEnv unsets the variables awsDetector dispatches on.
+func clearAWSEnv(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{"AWS_LAMBDA_FUNCTION_NAME", "ECS_CONTAINER_METADATA_URI_V4", "KUBERNETES_SERVICE_HOST"} {
+		t.Setenv(name, "")
+	}
+}
+
+func assertAttributes(t *testing.T, res *resource.Resource, want map[string]string) {
+	t.Helper()
+
+	for key, value := range want {
+		got, ok := res.Set().Value(attribute.Key(key))
+		if !ok {
+			t.Errorf("resource has no %s attribute", key)
+
+			continue
+		}
+
+		if got.Emit() != value {
+			t.Errorf("%s = %q, want %q", key, got.Emit(), value)
+		}
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func TestAWSDetectorLambda(t *testing.T) {
+	clearAWSEnv(t)
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "billing")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	res, err := AWSDetector().Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	assertAttributes(t, res, map[string]string{
+		"cloud.provider":  "aws",
+		"cloud.platform":  "aws_lambda",
+		"cloud.region":    "eu-west-1",
+		"faas.name":       "billing",
+		"faas.version":    "$LATEST",
+		"faas.max_memory": "134217728",
+	})
+}
+
+func TestAWSDetectorECS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4":
+			writeJSON(t, w, map[string]string{"DockerId": "abc123", "Name": "api"})
+		case "/v4/task":
+			writeJSON(t, w, map[string]string{
+				"Cluster":          "arn:aws:ecs:eu-west-1:1:cluster/prod",
+				"TaskARN":          "arn:aws:ecs:eu-west-1:1:task/prod/42",
+				"Family":           "api",
+				"Revision":         "7",
+				"AvailabilityZone": "eu-west-1a",
+				"LaunchType":       "FARGATE",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	clearAWSEnv(t)
+	t.Setenv("ECS_CONTAINER_METADATA_URI_V4", server.URL+"/v4")
+
+	res, err := awsDetector{client: server.Client()}.Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	assertAttributes(t, res, map[string]string{
+		"cloud.platform":          "aws_ecs",
+		"cloud.availability_zone": "eu-west-1a",
+		"aws.ecs.cluster.arn":     "arn:aws:ecs:eu-west-1:1:cluster/prod",
+		"aws.ecs.task.revision":   "7",
+		"aws.ecs.launchtype":      "fargate",
+		"container.id":            "abc123",
+		"container.name":          "api",
+	})
+}
+
+func TestAWSDetectorECSMetadataError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	clearAWSEnv(t)
+	t.Setenv("ECS_CONTAINER_METADATA_URI_V4", server.URL)
+
+	_, err := awsDetector{client: server.Client()}.Detect(t.Context())
+	if err == nil {
+		t.Fatal("Detect() error = nil, want the failed metadata request")
+	}
+}
+
+func newIMDSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/api/token":
+			_, _ = w.Write([]byte("token"))
+		case r.URL.Path == "/dynamic/instance-identity/document":
+			if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			writeJSON(t, w, map[string]string{
+				"accountId":        "123456789012",
+				"availabilityZone": "eu-west-1b",
+				"region":           "eu-west-1",
+				"instanceId":       "i-0abc",
+				"instanceType":     "t3.small",
+				"imageId":          "ami-1",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestAWSDetectorEC2(t *testing.T) {
+	server := newIMDSServer(t)
+	defer server.Close()
+
+	clearAWSEnv(t)
+
+	res, err := awsDetector{client: server.Client(), ec2URL: server.URL}.Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	assertAttributes(t, res, map[string]string{
+		"cloud.platform":          "aws_ec2",
+		"cloud.account.id":        "123456789012",
+		"cloud.region":            "eu-west-1",
+		"cloud.availability_zone": "eu-west-1b",
+		"host.id":                 "i-0abc",
+		"host.type":               "t3.small",
+		"host.image.id":           "ami-1",
+	})
+}
+
+func TestAWSDetectorEKS(t *testing.T) {
+	server := newIMDSServer(t)
+	defer server.Close()
+
+	clearAWSEnv(t)
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+
+	res, err := awsDetector{client: server.Client(), ec2URL: server.URL}.Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	assertAttributes(t, res, map[string]string{"cloud.platform": "aws_eks"})
+}
+
+func TestAWSDetectorOffAWS(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	clearAWSEnv(t)
+
+	res, err := awsDetector{client: &http.Client{}, ec2URL: server.URL}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v, want nil off AWS", err)
+	}
+
+	if res.Len() != 0 {
+		t.Errorf("Detect() = %v, want an empty resource", res.Attributes())
+	}
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	res, err := KubernetesDetector().Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	if res.Len() != 0 {
+		t.Errorf("Detect() outside a cluster = %v, want an empty resource", res.Attributes())
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("HOSTNAME", "api-7d9f")
+	t.Setenv("K8S_POD_UID", "uid-1")
+	t.Setenv("K8S_NODE_NAME", "node-a")
+
+	res, err = KubernetesDetector().Detect(t.Context())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	assertAttributes(t, res, map[string]string{
+		"k8s.pod.name":  "api-7d9f",
+		"k8s.pod.uid":   "uid-1",
+		"k8s.node.name": "node-a",
+	})
+}
@@ -0,0 +1,89 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/stdr"
+	"go.opentelemetry.io/otel"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+// installDiagnostics sends the OTel SDK's internal logs to an slog text
+// handler when WithDiagnostics is used and returns a function restoring the
+// SDK's default logger. Without WithDiagnostics the process-global logger is
+// left untouched.
+func (c *Client) installDiagnostics() func() {
+	if c.options.diagnosticsLevel <= 0 {
+		return func() {}
+	}
+
+	w := c.options.diagnosticsWriter
+	if w == nil {
+		w = os.Stderr
+	}
+
+	otel.SetLogger(newDiagnosticsLogger(w, c.options.diagnosticsLevel))
+
+	return func() {
+		otel.SetLogger(defaultDiagnosticsLogger())
+	}
+}
+
+// defaultDiagnosticsLogger mirrors the logger the OTel SDK starts with.
+func defaultDiagnosticsLogger() logr.Logger {
+	return stdr.New(log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile))
+}
+
+// newDiagnosticsLogger returns a logr.Logger enabled up to verbosity level.
+// logr maps V(n) to slog level -n, so the handler's minimum level is -level.
+func newDiagnosticsLogger(w io.Writer, level int) logr.Logger {
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: slog.Level(-level),
+	})
+
+	return logr.FromSlogHandler(handler)
+}
+
+// droppedLogRecord returns the callback counting records dropped by the
+// log batch processors on silgotel.log.dropped.records. The first drop of
+// each overflow is also reported to the OTel error handler. The counter is
+// created on first use because the log processors are built before the meter
+// provider exists.
+func (c *Client) droppedLogRecord() func(context.Context, bool) {
+	var (
+		once    sync.Once
+		dropped otelMetric.Int64Counter
+	)
+
+	return func(ctx context.Context, first bool) {
+		once.Do(func() {
+			dropped = mustInstrument(c.Meter(instrumentationScope).Int64Counter(
+				"silgotel.log.dropped.records",
+				otelMetric.WithDescription("Number of log records dropped because the export queue was full"),
+				otelMetric.WithUnit("{record}"),
+			))
+		})
+
+		dropped.Add(ctx, 1)
+
+		if first {
+			otel.Handle(fmt.Errorf("silgotel: log export queue is full, dropping records; consider raising LogMaxQueueSize")) //nolint: err113
+		}
+	}
+}
+
+// droppedSpan counts a span dropped by a batch span processor on
+// silgotel.spans.dropped.
+func (c *Client) droppedSpan(ctx context.Context, _ bool) {
+	if c.exportMetrics != nil {
+		c.exportMetrics.init()
+		c.exportMetrics.spansDropped.Add(ctx, 1)
+	}
+}
@@ -0,0 +1,93 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the SDK's concurrent writes.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// diagnostics exports a span to a rejecting collector with WithDiagnostics
+// at level and returns the diagnostic output written before shutdown, and
+// the output written by an SDK provider created after it.
+func diagnostics(t *testing.T, level int) (during, after string) {
+	t.Helper()
+
+	var out lockedBuffer
+
+	client := rejectingClient(t)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithDiagnostics(level),
+		silgotel.WithDiagnosticsWriter(&out),
+		silgotel.WithErrorHandler(func(error) {}),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_, span := client.Tracer("diagnostics").Start(t.Context(), "rejected")
+	span.End()
+
+	if err := client.ForceFlush(t.Context()); err == nil {
+		t.Error("ForceFlush() = nil, want the rejected export's error")
+	}
+
+	_ = shutdown(t.Context())
+
+	during = out.String()
+
+	_ = trace.NewTracerProvider().Shutdown(t.Context())
+
+	return during, strings.TrimPrefix(out.String(), during)
+}
+
+func TestDiagnosticsDebug(t *testing.T) {
+	during, after := diagnostics(t, 8)
+
+	for _, want := range []string{"TracerProvider created", "exporting spans", "OTLP/HTTP exporter export"} {
+		if !strings.Contains(during, want) {
+			t.Errorf("diagnostic output lacks %q:\n%s", want, during)
+		}
+	}
+
+	if after != "" {
+		t.Errorf("diagnostics still written after shutdown:\n%s", after)
+	}
+}
+
+func TestDiagnosticsInfo(t *testing.T) {
+	during, _ := diagnostics(t, 4)
+
+	if !strings.Contains(during, "TracerProvider created") {
+		t.Errorf("diagnostic output lacks the informational messages:\n%s", during)
+	}
+
+	if strings.Contains(during, "exporting spans") {
+		t.Errorf("diagnostic output at level 4 holds debug messages:\n%s", during)
+	}
+}
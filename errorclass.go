@@ -0,0 +1,156 @@
+package silgotel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// ErrorClassKey is the attribute carrying the class of a recorded error.
+const ErrorClassKey = attribute.Key("error.class")
+
+// Error classes returned by the default classifier.
+const (
+	ErrorClassTimeout    = "timeout"
+	ErrorClassCanceled   = "canceled"
+	ErrorClassValidation = "validation"
+	ErrorClassDatabase   = "database"
+	ErrorClassAuth       = "auth"
+	ErrorClassUnknown    = "unknown"
+)
+
+//nolint:gochecknoglobals
+var (
+	errorClassifier   atomic.Pointer[func(error) string]
+	errorCounterCache sync.Map // cacheKey -> otelMetric.Int64Counter
+)
+
+// ClassedError is implemented by errors that know their class. The default
+// classifier finds it anywhere in the error chain, so domain error types can
+// declare themselves as, say, ErrorClassAuth.
+type ClassedError interface {
+	error
+	ErrorClass() string
+}
+
+type classedError struct {
+	error
+
+	class string
+}
+
+func (e *classedError) ErrorClass() string {
+	return e.class
+}
+
+func (e *classedError) Unwrap() error {
+	return e.error
+}
+
+// WithErrorClass wraps err so that the default classifier reports class for
+// it. A nil err is returned as is.
+func WithErrorClass(err error, class string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &classedError{error: err, class: class}
+}
+
+// ClassifyError returns the class of err using the classifier set with
+// WithErrorClassifier, or DefaultErrorClassifier when none is set.
+func ClassifyError(err error) string {
+	if classify := errorClassifier.Load(); classify != nil {
+		return (*classify)(err)
+	}
+
+	return DefaultErrorClassifier(err)
+}
+
+// DefaultErrorClassifier recognizes a ClassedError in the chain, deadline
+// and network timeouts, cancellation, validator errors and database/sql
+// errors, and returns ErrorClassUnknown for anything else. Custom
+// classifiers can fall back to it.
+func DefaultErrorClassifier(err error) string {
+	var (
+		classed    ClassedError
+		netErr     net.Error
+		validation validator.ValidationErrors
+		invalid    *validator.InvalidValidationError
+	)
+
+	switch {
+	case errors.As(err, &classed):
+		return classed.ErrorClass()
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.As(err, &validation), errors.As(err, &invalid):
+		return ErrorClassValidation
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, sql.ErrConnDone),
+		errors.Is(err, sql.ErrTxDone), errors.Is(err, driver.ErrBadConn):
+		return ErrorClassDatabase
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+// installErrorClassifier makes the package's error helpers use the client's
+// classifier and returns a function restoring the previous one.
+func (c *Client) installErrorClassifier() func() {
+	if c.options.errorClassifier == nil || !c.registerGlobals() {
+		return func() {}
+	}
+
+	previous := errorClassifier.Swap(&c.options.errorClassifier)
+
+	return func() {
+		errorClassifier.Store(previous)
+	}
+}
+
+// classifyError returns the error.type and error.class attributes of err
+// and counts it on silgotel.errors.
+func classifyError(ctx context.Context, err error) []attribute.KeyValue {
+	class := ClassifyError(err)
+
+	meter := cachedMeter(instrumentationScope)
+
+	counter, cerr := cachedInstrument(&errorCounterCache, meter, "silgotel.errors",
+		func() (otelMetric.Int64Counter, error) {
+			return meter.Int64Counter(
+				"silgotel.errors",
+				otelMetric.WithDescription("Number of errors recorded through the package's helpers"),
+				otelMetric.WithUnit("1"),
+			)
+		},
+	)
+	if cerr != nil {
+		otel.Handle(cerr)
+	} else {
+		counter.Add(ctx, 1, otelMetric.WithAttributes(ErrorClassKey.String(class)))
+	}
+
+	// Report the wrapped error's type rather than the WithErrorClass wrapper.
+	typed := err
+	if classed, ok := err.(*classedError); ok { //nolint: errorlint
+		typed = classed.error
+	}
+
+	return []attribute.KeyValue{
+		semconv.ErrorTypeKey.String(fmt.Sprintf("%T", typed)),
+		ErrorClassKey.String(class),
+	}
+}
@@ -0,0 +1,125 @@
+package silgotel_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// netError is a net.Error whose Timeout is configurable.
+type netError struct{ timeout bool }
+
+func (e netError) Error() string   { return "network error" }
+func (e netError) Timeout() bool   { return e.timeout }
+func (e netError) Temporary() bool { return false }
+
+func TestDefaultErrorClassifier(t *testing.T) {
+	type order struct {
+		ID string `validate:"required"`
+	}
+
+	validationErr := validator.New().Struct(order{})
+	invalidErr := validator.New().Struct(nil)
+
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"deadline":           {err: context.DeadlineExceeded, want: silgotel.ErrorClassTimeout},
+		"wrapped deadline":   {err: fmt.Errorf("calling payments: %w", context.DeadlineExceeded), want: silgotel.ErrorClassTimeout},
+		"network timeout":    {err: fmt.Errorf("dial: %w", netError{timeout: true}), want: silgotel.ErrorClassTimeout},
+		"network error":      {err: netError{}, want: silgotel.ErrorClassUnknown},
+		"canceled":           {err: fmt.Errorf("request: %w", context.Canceled), want: silgotel.ErrorClassCanceled},
+		"validation":         {err: validationErr, want: silgotel.ErrorClassValidation},
+		"invalid validation": {err: invalidErr, want: silgotel.ErrorClassValidation},
+		"no rows":            {err: fmt.Errorf("loading order: %w", sql.ErrNoRows), want: silgotel.ErrorClassDatabase},
+		"bad conn":           {err: driver.ErrBadConn, want: silgotel.ErrorClassDatabase},
+		"classed":            {err: fmt.Errorf("login: %w", silgotel.WithErrorClass(errors.New("bad token"), silgotel.ErrorClassAuth)), want: silgotel.ErrorClassAuth},
+		"other":              {err: errors.New("boom"), want: silgotel.ErrorClassUnknown},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := silgotel.DefaultErrorClassifier(tt.err); got != tt.want {
+				t.Errorf("DefaultErrorClassifier(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordErrorClass(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "orders", "charge")
+	_ = silgotel.RecordError(ctx, silgotel.WithErrorClass(&netError{}, silgotel.ErrorClassAuth))
+	span.End()
+
+	attrs := spanAttrs(sdk.Spans()[0])
+	if attrs["error.class"] != silgotel.ErrorClassAuth || attrs["error.type"] != "*silgotel_test.netError" {
+		t.Errorf("error.class, error.type = %q, %q, want auth and the wrapped error's type",
+			attrs["error.class"], attrs["error.type"])
+	}
+
+	m, ok := findMetric(sdk.Metrics(), "silgotel.errors")
+	if !ok {
+		t.Fatal("silgotel.errors was not recorded")
+	}
+
+	points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 {
+		t.Fatalf("silgotel.errors has %d points, want 1", len(points))
+	}
+
+	if class, _ := points[0].Attributes.Value(silgotel.ErrorClassKey); class.AsString() != silgotel.ErrorClassAuth {
+		t.Errorf("silgotel.errors class = %q, want auth", class.AsString())
+	}
+}
+
+func TestWithErrorClassifier(t *testing.T) {
+	restoreGlobals(t)
+
+	errPayment := errors.New("card declined")
+
+	classify := func(err error) string {
+		if errors.Is(err, errPayment) {
+			return "payment"
+		}
+
+		return silgotel.DefaultErrorClassifier(err)
+	}
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), newClient(t), silgotel.WithErrorClassifier(classify))
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	if got := silgotel.ClassifyError(fmt.Errorf("charging: %w", errPayment)); got != "payment" {
+		t.Errorf("ClassifyError() = %q, want the custom classifier's payment", got)
+	}
+
+	if got := silgotel.ClassifyError(context.DeadlineExceeded); got != silgotel.ErrorClassTimeout {
+		t.Errorf("ClassifyError() = %q, want the default classifier's timeout as fallback", got)
+	}
+
+	_ = shutdown(t.Context())
+
+	if got := silgotel.ClassifyError(errPayment); got != silgotel.ErrorClassUnknown {
+		t.Errorf("ClassifyError() after shutdown = %q, want the default classifier restored", got)
+	}
+}
+
+func TestWithErrorClassifierNotGlobal(t *testing.T) {
+	startSDK(t, newClient(t), silgotel.WithErrorClassifier(func(error) string { return "custom" }))
+
+	if got := silgotel.ClassifyError(errors.New("boom")); got != silgotel.ErrorClassUnknown {
+		t.Errorf("ClassifyError() = %q, want the classifier left alone under WithoutGlobalRegistration", got)
+	}
+}
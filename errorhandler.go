@@ -0,0 +1,103 @@
+package silgotel
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+// errorLogInterval is the minimum time between two export error logs from
+// the default error handler.
+const errorLogInterval = 30 * time.Second
+
+// errorHandler receives errors the OTel SDK cannot return to the caller, most
+// notably export failures. Once closed it falls back to plain logging so a
+// stale handler never outlives its Client's providers.
+type errorHandler struct {
+	handle func(error)
+	closed atomic.Bool
+}
+
+func (h *errorHandler) Handle(err error) {
+	if h.closed.Load() {
+		log.Print(err)
+
+		return
+	}
+
+	h.handle(err)
+}
+
+// installErrorHandler registers the client's error handler globally and
+// returns a function restoring the previous one. Only the first call of the
+// returned function restores it. The global handler is left alone under
+// WithoutGlobalRegistration.
+func (c *Client) installErrorHandler() func() {
+	if !c.registerGlobals() {
+		return func() {}
+	}
+
+	handle := c.options.errorHandler
+	if handle == nil {
+		handle = newDefaultErrorHandler(c.Meter(instrumentationScope)).handle
+	}
+
+	previous := otel.GetErrorHandler()
+	handler := &errorHandler{handle: handle}
+
+	otel.SetErrorHandler(handler)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			handler.closed.Store(true)
+			otel.SetErrorHandler(previous)
+		})
+	}
+}
+
+// defaultErrorHandler counts every error on silgotel.export.errors and logs
+// at most one error per errorLogInterval.
+type defaultErrorHandler struct {
+	errors otelMetric.Int64Counter
+
+	mu         sync.Mutex
+	lastLogged time.Time
+	suppressed int
+}
+
+func newDefaultErrorHandler(meter otelMetric.Meter) *defaultErrorHandler {
+	return &defaultErrorHandler{
+		errors: mustInstrument(meter.Int64Counter(
+			"silgotel.export.errors",
+			otelMetric.WithDescription("Number of errors reported by the OpenTelemetry SDK"),
+			otelMetric.WithUnit("1"),
+		)),
+	}
+}
+
+func (h *defaultErrorHandler) handle(err error) {
+	h.errors.Add(context.Background(), 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(h.lastLogged) < errorLogInterval {
+		h.suppressed++
+
+		return
+	}
+
+	slog.Error("silgotel: opentelemetry error", "err", err, "suppressed", h.suppressed)
+
+	h.lastLogged = now
+	h.suppressed = 0
+}
@@ -0,0 +1,129 @@
+package silgotel_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// rejectingClient returns a client whose collector rejects every export.
+func rejectingClient(t *testing.T) *silgotel.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newClient(t)
+	client.OTLPBaseURL = server.URL
+
+	return client
+}
+
+func TestErrorHandler(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		errs   []error
+		custom = func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			errs = append(errs, err)
+		}
+	)
+
+	restoreGlobals(t)
+
+	previous := otel.GetErrorHandler()
+
+	client := rejectingClient(t)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithErrorHandler(custom),
+		silgotel.WithSyncSpanExport(),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_, span := client.Tracer("errors").Start(t.Context(), "rejected")
+	span.End()
+
+	mu.Lock()
+	got := len(errs)
+	mu.Unlock()
+
+	if got == 0 {
+		t.Error("the error handler received no export error")
+	}
+
+	_ = shutdown(t.Context())
+
+	if otel.GetErrorHandler() != previous {
+		t.Error("shutdown did not restore the previous error handler")
+	}
+}
+
+func TestDefaultErrorHandlerCountsErrors(t *testing.T) {
+	silenceDefaultLogger(t)
+
+	sdk := startGlobalSDK(t, newClient(t))
+
+	otel.Handle(errors.New("export failed"))
+	otel.Handle(errors.New("export failed again"))
+
+	m, ok := findMetric(sdk.Metrics(t), "silgotel.export.errors")
+	if !ok {
+		t.Fatal("silgotel.export.errors was not recorded")
+	}
+
+	if value := m.Data.(metricdata.Sum[int64]).DataPoints[0].Value; value != 2 { //nolint:forcetypeassert
+		t.Errorf("silgotel.export.errors = %d, want 2", value)
+	}
+}
+
+func TestErrorHandlerNotGlobal(t *testing.T) {
+	previous := otel.GetErrorHandler()
+
+	startSDK(t, newClient(t), silgotel.WithErrorHandler(func(error) {
+		t.Error("a client without global registration received a global error")
+	}))
+
+	if otel.GetErrorHandler() != previous {
+		t.Error("a client without global registration replaced the global error handler")
+	}
+
+	otel.Handle(errors.New("another client's export failed"))
+}
+
+func TestErrorHandlerRestoreOnce(t *testing.T) {
+	restoreGlobals(t)
+
+	first, err := silgotel.NewOtelSDK(t.Context(), newClient(t), silgotel.WithErrorHandler(func(error) {}))
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_ = first(t.Context())
+
+	var handled int
+
+	startGlobalSDK(t, newClient(t), silgotel.WithErrorHandler(func(error) { handled++ }))
+
+	// A repeated shutdown of the first client must not put back the handler
+	// it replaced over the second client's.
+	_ = first(t.Context())
+
+	otel.Handle(errors.New("export failed"))
+
+	if handled != 1 {
+		t.Errorf("second client's handler received %d errors, want 1", handled)
+	}
+}
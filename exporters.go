@@ -0,0 +1,240 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// traceExporterOptions configures the OTLP trace exporter's HTTP client.
+func (c *Client) traceExporterOptions() []otlptracehttp.Option {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(c.signalURL(c.TracesURL, tracesPath)),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlptracehttp.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(c.tlsConfig))
+	}
+
+	if proxy := c.proxyFunc(); proxy != nil {
+		opts = append(opts, otlptracehttp.WithProxy(proxy))
+	}
+
+	if c.options.exporterHTTPClient != nil {
+		opts = append(opts, otlptracehttp.WithHTTPClient(c.options.exporterHTTPClient))
+	}
+
+	return opts
+}
+
+// metricExporterOptions configures the OTLP metric exporter.
+func (c *Client) metricExporterOptions() []otlpmetrichttp.Option {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpointURL(c.signalURL(c.MetricsURL, metricsPath)),
+		otlpmetrichttp.WithTemporalitySelector(c.temporalitySelector()),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlpmetrichttp.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(c.tlsConfig))
+	}
+
+	if proxy := c.proxyFunc(); proxy != nil {
+		opts = append(opts, otlpmetrichttp.WithProxy(proxy))
+	}
+
+	if c.options.exporterHTTPClient != nil {
+		opts = append(opts, otlpmetrichttp.WithHTTPClient(c.options.exporterHTTPClient))
+	}
+
+	return opts
+}
+
+// logExporterOptions configures the OTLP log exporter.
+func (c *Client) logExporterOptions() []otlploghttp.Option {
+	opts := []otlploghttp.Option{
+		otlploghttp.WithEndpointURL(c.signalURL(c.LogsURL, logsPath)),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlploghttp.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(c.tlsConfig))
+	}
+
+	if proxy := c.proxyFunc(); proxy != nil {
+		opts = append(opts, otlploghttp.WithProxy(proxy))
+	}
+
+	if c.options.exporterHTTPClient != nil {
+		opts = append(opts, otlploghttp.WithHTTPClient(c.options.exporterHTTPClient))
+	}
+
+	return opts
+}
+
+// otlpHeaders returns the headers of OTEL_EXPORTER_OTLP_HEADERS overlaid with
+// the Client's Headers and then those given to WithHeaders.
+func (c *Client) otlpHeaders() map[string]string {
+	headers := otlpEnvHeaders()
+	maps.Copy(headers, c.Headers)
+	maps.Copy(headers, c.options.headers)
+
+	return headers
+}
+
+// hasCustomHeaders reports whether the exporters need headers beyond those
+// they read from the environment themselves.
+func (c *Client) hasCustomHeaders() bool {
+	return len(c.Headers) > 0 || len(c.options.headers) > 0
+}
+
+// newTraceExporter creates the OTLP span exporter for the configured
+// protocol.
+//
+//nolint:ireturn
+func (c *Client) newTraceExporter(ctx context.Context) (trace.SpanExporter, error) {
+	var client otlptrace.Client
+	if c.useGRPC() {
+		client = otlptracegrpc.NewClient(c.traceGRPCOptions()...)
+	} else {
+		client = otlptracehttp.NewClient(c.traceExporterOptions()...)
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// newMetricExporter creates the OTLP metric exporter for the configured
+// protocol.
+//
+//nolint:ireturn
+func (c *Client) newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	var (
+		exporter sdkmetric.Exporter
+		err      error
+	)
+
+	if c.useGRPC() {
+		exporter, err = otlpmetricgrpc.New(ctx, c.metricGRPCOptions()...)
+	} else {
+		exporter, err = otlpmetrichttp.New(ctx, c.metricExporterOptions()...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("creating metric exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// newLogExporter creates the OTLP log exporter for the configured protocol.
+//
+//nolint:ireturn
+func (c *Client) newLogExporter(ctx context.Context) (log.Exporter, error) {
+	var (
+		exporter log.Exporter
+		err      error
+	)
+
+	if c.useGRPC() {
+		exporter, err = otlploggrpc.New(ctx, c.logGRPCOptions()...)
+	} else {
+		exporter, err = otlploghttp.New(ctx, c.logExporterOptions()...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("creating log exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// proxyFunc returns the proxy selector for exporter requests, or nil to keep
+// the default of honouring the proxy environment variables.
+func (c *Client) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if c.options.proxy != nil {
+		return c.options.proxy
+	}
+
+	if c.ProxyURL == "" {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(c.ProxyURL)
+	if err != nil {
+		return nil
+	}
+
+	return http.ProxyURL(proxyURL)
+}
+
+// checkExporterHTTPClient rejects transport settings that a custom exporter
+// HTTP client would silently override.
+func (c *Client) checkExporterHTTPClient() error {
+	if c.options.exporterHTTPClient == nil {
+		return nil
+	}
+
+	var conflicts []string
+
+	if c.options.clientCertificate != nil || c.ClientCertFile != "" {
+		conflicts = append(conflicts, "client certificates")
+	}
+
+	if c.options.tlsConfig != nil || c.CAFile != "" {
+		conflicts = append(conflicts, "TLS settings")
+	}
+
+	if c.options.proxy != nil || c.ProxyURL != "" {
+		conflicts = append(conflicts, "proxy settings")
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf( //nolint: err113
+			"silgotel: WithExporterHTTPClient cannot be combined with %s; configure them on the client's transport",
+			strings.Join(conflicts, " or "),
+		)
+	}
+
+	return nil
+}
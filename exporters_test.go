@@ -0,0 +1,375 @@
+package silgotel_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExportContentTypeMatchesBody(t *testing.T) {
+	for _, encoding := range []string{"", "proto"} {
+		t.Run("encoding "+encoding, func(t *testing.T) {
+			collector := newRecordingCollector(t)
+
+			client := newClient(t)
+			client.OTLPBaseURL = collector.URL
+			client.Encoding = encoding
+
+			emitAll(t, startSDK(t, client))
+
+			messages := map[string]proto.Message{
+				"/v1/traces":  &coltracepb.ExportTraceServiceRequest{},
+				"/v1/metrics": &colmetricpb.ExportMetricsServiceRequest{},
+				"/v1/logs":    &collogspb.ExportLogsServiceRequest{},
+			}
+
+			collector.mu.Lock()
+			defer collector.mu.Unlock()
+
+			seen := make(map[string]bool)
+
+			for i, r := range collector.requests {
+				msg, ok := messages[r.URL.Path]
+				if !ok {
+					continue
+				}
+
+				seen[r.URL.Path] = true
+
+				if ct := r.Header.Get("Content-Type"); ct != "application/x-protobuf" {
+					t.Errorf("%s Content-Type = %q, want application/x-protobuf", r.URL.Path, ct)
+				}
+
+				if err := proto.Unmarshal(collector.bodies[i], msg); err != nil {
+					t.Errorf("%s body is not protobuf: %v", r.URL.Path, err)
+				}
+			}
+
+			if len(seen) != len(messages) {
+				t.Errorf("collector received %v, want every signal", seen)
+			}
+		})
+	}
+}
+
+func TestEncodingJSONRejected(t *testing.T) {
+	client := newClient(t)
+	client.Encoding = "json"
+
+	if err := client.Validate(); err == nil {
+		t.Error("Validate() = nil, want an error for the unsupported JSON encoding")
+	}
+}
+
+// clearOTLPEnv unsets the OTLP endpoint and header variables for the test.
+func clearOTLPEnv(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_HEADERS",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestOTLPEnvEndpoint(t *testing.T) {
+	clearOTLPEnv(t)
+
+	collector := newRecordingCollector(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL+"/otlp")
+
+	client := newClient(t)
+	client.OTLPBaseURL = ""
+
+	if err := client.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, want OTEL_EXPORTER_OTLP_ENDPOINT to stand in for otlpBaseURL", err)
+	}
+
+	emitAll(t, startSDK(t, client))
+
+	if got, want := collector.Paths(), []string{"/otlp/v1/logs", "/otlp/v1/metrics", "/otlp/v1/traces"}; !slices.Equal(got, want) {
+		t.Errorf("collector paths = %v, want %v", got, want)
+	}
+}
+
+func TestOTLPClientFieldsBeatEnv(t *testing.T) {
+	clearOTLPEnv(t)
+
+	fromEnv := newRecordingCollector(t)
+	fromClient := newRecordingCollector(t)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", fromEnv.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", fromEnv.URL+"/traces")
+
+	client := newClient(t)
+	client.OTLPBaseURL = fromClient.URL
+
+	emitAll(t, startSDK(t, client))
+
+	if got := fromEnv.Paths(); len(got) != 0 {
+		t.Errorf("environment collector received %v, want nothing", got)
+	}
+
+	if got, want := fromClient.Paths(), []string{"/v1/logs", "/v1/metrics", "/v1/traces"}; !slices.Equal(got, want) {
+		t.Errorf("client collector paths = %v, want %v", got, want)
+	}
+}
+
+func TestOTLPSignalEnvEndpoint(t *testing.T) {
+	clearOTLPEnv(t)
+
+	base := newRecordingCollector(t)
+	traces := newRecordingCollector(t)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", base.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", traces.URL+"/custom/traces")
+
+	client := newClient(t)
+	client.OTLPBaseURL = ""
+
+	emitAll(t, startSDK(t, client))
+
+	if got, want := traces.Paths(), []string{"/custom/traces"}; !slices.Equal(got, want) {
+		t.Errorf("traces collector paths = %v, want the signal endpoint used as is", got)
+	}
+
+	if got, want := base.Paths(), []string{"/v1/logs", "/v1/metrics"}; !slices.Equal(got, want) {
+		t.Errorf("base collector paths = %v, want %v", got, want)
+	}
+}
+
+func TestOTLPHeadersPrecedence(t *testing.T) {
+	clearOTLPEnv(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-tenant=acme,x-env=from-env")
+
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.Headers = map[string]string{"x-env": "from-client"}
+
+	emitAll(t, startSDK(t, client))
+
+	requests := collector.Requests()
+	if len(requests) == 0 {
+		t.Fatal("collector received no exports")
+	}
+
+	for _, r := range requests {
+		if r.Header.Get("x-tenant") != "acme" || r.Header.Get("x-env") != "from-client" {
+			t.Errorf("%s headers = %v, want x-tenant from the environment and x-env from the client", r.URL.Path, r.Header)
+		}
+	}
+}
+
+// countingTransport is a RoundTripper recording the path and encoding of
+// every request it forwards.
+type countingTransport struct {
+	mu        sync.Mutex
+	paths     []string
+	encodings []string
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.paths = append(c.paths, r.URL.Path)
+	c.encodings = append(c.encodings, r.Header.Get("Content-Encoding"))
+	c.mu.Unlock()
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithExporterHTTPClient(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.Sampler = "always_on" // keep the preset from sampling out the span
+
+	transport := &countingTransport{}
+
+	emitAll(t, startSDK(t, client,
+		silgotel.WithExporterHTTPClient(&http.Client{Transport: transport}),
+		silgotel.WithPreset(silgotel.PresetProduction),
+	))
+
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	paths := slices.Clone(transport.paths)
+	slices.Sort(paths)
+
+	if got, want := slices.Compact(paths), []string{"/v1/logs", "/v1/metrics", "/v1/traces"}; !slices.Equal(got, want) {
+		t.Errorf("transport forwarded %v, want every signal", got)
+	}
+
+	if got := len(collector.Requests()); got != len(transport.paths) {
+		t.Errorf("collector received %d requests, transport forwarded %d; want all through the transport", got, len(transport.paths))
+	}
+
+	for i, encoding := range transport.encodings {
+		if encoding != "gzip" {
+			t.Errorf("%s Content-Encoding = %q, want the preset's gzip kept", transport.paths[i], encoding)
+		}
+	}
+}
+
+func TestWithExporterHTTPClientConflicts(t *testing.T) {
+	tests := map[string]struct {
+		configure func(*silgotel.Client)
+		opts      []silgotel.Option
+		want      string
+	}{
+		"ProxyURL": {
+			configure: func(c *silgotel.Client) { c.ProxyURL = "http://proxy:3128" },
+			want:      "proxy settings",
+		},
+		"WithProxy": {
+			opts: []silgotel.Option{silgotel.WithProxy(func(*http.Request) (*url.URL, error) { return nil, nil })},
+			want: "proxy settings",
+		},
+		"CAFile": {
+			configure: func(c *silgotel.Client) { c.CAFile = "ca.pem" },
+			want:      "TLS settings",
+		},
+		"WithTLSConfig": {
+			opts: []silgotel.Option{silgotel.WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13})},
+			want: "TLS settings",
+		},
+		"ClientCertFile": {
+			configure: func(c *silgotel.Client) { c.ClientCertFile, c.ClientKeyFile = "client.pem", "client-key.pem" },
+			want:      "client certificates",
+		},
+		"WithClientCertificate": {
+			opts: []silgotel.Option{silgotel.WithClientCertificate(tls.Certificate{})},
+			want: "client certificates",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t)
+			if tt.configure != nil {
+				tt.configure(client)
+			}
+
+			opts := append([]silgotel.Option{
+				silgotel.WithoutGlobalRegistration(),
+				silgotel.WithExporterHTTPClient(&http.Client{}),
+			}, tt.opts...)
+
+			shutdown, err := silgotel.NewOtelSDK(t.Context(), client, opts...)
+			if err == nil {
+				_ = shutdown(t.Context())
+
+				t.Fatal("NewOtelSDK() = nil, want a conflict error")
+			}
+
+			if !strings.Contains(err.Error(), "WithExporterHTTPClient") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewOtelSDK() error = %q, want it to name WithExporterHTTPClient and %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	clearOTLPEnv(t)
+
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	emitAll(t, startSDK(t, client, silgotel.WithHeaders(map[string]string{"X-Tenant": "clinic-a"})))
+
+	seen := make(map[string]bool)
+
+	for _, r := range collector.Requests() {
+		seen[r.URL.Path] = true
+
+		if got := r.Header.Get("X-Tenant"); got != "clinic-a" {
+			t.Errorf("%s X-Tenant = %q, want clinic-a", r.URL.Path, got)
+		}
+	}
+
+	if !seen["/v1/traces"] || !seen["/v1/metrics"] || !seen["/v1/logs"] {
+		t.Errorf("collector received %v, want every signal", seen)
+	}
+}
+
+func TestWithMetricInterval(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	sdk := startSDK(t, client, silgotel.WithMetricInterval(50*time.Millisecond))
+
+	counter, err := sdk.client.Meter("interval").Int64Counter("interval.count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	// Nothing is flushed: the export has to come from the periodic reader.
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if slices.Contains(collector.Paths(), "/v1/metrics") {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("no metrics were exported within 5s, want one every 50ms")
+}
+
+func TestNewOtelSDKDoesNotReuseOptions(t *testing.T) {
+	clearOTLPEnv(t)
+
+	first := newRecordingCollector(t)
+	second := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = first.URL
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, silgotel.WithoutGlobalRegistration(),
+		silgotel.WithHeaders(map[string]string{"X-First": "1"}))
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	if err := shutdown(t.Context()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	client.OTLPBaseURL = second.URL
+
+	emitAll(t, startSDK(t, client, silgotel.WithHeaders(map[string]string{"X-Second": "2"})))
+
+	requests := second.Requests()
+	if len(requests) == 0 {
+		t.Fatal("the second setup exported nothing")
+	}
+
+	for _, r := range requests {
+		if r.Header.Get("X-First") != "" || r.Header.Get("X-Second") != "2" {
+			t.Errorf("%s headers = %v, want only the second setup's", r.URL.Path, r.Header)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Environment variables the SDK's batch processors read their queue size
+// from.
+const (
+	envBSPMaxQueueSize  = "OTEL_BSP_MAX_QUEUE_SIZE"
+	envBLRPMaxQueueSize = "OTEL_BLRP_MAX_QUEUE_SIZE"
+)
+
+// exportQueue bounds the spans or records handed to one batch processor and
+// not yet exported. Those that do not fit are dropped before they reach the
+// processor and reported to onDrop, so each processor keeps its own exact
+// drop count rather than one read back from the SDK's diagnostics. size
+// matches the processor's queue, which therefore never overflows itself.
+type exportQueue struct {
+	size    int64
+	pending atomic.Int64
+	full    atomic.Bool
+
+	// onDrop is called for every dropped item; first is true for the first
+	// drop since the queue last had room.
+	onDrop func(ctx context.Context, first bool)
+}
+
+// reserve claims a slot for one item, reporting false, and the drop, when
+// the queue is full.
+func (q *exportQueue) reserve(ctx context.Context) bool {
+	if q.pending.Add(1) > q.size {
+		q.pending.Add(-1)
+		q.onDrop(ctx, q.full.CompareAndSwap(false, true))
+
+		return false
+	}
+
+	return true
+}
+
+// release frees the slots of n exported items, whatever the export outcome.
+func (q *exportQueue) release(n int) {
+	q.pending.Add(-int64(n))
+	q.full.Store(false)
+}
+
+// queuedSpanProcessor drops spans that do not fit in queue before they reach
+// the batch span processor.
+type queuedSpanProcessor struct {
+	trace.SpanProcessor
+
+	queue    *exportQueue
+	exporter *queuedSpanExporter
+}
+
+func (p *queuedSpanProcessor) OnEnd(s trace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() && p.queue.reserve(context.Background()) {
+		p.SpanProcessor.OnEnd(s)
+	}
+}
+
+// Shutdown shuts the batch processor down and returns the error of its
+// exporter's Shutdown, which the SDK only hands to otel.Handle.
+func (p *queuedSpanProcessor) Shutdown(ctx context.Context) error {
+	err := p.SpanProcessor.Shutdown(ctx)
+
+	select {
+	case exportErr := <-p.exporter.shutdownErr:
+		return errors.Join(err, exportErr)
+	default:
+		return err
+	}
+}
+
+// queuedSpanExporter frees the queue slots of the spans it exports.
+type queuedSpanExporter struct {
+	trace.SpanExporter
+
+	queue *exportQueue
+
+	// shutdownErr receives the result of Shutdown.
+	shutdownErr chan error
+}
+
+func (e *queuedSpanExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	defer e.queue.release(len(spans))
+
+	return e.SpanExporter.ExportSpans(ctx, spans)
+}
+
+func (e *queuedSpanExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if err != nil {
+		e.shutdownErr <- err
+	}
+
+	return nil
+}
+
+// queuedLogProcessor drops records that do not fit in queue before they
+// reach the batch log processor.
+type queuedLogProcessor struct {
+	log.Processor
+
+	queue *exportQueue
+}
+
+func (p *queuedLogProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	if !p.queue.reserve(ctx) {
+		return nil
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// queuedLogExporter frees the queue slots of the records it exports.
+type queuedLogExporter struct {
+	log.Exporter
+
+	queue *exportQueue
+}
+
+func (e *queuedLogExporter) Export(ctx context.Context, records []log.Record) error {
+	defer e.queue.release(len(records))
+
+	return e.Exporter.Export(ctx, records)
+}
+
+// newBatchSpanProcessor batches spans for exporter behind its own
+// exportQueue, counting the spans it drops on silgotel.spans.dropped.
+//
+//nolint:ireturn
+func (c *Client) newBatchSpanProcessor(exporter trace.SpanExporter) trace.SpanProcessor {
+	queue := &exportQueue{
+		size:   int64(envQueueSize(envBSPMaxQueueSize, trace.DefaultMaxQueueSize)),
+		onDrop: c.droppedSpan,
+	}
+
+	queued := &queuedSpanExporter{SpanExporter: exporter, queue: queue, shutdownErr: make(chan error, 1)}
+
+	return &queuedSpanProcessor{
+		SpanProcessor: trace.NewBatchSpanProcessor(queued,
+			trace.WithMaxQueueSize(int(queue.size)),
+			trace.WithMaxExportBatchSize(trace.DefaultMaxExportBatchSize),
+			trace.WithBatchTimeout(c.batchTimeout()),
+			trace.WithExportTimeout(spanExportTimeout),
+		),
+		queue:    queue,
+		exporter: queued,
+	}
+}
+
+// newBatchLogProcessor batches records for exporter behind its own
+// exportQueue, counting the records it drops on
+// silgotel.log.dropped.records.
+//
+//nolint:ireturn
+func (c *Client) newBatchLogProcessor(exporter log.Exporter) log.Processor {
+	size := c.LogMaxQueueSize
+	if size <= 0 {
+		size = envQueueSize(envBLRPMaxQueueSize, defaultLogMaxQueueSize)
+	}
+
+	queue := &exportQueue{size: int64(size), onDrop: c.droppedLogRecord()}
+
+	opts := append(c.logBatchOptions(), log.WithMaxQueueSize(size))
+
+	return &queuedLogProcessor{
+		Processor: log.NewBatchProcessor(&queuedLogExporter{Exporter: exporter, queue: queue}, opts...),
+		queue:     queue,
+	}
+}
+
+// envQueueSize reads a positive queue size from the environment variable
+// name, or returns fallback.
+func envQueueSize(name string, fallback int) int {
+	size, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || size <= 0 {
+		return fallback
+	}
+
+	return size
+}
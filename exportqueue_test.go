@@ -0,0 +1,171 @@
+package silgotel_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// logBatchSizes returns the number of records in each log export collector
+// received.
+func logBatchSizes(t *testing.T, collector *recordingCollector) []int {
+	t.Helper()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	var sizes []int
+
+	for i, r := range collector.requests {
+		if r.URL.Path != "/v1/logs" {
+			continue
+		}
+
+		var req collogspb.ExportLogsServiceRequest
+		if err := proto.Unmarshal(collector.bodies[i], &req); err != nil {
+			t.Fatalf("decoding log export: %v", err)
+		}
+
+		var size int
+
+		for _, rl := range req.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				size += len(sl.GetLogRecords())
+			}
+		}
+
+		sizes = append(sizes, size)
+	}
+
+	return sizes
+}
+
+func TestLogMaxBatchSize(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.LogMaxBatchSize = 2
+
+	sdk := startSDK(t, client)
+	logger := slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler))
+
+	for range 5 {
+		logger.InfoContext(t.Context(), "batched")
+	}
+
+	sdk.flush(t)
+
+	var total int
+
+	for _, size := range logBatchSizes(t, collector) {
+		total += size
+
+		if size > 2 {
+			t.Errorf("log export carried %d records, want at most LogMaxBatchSize 2", size)
+		}
+	}
+
+	if total != 5 {
+		t.Errorf("collector received %d records, want 5", total)
+	}
+}
+
+func TestLogExportInterval(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.LogExportInterval = 20 * time.Millisecond
+
+	sdk := startSDK(t, client)
+	slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "on a timer")
+
+	// The default interval is a second; only the configured one exports
+	// this soon without a flush.
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for len(logBatchSizes(t, collector)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no log export within 500ms of a 20ms LogExportInterval")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLogQueueDrops(t *testing.T) {
+	release := make(chan struct{})
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		if r.URL.Path == "/v1/logs" {
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.LogMaxQueueSize = 2
+	client.LogMaxBatchSize = 1
+	client.LogExportInterval = time.Millisecond
+
+	var (
+		mu       sync.Mutex
+		reported []error
+	)
+
+	sdk := startGlobalSDK(t, client, silgotel.WithErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		reported = append(reported, err)
+	}))
+	t.Cleanup(func() { close(release) })
+
+	logger := slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler))
+	for range 50 {
+		logger.InfoContext(t.Context(), "burst")
+	}
+
+	m, ok := findMetric(sdk.Metrics(t), "silgotel.log.dropped.records")
+	if !ok {
+		t.Fatal("silgotel.log.dropped.records was not recorded")
+	}
+
+	// At most the queue and the batch in flight got through while the
+	// collector was stalled.
+	if dropped := m.Data.(metricdata.Sum[int64]).DataPoints[0].Value; dropped < 50-3 { //nolint:forcetypeassert
+		t.Errorf("silgotel.log.dropped.records = %d, want at least %d of the burst", dropped, 50-3)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var full int
+
+	for _, err := range reported {
+		if strings.Contains(err.Error(), "log export queue is full") {
+			full++
+		}
+	}
+
+	// Each batch processor, including the test's in-memory one, reports its
+	// first drop once rather than every dropped record.
+	if full == 0 || full > 2 {
+		t.Errorf("error handler received %d queue-full reports, want one per processor: %v", full, reported)
+	}
+}
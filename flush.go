@@ -0,0 +1,58 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const defaultServerlessFlushTimeout = 2 * time.Second
+
+// FlushMiddleware flushes the trace, metric and log providers after each
+// response when ServerlessMode is enabled, so telemetry is exported before
+// the platform throttles the instance. Otherwise it returns next unchanged.
+func (c *Client) FlushMiddleware(next http.Handler) http.Handler {
+	if !c.ServerlessMode {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), c.serverlessFlushTimeout())
+		defer cancel()
+
+		err := c.ForceFlush(ctx)
+		if err != nil {
+			LoggerFromContext(r.Context()).WarnContext(r.Context(), "silgotel: flushing telemetry", "err", err)
+		}
+	})
+}
+
+// ForceFlush exports all telemetry buffered by the client's providers.
+func (c *Client) ForceFlush(ctx context.Context) error {
+	var err error
+
+	if c.tracerProvider != nil {
+		err = errors.Join(err, c.tracerProvider.ForceFlush(ctx))
+	}
+
+	if c.meterProvider != nil {
+		err = errors.Join(err, c.meterProvider.ForceFlush(ctx))
+	}
+
+	if c.loggerProvider != nil {
+		err = errors.Join(err, c.loggerProvider.ForceFlush(ctx))
+	}
+
+	return err
+}
+
+func (c *Client) serverlessFlushTimeout() time.Duration {
+	if c.ServerlessFlushTimeout > 0 {
+		return c.ServerlessFlushTimeout
+	}
+
+	return defaultServerlessFlushTimeout
+}
@@ -0,0 +1,50 @@
+package silgotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+// flushedSpans serves one request through FlushMiddleware and returns the
+// spans the collector received by the time the response was written.
+func flushedSpans(t *testing.T, serverless bool) []string {
+	t.Helper()
+
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.ServerlessMode = serverless
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, silgotel.WithoutGlobalRegistration())
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	handler := client.FlushMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, span := client.Tracer("handler").Start(r.Context(), "handle")
+		span.End()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	return collector.SpanNames(t)
+}
+
+func TestFlushMiddleware(t *testing.T) {
+	if names := flushedSpans(t, true); len(names) != 1 || names[0] != "handle" {
+		t.Errorf("collector received %v after the response in serverless mode, want [handle]", names)
+	}
+}
+
+func TestFlushMiddlewareOutsideServerlessMode(t *testing.T) {
+	if names := flushedSpans(t, false); len(names) != 0 {
+		t.Errorf("collector received %v after the response, want the span still batched", names)
+	}
+}
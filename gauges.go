@@ -0,0 +1,94 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+// RegisterGauge creates an observable gauge on meter and registers observe
+// as its callback. observe is called on every collection and returns the
+// current value with the attributes to record it under. A panic inside
+// observe is recovered and reported to the OTel error handler, skipping that
+// observation. The registration is tracked by the Client and removed by
+// UnregisterAll, which runs on shutdown.
+//
+//nolint:ireturn
+func (c *Client) RegisterGauge(
+	meter otelMetric.Meter,
+	name, unit, desc string,
+	observe func(context.Context) (float64, []attribute.KeyValue),
+	opts ...otelMetric.Float64ObservableGaugeOption,
+) (otelMetric.Registration, error) {
+	opts = append([]otelMetric.Float64ObservableGaugeOption{
+		otelMetric.WithUnit(unit),
+		otelMetric.WithDescription(desc),
+	}, opts...)
+
+	gauge, err := meter.Float64ObservableGauge(name, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating gauge %s: %w", name, err)
+	}
+
+	registration, err := meter.RegisterCallback(
+		func(ctx context.Context, observer otelMetric.Observer) error {
+			value, attrs, ok := observeGauge(ctx, name, observe)
+			if ok {
+				observer.ObserveFloat64(gauge, value, otelMetric.WithAttributes(attrs...))
+			}
+
+			return nil
+		},
+		gauge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("registering gauge %s: %w", name, err)
+	}
+
+	c.gaugesMu.Lock()
+	c.gauges = append(c.gauges, registration)
+	c.gaugesMu.Unlock()
+
+	return registration, nil
+}
+
+// UnregisterAll removes every callback registered through RegisterGauge so
+// that no further observations are made.
+func (c *Client) UnregisterAll() error {
+	c.gaugesMu.Lock()
+	gauges := c.gauges
+	c.gauges = nil
+	c.gaugesMu.Unlock()
+
+	var err error
+	for _, registration := range gauges {
+		err = errors.Join(err, registration.Unregister())
+	}
+
+	return err
+}
+
+// observeGauge calls observe, turning a panic into an error for the OTel
+// error handler.
+//
+//nolint:nonamedreturns
+func observeGauge(
+	ctx context.Context,
+	name string,
+	observe func(context.Context) (float64, []attribute.KeyValue),
+) (value float64, attrs []attribute.KeyValue, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			otel.Handle(fmt.Errorf("silgotel: gauge %s callback panicked: %v", name, r)) //nolint: err113
+			ok = false
+		}
+	}()
+
+	value, attrs = observe(ctx)
+
+	return value, attrs, true
+}
@@ -0,0 +1,98 @@
+package silgotel_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRegisterGauge(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	depth := 7.0
+
+	_, err := sdk.client.RegisterGauge(sdk.client.Meter("gauges"), "queue.depth", "1", "Messages waiting",
+		func(context.Context) (float64, []attribute.KeyValue) {
+			return depth, []attribute.KeyValue{attribute.String("queue", "orders")}
+		},
+	)
+	if err != nil {
+		t.Fatalf("RegisterGauge() error = %v", err)
+	}
+
+	for _, want := range []float64{7, 3} {
+		depth = want
+
+		m, ok := findMetric(sdk.Metrics(t), "queue.depth")
+		if !ok {
+			t.Fatal("queue.depth was not collected")
+		}
+
+		points := m.Data.(metricdata.Gauge[float64]).DataPoints //nolint:forcetypeassert
+		if len(points) != 1 || points[0].Value != want {
+			t.Fatalf("queue.depth points = %v, want one at %v", points, want)
+		}
+
+		if queue, _ := points[0].Attributes.Value("queue"); queue.AsString() != "orders" {
+			t.Errorf("queue.depth queue = %q, want orders", queue.AsString())
+		}
+
+		if m.Unit != "1" || m.Description != "Messages waiting" {
+			t.Errorf("queue.depth unit, description = %q, %q", m.Unit, m.Description)
+		}
+	}
+
+	if err := sdk.client.UnregisterAll(); err != nil {
+		t.Fatalf("UnregisterAll() error = %v", err)
+	}
+
+	if m, ok := findMetric(sdk.Metrics(t), "queue.depth"); ok && len(m.Data.(metricdata.Gauge[float64]).DataPoints) > 0 { //nolint:forcetypeassert
+		t.Error("queue.depth was still observed after UnregisterAll")
+	}
+}
+
+func TestRegisterGaugeRecoversPanics(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+
+	sdk := startGlobalSDK(t, newClient(t), silgotel.WithErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	}))
+
+	meter := sdk.client.Meter("gauges")
+
+	_, err := sdk.client.RegisterGauge(meter, "cache.size", "1", "Cached entries",
+		func(context.Context) (float64, []attribute.KeyValue) { panic("cache closed") },
+	)
+	if err != nil {
+		t.Fatalf("RegisterGauge() error = %v", err)
+	}
+
+	_, err = sdk.client.RegisterGauge(meter, "config.version", "1", "Loaded config version",
+		func(context.Context) (float64, []attribute.KeyValue) { return 12, nil },
+	)
+	if err != nil {
+		t.Fatalf("RegisterGauge() error = %v", err)
+	}
+
+	if _, ok := findMetric(sdk.Metrics(t), "config.version"); !ok {
+		t.Error("config.version was not collected next to the panicking gauge")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cache.size") {
+		t.Errorf("error handler received %v, want the cache.size panic", errs)
+	}
+}
@@ -0,0 +1,122 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// Cloud Logging structured log fields correlating entries with Cloud Trace.
+const (
+	GCPTraceKey        = "logging.googleapis.com/trace"
+	GCPSpanIDKey       = "logging.googleapis.com/spanId"
+	GCPTraceSampledKey = "logging.googleapis.com/trace_sampled"
+)
+
+const (
+	gcpMetadataProjectURL = "http://metadata.google.internal/computeMetadata/v1/project/project-id"
+	gcpMetadataTimeout    = 2 * time.Second
+)
+
+// resolveGCPProject returns the project ID for WithGCPLogCorrelation, asking
+// the metadata server when none was given.
+func (c *Client) resolveGCPProject(ctx context.Context) (string, error) {
+	if c.options.gcpProjectID != "" {
+		return c.options.gcpProjectID, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gcpMetadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataProjectURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("silgotel: querying GCP project ID: %w", err)
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("silgotel: querying GCP project ID: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024)) //nolint:mnd
+	if err != nil {
+		return "", fmt.Errorf("silgotel: querying GCP project ID: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("silgotel: querying GCP project ID: unexpected status %s", resp.Status) //nolint: err113
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// gcpTraceValue formats a trace ID the way Cloud Logging expects it.
+func gcpTraceValue(projectID string, traceID otelTrace.TraceID) string {
+	return "projects/" + projectID + "/traces/" + traceID.String()
+}
+
+// gcpProcessor adds the Cloud Logging correlation fields to records emitted
+// with a valid trace context.
+type gcpProcessor struct {
+	log.Processor
+
+	projectID string
+}
+
+func (p *gcpProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	if record.TraceID().IsValid() {
+		record.AddAttributes(
+			otelLog.String(GCPTraceKey, gcpTraceValue(p.projectID, record.TraceID())),
+			otelLog.String(GCPSpanIDKey, record.SpanID().String()),
+			otelLog.Bool(GCPTraceSampledKey, record.TraceFlags().IsSampled()),
+		)
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// gcpHandler is the slog counterpart of gcpProcessor for console output
+// scraped by Cloud Logging.
+type gcpHandler struct {
+	next      slog.Handler
+	projectID string
+}
+
+func (h *gcpHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+//nolint:gocritic
+func (h *gcpHandler) Handle(ctx context.Context, record slog.Record) error {
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		record = record.Clone()
+		record.AddAttrs(
+			slog.String(GCPTraceKey, gcpTraceValue(h.projectID, sc.TraceID())),
+			slog.String(GCPSpanIDKey, sc.SpanID().String()),
+			slog.Bool(GCPTraceSampledKey, sc.IsSampled()),
+		)
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *gcpHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &gcpHandler{next: h.next.WithAttrs(attrs), projectID: h.projectID}
+}
+
+func (h *gcpHandler) WithGroup(name string) slog.Handler {
+	return &gcpHandler{next: h.next.WithGroup(name), projectID: h.projectID}
+}
@@ -0,0 +1,86 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestGCPLogCorrelation(t *testing.T) {
+	traceID := otelTrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID := otelTrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+
+	withSpan := func(flags otelTrace.TraceFlags) context.Context {
+		return otelTrace.ContextWithSpanContext(t.Context(), otelTrace.NewSpanContext(otelTrace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: flags,
+		}))
+	}
+
+	const wantTrace = "projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		sampled bool
+		present bool
+	}{
+		{name: "sampled", ctx: withSpan(otelTrace.FlagsSampled), sampled: true, present: true},
+		{name: "unsampled", ctx: withSpan(0), sampled: false, present: true},
+		{name: "no span", ctx: t.Context()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk := startSDK(t, newClient(t), silgotel.WithGCPLogCorrelation("my-project"))
+
+			var console bytes.Buffer
+
+			logger := slog.New(sdk.client.NewSlogHandler(slog.NewJSONHandler(&console, nil)))
+			logger.InfoContext(tt.ctx, "correlated")
+
+			records := sdk.Logs(t)
+			if len(records) != 1 {
+				t.Fatalf("exported %d records, want 1", len(records))
+			}
+
+			lines := decodeJSONLines(t, &console)
+			if len(lines) != 1 {
+				t.Fatalf("console wrote %d lines, want 1", len(lines))
+			}
+
+			traceValue, hasTrace := logAttr(records[0], silgotel.GCPTraceKey)
+			spanValue, _ := logAttr(records[0], silgotel.GCPSpanIDKey)
+			sampledValue, _ := logAttr(records[0], silgotel.GCPTraceSampledKey)
+
+			if !tt.present {
+				if hasTrace {
+					t.Errorf("exported record has %s = %s without a span", silgotel.GCPTraceKey, traceValue)
+				}
+
+				if _, ok := lines[0][silgotel.GCPTraceKey]; ok {
+					t.Errorf("console line has %s without a span: %v", silgotel.GCPTraceKey, lines[0])
+				}
+
+				return
+			}
+
+			if traceValue.AsString() != wantTrace || spanValue.AsString() != spanID.String() ||
+				sampledValue.AsBool() != tt.sampled {
+				t.Errorf("exported trace, spanId, trace_sampled = %s, %s, %s, want %s, %s, %v",
+					traceValue, spanValue, sampledValue, wantTrace, spanID, tt.sampled)
+			}
+
+			line := lines[0]
+			if line[silgotel.GCPTraceKey] != wantTrace || line[silgotel.GCPSpanIDKey] != spanID.String() ||
+				line[silgotel.GCPTraceSampledKey] != tt.sampled {
+				t.Errorf("console line = %v, want trace %s, spanId %s and trace_sampled %v", line, wantTrace, spanID, tt.sampled)
+			}
+		})
+	}
+}
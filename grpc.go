@@ -0,0 +1,175 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// OTLP transports accepted by the Client's Protocol field.
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+const envOTLPProtocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
+
+// WithGRPCDialOptions passes opts to every gRPC exporter connection, for
+// settings such as keepalive:
+//
+//	silgotel.WithGRPCDialOptions(grpc.WithKeepaliveParams(keepalive.ClientParameters{
+//		Time: 30 * time.Second,
+//	}))
+//
+// It only applies when the Client's Protocol is grpc and can be repeated.
+func WithGRPCDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.grpcDialOptions = append(o.grpcDialOptions, opts...)
+	}
+}
+
+// useGRPC reports whether the exporters speak OTLP over gRPC, as chosen by
+// the Protocol field or, when it is empty, OTEL_EXPORTER_OTLP_PROTOCOL.
+func (c *Client) useGRPC() bool {
+	if c.Protocol != "" {
+		return c.Protocol == ProtocolGRPC
+	}
+
+	return os.Getenv(envOTLPProtocol) == ProtocolGRPC
+}
+
+// checkProtocol rejects settings the gRPC exporters cannot honour.
+func (c *Client) checkProtocol() error {
+	if !c.useGRPC() {
+		return nil
+	}
+
+	var conflicts []string
+
+	if c.options.exporterHTTPClient != nil {
+		conflicts = append(conflicts, "WithExporterHTTPClient")
+	}
+
+	if c.options.proxy != nil || c.ProxyURL != "" {
+		conflicts = append(conflicts, "proxy settings")
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf( //nolint: err113
+			"silgotel: the grpc protocol cannot be combined with %s",
+			strings.Join(conflicts, " or "),
+		)
+	}
+
+	return nil
+}
+
+// traceGRPCOptions configures the OTLP gRPC trace exporter. An http:// or
+// https:// endpoint selects plaintext or TLS respectively; signal paths are
+// ignored by gRPC.
+func (c *Client) traceGRPCOptions() []otlptracegrpc.Option {
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpointURL(c.signalURL(c.TracesURL, tracesPath)),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlptracegrpc.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(c.tlsConfig)))
+	}
+
+	if len(c.options.grpcDialOptions) > 0 {
+		opts = append(opts, otlptracegrpc.WithDialOption(c.options.grpcDialOptions...))
+	}
+
+	return opts
+}
+
+// metricGRPCOptions configures the OTLP gRPC metric exporter.
+func (c *Client) metricGRPCOptions() []otlpmetricgrpc.Option {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpointURL(c.signalURL(c.MetricsURL, metricsPath)),
+		otlpmetricgrpc.WithTemporalitySelector(c.temporalitySelector()),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(c.tlsConfig)))
+	}
+
+	if len(c.options.grpcDialOptions) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithDialOption(c.options.grpcDialOptions...))
+	}
+
+	return opts
+}
+
+// logGRPCOptions configures the OTLP gRPC log exporter.
+func (c *Client) logGRPCOptions() []otlploggrpc.Option {
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpointURL(c.signalURL(c.LogsURL, logsPath)),
+	}
+
+	if c.hasCustomHeaders() {
+		opts = append(opts, otlploggrpc.WithHeaders(c.otlpHeaders()))
+	}
+
+	if c.options.gzip {
+		opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+	}
+
+	if c.tlsConfig != nil {
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(c.tlsConfig)))
+	}
+
+	if len(c.options.grpcDialOptions) > 0 {
+		opts = append(opts, otlploggrpc.WithDialOption(c.options.grpcDialOptions...))
+	}
+
+	return opts
+}
+
+// checkGRPCEndpoint verifies that the gRPC collector accepts TCP
+// connections, which is as far as a probe can go without an OTLP export.
+func (c *Client) checkGRPCEndpoint(ctx context.Context, endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("silgotel: startup check against %s: %w", endpoint, err)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4317")
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("silgotel: startup check against %s: %w", endpoint, err)
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,251 @@
+package silgotel_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	otelLog "go.opentelemetry.io/otel/log"
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcCollector is an in-process OTLP gRPC collector counting the spans,
+// metrics and log records it receives.
+type grpcCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+	collectormetrics.UnimplementedMetricsServiceServer
+	collectorlogs.UnimplementedLogsServiceServer
+
+	mu      sync.Mutex
+	spans   int
+	metrics int
+	logs    int
+}
+
+func (c *grpcCollector) Export(
+	_ context.Context, req *collectortrace.ExportTraceServiceRequest,
+) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			c.spans += len(ss.GetSpans())
+		}
+	}
+
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+// metricsService and logsService adapt the collector to the services whose
+// Export methods clash with the trace service's.
+type (
+	metricsService struct{ *grpcCollector }
+	logsService    struct{ *grpcCollector }
+)
+
+func (s metricsService) Export(
+	_ context.Context, req *collectormetrics.ExportMetricsServiceRequest,
+) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			s.metrics += len(sm.GetMetrics())
+		}
+	}
+
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (s logsService) Export(
+	_ context.Context, req *collectorlogs.ExportLogsServiceRequest,
+) (*collectorlogs.ExportLogsServiceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rl := range req.GetResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			s.logs += len(sl.GetLogRecords())
+		}
+	}
+
+	return &collectorlogs.ExportLogsServiceResponse{}, nil
+}
+
+// counts returns the number of spans, metrics and log records received.
+func (c *grpcCollector) counts() (int, int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.spans, c.metrics, c.logs
+}
+
+// serveGRPCCollector serves a plaintext grpcCollector on lis until the test
+// ends.
+func serveGRPCCollector(t *testing.T, lis net.Listener) *grpcCollector {
+	t.Helper()
+
+	collector := &grpcCollector{}
+
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	collectormetrics.RegisterMetricsServiceServer(server, metricsService{collector})
+	collectorlogs.RegisterLogsServiceServer(server, logsService{collector})
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	return collector
+}
+
+// emitSignals records a span, a counter and a log record on the client and
+// flushes them.
+func emitSignals(t *testing.T, client *silgotel.Client) {
+	t.Helper()
+
+	_, span := client.Tracer("grpc").Start(t.Context(), "export")
+	span.End()
+
+	counter, err := client.Meter("grpc").Int64Counter("grpc.exports")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	var record otelLog.Record
+	record.SetBody(otelLog.StringValue("exported over grpc"))
+	client.Logger("grpc").Emit(t.Context(), record)
+
+	if err := client.ForceFlush(t.Context()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+}
+
+func TestGRPCProtocol(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	collector := serveGRPCCollector(t, lis)
+
+	client := newClient(t)
+	client.Protocol = silgotel.ProtocolGRPC
+	// The collector only speaks plaintext, so nothing arrives unless the
+	// http:// scheme selects an insecure connection.
+	client.OTLPBaseURL = "http://" + lis.Addr().String()
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, silgotel.WithoutGlobalRegistration())
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	emitSignals(t, client)
+
+	if spans, metrics, logs := collector.counts(); spans != 1 || metrics == 0 || logs != 1 {
+		t.Errorf("collector received %d spans, %d metrics and %d logs, want every signal", spans, metrics, logs)
+	}
+}
+
+func TestGRPCProtocolFromEnvironment(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	collector := serveGRPCCollector(t, lis)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+
+	client := newClient(t)
+	client.OTLPBaseURL = "http://" + lis.Addr().String()
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, silgotel.WithoutGlobalRegistration())
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	emitSignals(t, client)
+
+	if spans, _, _ := collector.counts(); spans != 1 {
+		t.Errorf("collector received %d spans, want OTEL_EXPORTER_OTLP_PROTOCOL to select grpc", spans)
+	}
+}
+
+func TestWithGRPCDialOptions(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	collector := serveGRPCCollector(t, lis)
+
+	client := newClient(t)
+	client.Protocol = silgotel.ProtocolGRPC
+	// Nothing listens on this port; only the dialer below reaches the
+	// collector.
+	client.OTLPBaseURL = "http://localhost:1"
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithGRPCDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	emitSignals(t, client)
+
+	if spans, metrics, logs := collector.counts(); spans != 1 || metrics == 0 || logs != 1 {
+		t.Errorf("collector received %d spans, %d metrics and %d logs through the dial option, want every signal",
+			spans, metrics, logs)
+	}
+}
+
+func TestGRPCProtocolConflicts(t *testing.T) {
+	tests := map[string]struct {
+		protocol string
+		proxyURL string
+		opts     []silgotel.Option
+		want     string
+	}{
+		"unknown protocol": {protocol: "thrift", want: "protocol must be one of"},
+		"http client": {
+			protocol: silgotel.ProtocolGRPC,
+			opts:     []silgotel.Option{silgotel.WithExporterHTTPClient(&http.Client{Timeout: time.Second})},
+			want:     "WithExporterHTTPClient",
+		},
+		"proxy": {protocol: silgotel.ProtocolGRPC, proxyURL: "http://proxy:3128", want: "proxy settings"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t)
+			client.Protocol = tt.protocol
+			client.ProxyURL = tt.proxyURL
+
+			_, err := silgotel.NewOtelSDK(t.Context(), client,
+				append([]silgotel.Option{silgotel.WithoutGlobalRegistration()}, tt.opts...)...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewOtelSDK() error = %v, want one naming %s", err, tt.want)
+			}
+		})
+	}
+}
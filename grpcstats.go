@@ -0,0 +1,42 @@
+package silgotel
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// GRPCServerOptions returns the server options that trace every incoming
+// RPC, continuing the caller's trace context, and record the rpc.server.*
+// metrics on the client's providers:
+//
+//	server := grpc.NewServer(otelClient.GRPCServerOptions()...)
+//
+// opts are applied after the package's defaults and can override them.
+func (c *Client) GRPCServerOptions(opts ...otelgrpc.Option) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(c.grpcStatsOptions(opts)...)),
+	}
+}
+
+// GRPCDialOptions returns the dial options that trace every outgoing RPC,
+// propagating the trace context to the server, and record the rpc.client.*
+// metrics on the client's providers:
+//
+//	conn, err := grpc.NewClient(target, otelClient.GRPCDialOptions()...)
+//
+// opts are applied after the package's defaults and can override them.
+func (c *Client) GRPCDialOptions(opts ...otelgrpc.Option) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(c.grpcStatsOptions(opts)...)),
+	}
+}
+
+func (c *Client) grpcStatsOptions(opts []otelgrpc.Option) []otelgrpc.Option {
+	defaults := []otelgrpc.Option{
+		otelgrpc.WithTracerProvider(c.TracerProvider()),
+		otelgrpc.WithMeterProvider(c.MeterProvider()),
+		otelgrpc.WithPropagators(newPropagator()),
+	}
+
+	return append(defaults, opts...)
+}
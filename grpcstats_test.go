@@ -0,0 +1,123 @@
+package silgotel_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	otelTrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCStatsHandlers(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+	client := newClient(t)
+
+	providers := []otelgrpc.Option{
+		otelgrpc.WithTracerProvider(sdk.TracerProvider),
+		otelgrpc.WithMeterProvider(sdk.MeterProvider),
+	}
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(client.GRPCServerOptions(providers...)...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		append(client.GRPCDialOptions(providers...),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)...,
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	var serverParent, clientSpan otelTrace.SpanContext
+
+	for _, span := range sdk.Spans() {
+		if span.Name() != "grpc.health.v1.Health/Check" {
+			continue
+		}
+
+		switch span.SpanKind() {
+		case otelTrace.SpanKindServer:
+			serverParent = span.Parent()
+		case otelTrace.SpanKindClient:
+			clientSpan = span.SpanContext()
+		}
+	}
+
+	if !clientSpan.IsValid() || !serverParent.Equal(clientSpan.WithRemote(true)) {
+		t.Errorf("server span parent = %v, want the client span %v propagated", serverParent, clientSpan)
+	}
+
+	for _, name := range []string{"rpc.server.call.duration", "rpc.client.call.duration"} {
+		m, ok := findMetric(sdk.Metrics(), name)
+		if !ok {
+			t.Errorf("%s was not recorded", name)
+
+			continue
+		}
+
+		points := m.Data.(metricdata.Histogram[float64]).DataPoints //nolint:forcetypeassert
+		if len(points) != 1 || points[0].Count != 1 {
+			t.Errorf("%s points = %v, want one call", name, points)
+		}
+	}
+}
+
+func TestGRPCServerOptionsUseClientProviders(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(sdk.client.GRPCServerOptions()...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	spans := sdk.Spans(t)
+	if len(spans) != 1 || spans[0].Name != "grpc.health.v1.Health/Check" || spans[0].SpanKind != otelTrace.SpanKindServer {
+		t.Errorf("spans = %v, want the server span on the client's own provider", spans)
+	}
+}
@@ -0,0 +1,61 @@
+package silgotel
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// HTTPTransport wraps base so that outgoing requests carry the trace context,
+// produce client spans named "<method> <host>" and record the
+// http.client.request.duration histogram. A nil base uses
+// http.DefaultTransport. opts are applied after the package's defaults and
+// can override them.
+//
+//nolint:ireturn
+func (c *Client) HTTPTransport(base http.RoundTripper, opts ...otelhttp.Option) http.RoundTripper {
+	defaults := []otelhttp.Option{
+		otelhttp.WithTracerProvider(c.TracerProvider()),
+		otelhttp.WithMeterProvider(c.MeterProvider()),
+	}
+
+	return wrapTransport(base, append(defaults, opts...)...)
+}
+
+// HTTPClient returns a copy of base whose transport is instrumented by
+// HTTPTransport. A nil base behaves like a zero http.Client.
+func (c *Client) HTTPClient(base *http.Client, opts ...otelhttp.Option) *http.Client {
+	if base == nil {
+		base = &http.Client{}
+	}
+
+	client := *base
+	client.Transport = c.HTTPTransport(base.Transport, opts...)
+
+	return &client
+}
+
+// WrapTransport is HTTPTransport for code without a Client at hand, such as
+// libraries: spans and metrics go to the global providers, which delegate to
+// the SDK once NewOtelSDK has run, so it can be called before that.
+//
+//nolint:ireturn
+func WrapTransport(rt http.RoundTripper, opts ...otelhttp.Option) http.RoundTripper {
+	return wrapTransport(rt, opts...)
+}
+
+//nolint:ireturn
+func wrapTransport(base http.RoundTripper, opts ...otelhttp.Option) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	defaults := []otelhttp.Option{
+		otelhttp.WithPropagators(newPropagator()),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Host
+		}),
+	}
+
+	return otelhttp.NewTransport(base, append(defaults, opts...)...)
+}
@@ -0,0 +1,79 @@
+package silgotel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPClient(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	var traceparent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := sdk.client.Tracer("httpclient").Start(t.Context(), "parent")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/orders", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := sdk.client.HTTPClient(nil).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	parent.End()
+
+	if !strings.Contains(traceparent, parent.SpanContext().TraceID().String()) {
+		t.Errorf("outgoing traceparent = %q, want the parent's trace ID", traceparent)
+	}
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	var found bool
+
+	for _, span := range sdk.Spans(t) {
+		if span.Name == "GET "+host {
+			found = true
+
+			if span.SpanKind != otelTrace.SpanKindClient {
+				t.Errorf("%s kind = %v, want client", span.Name, span.SpanKind)
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("no client span named %q was recorded", "GET "+host)
+	}
+
+	if _, ok := findMetric(sdk.Metrics(t), "http.client.request.duration"); !ok {
+		t.Error("http.client.request.duration was not recorded")
+	}
+}
+
+func TestHTTPClientKeepsBaseSettings(t *testing.T) {
+	client := newClient(t)
+
+	base := &http.Client{Timeout: time.Second}
+	wrapped := client.HTTPClient(base)
+
+	if wrapped == base || wrapped.Timeout != base.Timeout {
+		t.Error("HTTPClient() did not return a copy of base keeping its timeout")
+	}
+
+	if base.Transport != nil {
+		t.Error("HTTPClient() modified the transport of base")
+	}
+}
@@ -0,0 +1,25 @@
+package silgotel
+
+import (
+	"net/http"
+	"strings"
+)
+
+// IgnoresRequest reports whether r matches WithIgnoredPaths or
+// WithIgnoredRequests and should bypass instrumentation. Framework
+// middleware outside this package uses it to honour the same options.
+func (c *Client) IgnoresRequest(r *http.Request) bool {
+	for _, prefix := range c.options.ignoredPaths {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+
+	for _, ignore := range c.options.ignoreRequests {
+		if ignore(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package silgotel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestIgnoredRequests(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithIgnoredPaths("/health", "/metrics"),
+		silgotel.WithIgnoredRequests(func(r *http.Request) bool { return r.Header.Get("X-Probe") != "" }),
+	)
+
+	const traceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+	var inHandler []otelTrace.SpanContext
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(_ http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") != traceparent {
+			t.Errorf("%s reached the handler with traceparent %q, want it untouched", r.URL.Path, r.Header.Get("traceparent"))
+		}
+
+		inHandler = append(inHandler, otelTrace.SpanContextFromContext(r.Context()))
+	})
+
+	handler := sdk.client.HTTPMiddleware(sdk.client.MetricsMiddleware(mux))
+
+	for _, tt := range []struct {
+		path  string
+		probe bool
+	}{
+		{path: "/health"},
+		{path: "/metrics/prometheus"},
+		{path: "/orders", probe: true},
+		{path: "/orders"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("traceparent", traceparent)
+
+		if tt.probe {
+			req.Header.Set("X-Probe", "kubelet")
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	for i, sc := range inHandler[:3] {
+		if sc.IsValid() {
+			t.Errorf("ignored request %d ran inside span %v, want no span started", i, sc.SpanID())
+		}
+	}
+
+	if sc := inHandler[3]; !sc.IsValid() || sc.SpanID() == (otelTrace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Error("instrumented request did not run inside its own server span")
+	}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].Name != "GET /" {
+		t.Errorf("exported spans = %v, want only the instrumented request", spans)
+	}
+
+	for _, sm := range sdk.Metrics(t).ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.server.request.duration" {
+				continue
+			}
+
+			var count uint64
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+				count += point.Count
+			}
+
+			if count != 1 {
+				t.Errorf("%s recorded %d samples, want only the instrumented request", sm.Scope.Name, count)
+			}
+		}
+	}
+}
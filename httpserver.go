@@ -0,0 +1,44 @@
+package silgotel
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// HTTPMiddleware wraps next so that every request continues the trace
+// context it carries, produces a server span named "<method> <route>" and
+// records the http.server.request.duration histogram bucketed by
+// WithHTTPViews. The route is the http.ServeMux pattern that matched; when
+// none did the span is named after the method alone. Requests ignored
+// through WithIgnoredPaths pass straight through. opts are applied after the
+// package's defaults and can override them.
+func (c *Client) HTTPMiddleware(next http.Handler, opts ...otelhttp.Option) http.Handler {
+	defaults := []otelhttp.Option{
+		otelhttp.WithTracerProvider(c.TracerProvider()),
+		otelhttp.WithMeterProvider(c.MeterProvider()),
+		otelhttp.WithPropagators(newPropagator()),
+		otelhttp.WithSpanNameFormatter(serverSpanName),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !c.IgnoresRequest(r)
+		}),
+	}
+
+	return otelhttp.NewHandler(next, "", append(defaults, opts...)...)
+}
+
+// serverSpanName names a server span after the request method and matched
+// route. otelhttp calls it again once the mux has set r.Pattern.
+func serverSpanName(_ string, r *http.Request) string {
+	if r.Pattern == "" {
+		return r.Method
+	}
+
+	// Patterns such as "GET /users/{id}" already lead with the method.
+	if method, route, ok := strings.Cut(r.Pattern, " "); ok && method == r.Method {
+		return method + " " + strings.TrimSpace(route)
+	}
+
+	return r.Method + " " + r.Pattern
+}
@@ -0,0 +1,53 @@
+package silgotel
+
+import (
+	"strings"
+)
+
+// Signals named by InitError.
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+// InitError is returned by NewOtelSDK under WithBestEffortInit when some
+// signals could not be set up. The failed signals run on no-op providers
+// while the others export normally, and the returned shutdown function must
+// still be called.
+type InitError struct {
+	// Signals maps each failed signal, one of SignalTraces, SignalMetrics
+	// and SignalLogs, to the reason it failed.
+	Signals map[string]error
+}
+
+func (e *InitError) add(signal string, err error) {
+	if e.Signals == nil {
+		e.Signals = make(map[string]error)
+	}
+
+	e.Signals[signal] = err
+}
+
+// Error lists the failed signals in traces, metrics, logs order.
+func (e *InitError) Error() string {
+	parts := make([]string, 0, len(e.Signals))
+
+	for _, signal := range []string{SignalTraces, SignalMetrics, SignalLogs} {
+		if err, ok := e.Signals[signal]; ok {
+			parts = append(parts, signal+": "+err.Error())
+		}
+	}
+
+	return "silgotel: running without " + strings.Join(parts, "; ")
+}
+
+// Unwrap returns the per-signal errors.
+func (e *InitError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Signals))
+	for _, err := range e.Signals {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
@@ -0,0 +1,84 @@
+package silgotel_test
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+// brokenLogs fails only the logger provider: the OTLP exporters ignore
+// endpoints they cannot parse, but an invalid redaction pattern is an error.
+var brokenLogs = silgotel.WithLogRedaction([]string{"("}, nil)
+
+func TestBestEffortInit(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithBestEffortInit(),
+		brokenLogs,
+	)
+
+	var initErr *silgotel.InitError
+	if !errors.As(err, &initErr) {
+		t.Fatalf("NewOtelSDK() error = %v, want an *InitError", err)
+	}
+
+	if shutdown == nil {
+		t.Fatal("NewOtelSDK() returned no shutdown function with an *InitError")
+	}
+
+	if _, ok := initErr.Signals[silgotel.SignalLogs]; !ok || len(initErr.Signals) != 1 {
+		t.Errorf("failed signals = %v, want only logs", initErr.Signals)
+	}
+
+	if !strings.Contains(err.Error(), "logs:") || !strings.Contains(err.Error(), "redaction") {
+		t.Errorf("error = %q, want it to name the logs signal and its cause", err)
+	}
+
+	_, span := client.Tracer("best-effort").Start(t.Context(), "op")
+	span.End()
+
+	counter, _ := client.Meter("best-effort").Int64Counter("best_effort.count")
+	counter.Add(t.Context(), 1)
+
+	// Logs run on a no-op provider, so this record goes nowhere.
+	slog.New(client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "dropped")
+
+	if err := client.ForceFlush(t.Context()); err != nil {
+		t.Errorf("ForceFlush() error = %v, want the working signals flushed", err)
+	}
+
+	if got, want := collector.Paths(), []string{"/v1/metrics", "/v1/traces"}; !slices.Equal(got, want) {
+		t.Errorf("collector received %v, want %v", got, want)
+	}
+
+	if err := shutdown(t.Context()); err != nil {
+		t.Errorf("shutdown error = %v, want only the created providers shut down cleanly", err)
+	}
+}
+
+func TestInitFailsWithoutBestEffort(t *testing.T) {
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), newClient(t), silgotel.WithoutGlobalRegistration(), brokenLogs)
+	if err == nil {
+		_ = shutdown(t.Context())
+
+		t.Fatal("NewOtelSDK() = nil, want the logger provider's error")
+	}
+
+	var initErr *silgotel.InitError
+	if errors.As(err, &initErr) {
+		t.Errorf("NewOtelSDK() error = %v, want a fatal error rather than an *InitError", err)
+	}
+
+	if shutdown != nil {
+		t.Error("NewOtelSDK() returned a shutdown function on a fatal error")
+	}
+}
@@ -0,0 +1,62 @@
+package silgotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// JobNameKey is the attribute carrying the job name on spans started by
+// StartJobSpan.
+const JobNameKey = attribute.Key("job.name")
+
+// StartJobSpan starts the root span of a background job or cron run. Any
+// span already held in ctx is not used as the parent, so every run is its
+// own trace; it is linked instead so the trigger can still be found. End the
+// span with EndJobSpan.
+//
+//nolint:ireturn
+func StartJobSpan(
+	ctx context.Context,
+	jobName string,
+	attrs ...attribute.KeyValue,
+) (context.Context, otelTrace.Span) {
+	opts := []otelTrace.SpanStartOption{
+		otelTrace.WithNewRoot(),
+		otelTrace.WithSpanKind(otelTrace.SpanKindInternal),
+		ctxAttributesOption(ctx),
+		otelTrace.WithAttributes(append([]attribute.KeyValue{JobNameKey.String(jobName)}, attrs...)...),
+	}
+
+	if parent := otelTrace.SpanContextFromContext(ctx); parent.IsValid() {
+		opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: parent}))
+	}
+
+	ctx = otelTrace.ContextWithSpanContext(ctx, otelTrace.SpanContext{})
+
+	return cachedTracer(instrumentationScope).Start(ctx, jobName, opts...) //nolint:spancheck
+}
+
+// EndJobSpan records err on span, setting its status to error, or marks it
+// ok when err is nil, and ends it.
+func EndJobSpan(span otelTrace.Span, err error) {
+	if err != nil {
+		CaptureTraceStatusAndError(span, err)
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+
+	span.End()
+}
+
+// RunJob runs fn inside a job span named name and returns its error.
+func RunJob(ctx context.Context, name string, fn func(context.Context) error) error {
+	ctx, span := StartJobSpan(ctx, name)
+
+	err := fn(ctx)
+	EndJobSpan(span, err)
+
+	return err
+}
@@ -0,0 +1,77 @@
+package silgotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestStartJobSpanIsRoot(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, trigger := silgotel.Trace(t.Context(), "scheduler", "tick")
+
+	_, job := silgotel.StartJobSpan(ctx, "reconcile-payments", attribute.Int("job.batch", 3))
+	silgotel.EndJobSpan(job, nil)
+	trigger.End()
+
+	span := sdk.Spans()[0]
+
+	if span.Parent().IsValid() || span.SpanContext().TraceID() == trigger.SpanContext().TraceID() {
+		t.Error("job span continued the trigger's trace, want a new root trace")
+	}
+
+	if links := span.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != trigger.SpanContext().SpanID() {
+		t.Errorf("job span links = %v, want one link to the trigger", links)
+	}
+
+	if span.Name() != "reconcile-payments" || span.SpanKind() != otelTrace.SpanKindInternal {
+		t.Errorf("job span = %q %v, want reconcile-payments internal", span.Name(), span.SpanKind())
+	}
+
+	attrs := attribute.NewSet(span.Attributes()...)
+	if name, _ := attrs.Value(silgotel.JobNameKey); name.AsString() != "reconcile-payments" {
+		t.Errorf("job.name = %q, want reconcile-payments", name.AsString())
+	}
+
+	if batch, _ := attrs.Value("job.batch"); batch.AsInt64() != 3 {
+		t.Errorf("job.batch = %v, want the extra attributes kept", batch)
+	}
+
+	if span.Status().Code != codes.Ok {
+		t.Errorf("job span status = %v, want ok", span.Status())
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	errFailed := errors.New("ledger unavailable")
+
+	var inJob otelTrace.SpanContext
+
+	err := silgotel.RunJob(t.Context(), "close-books", func(ctx context.Context) error {
+		inJob = otelTrace.SpanContextFromContext(ctx)
+
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Errorf("RunJob() error = %v, want fn's error", err)
+	}
+
+	span := sdk.Spans()[0]
+
+	if inJob.SpanID() != span.SpanContext().SpanID() {
+		t.Error("fn did not run inside the job span")
+	}
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("job span status = %v, want error", span.Status())
+	}
+}
@@ -0,0 +1,128 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// SpanLeak describes a span that was started but has not ended.
+type SpanLeak struct {
+	Name    string
+	SpanID  otelTrace.SpanID
+	Started time.Time
+	// Location is the file:line of the first caller outside the OTel SDK
+	// and this package, i.e. the code that started the span.
+	Location string
+}
+
+func (l SpanLeak) String() string {
+	return fmt.Sprintf("span %q started at %s was never ended", l.Name, l.Location)
+}
+
+// LeakDetector is a span processor that tracks every span from start to end
+// to find spans that are never ended, typically on an early return. It
+// captures a stack frame per span, so it is meant for tests and debugging.
+type LeakDetector struct {
+	mu   sync.Mutex
+	open map[otelTrace.SpanID]SpanLeak
+}
+
+// NewLeakDetector returns an empty LeakDetector. Register it with
+// WithSpanProcessor or trace.WithSpanProcessor.
+func NewLeakDetector() *LeakDetector {
+	return &LeakDetector{open: make(map[otelTrace.SpanID]SpanLeak)}
+}
+
+func (d *LeakDetector) OnStart(_ context.Context, s trace.ReadWriteSpan) {
+	leak := SpanLeak{
+		Name:     s.Name(),
+		SpanID:   s.SpanContext().SpanID(),
+		Started:  s.StartTime(),
+		Location: spanCallSite(),
+	}
+
+	d.mu.Lock()
+	d.open[leak.SpanID] = leak
+	d.mu.Unlock()
+}
+
+func (d *LeakDetector) OnEnd(s trace.ReadOnlySpan) {
+	d.mu.Lock()
+	delete(d.open, s.SpanContext().SpanID())
+	d.mu.Unlock()
+}
+
+// Shutdown reports the spans still open, if any, as an error.
+func (d *LeakDetector) Shutdown(context.Context) error {
+	leaks := d.Leaks()
+	if len(leaks) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(leaks))
+	for i, leak := range leaks {
+		msgs[i] = leak.String()
+	}
+
+	return fmt.Errorf("silgotel: %d leaked spans: %s", len(leaks), strings.Join(msgs, "; ")) //nolint: err113
+}
+
+func (d *LeakDetector) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Leaks returns the spans started but not yet ended, oldest first.
+func (d *LeakDetector) Leaks() []SpanLeak {
+	d.mu.Lock()
+	leaks := make([]SpanLeak, 0, len(d.open))
+
+	for _, leak := range d.open {
+		leaks = append(leaks, leak)
+	}
+	d.mu.Unlock()
+
+	slices.SortFunc(leaks, func(a, b SpanLeak) int {
+		return a.Started.Compare(b.Started)
+	})
+
+	return leaks
+}
+
+// SpanLeaks returns the spans started but not yet ended when
+// WithLeakDetection is enabled, and nil otherwise.
+func (c *Client) SpanLeaks() []SpanLeak {
+	if c.options.leakDetector == nil {
+		return nil
+	}
+
+	return c.options.leakDetector.Leaks()
+}
+
+// spanCallSite returns the file:line of the first frame outside the OTel
+// SDK and the root silgotel package.
+func spanCallSite() string {
+	var pcs [32]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])]) //nolint:mnd
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "go.opentelemetry.io/") &&
+			!strings.HasPrefix(frame.Function, "github.com/savannahghi/sil-gotel.") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package silgotel_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestWithLeakDetection(t *testing.T) {
+	client := newClient(t)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithLeakDetection(),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	tracer := client.Tracer("leaks")
+
+	_, ended := tracer.Start(t.Context(), "ended")
+	ended.End()
+
+	_, file, line, _ := runtime.Caller(0)
+	_, leaked := tracer.Start(t.Context(), "leaked")
+
+	site := fmt.Sprintf("%s:%d", file, line+1)
+
+	leaks := client.SpanLeaks()
+	if len(leaks) != 1 || leaks[0].Name != "leaked" || leaks[0].Location != site {
+		t.Fatalf("SpanLeaks() = %v, want only the leaked span started at %s", leaks, site)
+	}
+
+	if leaks[0].SpanID != leaked.SpanContext().SpanID() {
+		t.Errorf("leak span ID = %s, want %s", leaks[0].SpanID, leaked.SpanContext().SpanID())
+	}
+
+	err = shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `span "leaked" started at `+site) {
+		t.Errorf("shutdown error = %v, want the leaked span and its call site", err)
+	}
+}
+
+func TestLeakDetectionOff(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	_, span := sdk.client.Tracer("leaks").Start(t.Context(), "open")
+	defer span.End()
+
+	if leaks := sdk.client.SpanLeaks(); leaks != nil {
+		t.Errorf("SpanLeaks() = %v without WithLeakDetection, want nil", leaks)
+	}
+}
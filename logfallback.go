@@ -0,0 +1,100 @@
+package silgotel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// fallbackExporter writes records the wrapped exporter failed to deliver,
+// after its own retries, to a writer as JSON lines. Every batch is offered to
+// the wrapped exporter first, so export resumes as soon as the collector is
+// reachable again.
+type fallbackExporter struct {
+	log.Exporter
+
+	mu       sync.Mutex
+	w        io.Writer
+	fallback otelMetric.Int64Counter
+}
+
+func newFallbackExporter(next log.Exporter, w io.Writer, meter otelMetric.Meter) *fallbackExporter {
+	return &fallbackExporter{
+		Exporter: next,
+		w:        w,
+		fallback: mustInstrument(meter.Int64Counter(
+			"silgotel.log.fallback.records",
+			otelMetric.WithDescription("Number of log records written to the fallback writer"),
+			otelMetric.WithUnit("{record}"),
+		)),
+	}
+}
+
+// fallbackRecord is the JSON line written for each undelivered record.
+type fallbackRecord struct {
+	Timestamp    time.Time         `json:"timestamp"`
+	Severity     int               `json:"severity"`
+	SeverityText string            `json:"severity_text,omitempty"`
+	Body         string            `json:"body"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	TraceID      string            `json:"trace_id,omitempty"`
+	SpanID       string            `json:"span_id,omitempty"`
+}
+
+func (e *fallbackExporter) Export(ctx context.Context, records []log.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	if err == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	enc := json.NewEncoder(e.w)
+
+	for i := range records {
+		encErr := enc.Encode(newFallbackRecord(&records[i]))
+		if encErr != nil {
+			return fmt.Errorf("%w; writing log fallback: %w", err, encErr)
+		}
+	}
+
+	e.fallback.Add(ctx, int64(len(records)))
+
+	return err
+}
+
+func newFallbackRecord(record *log.Record) fallbackRecord {
+	out := fallbackRecord{
+		Timestamp:    record.Timestamp(),
+		Severity:     int(record.Severity()),
+		SeverityText: record.SeverityText(),
+		Body:         record.Body().String(),
+	}
+
+	if record.AttributesLen() > 0 {
+		out.Attributes = make(map[string]string, record.AttributesLen())
+		record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+			out.Attributes[kv.Key] = kv.Value.String()
+
+			return true
+		})
+	}
+
+	if record.TraceID().IsValid() {
+		out.TraceID = record.TraceID().String()
+	}
+
+	if record.SpanID().IsValid() {
+		out.SpanID = record.SpanID().String()
+	}
+
+	return out
+}
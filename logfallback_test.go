@@ -0,0 +1,103 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestLogFallback(t *testing.T) {
+	silenceDefaultLogger(t)
+
+	var (
+		down     atomic.Bool
+		mu       sync.Mutex
+		received int
+	)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		// 400 is not retried, so a failed export reaches the fallback at once.
+		if down.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if r.URL.Path == "/v1/logs" {
+			mu.Lock()
+			received++
+			mu.Unlock()
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	fallback := &lockedBuffer{}
+	sdk := startSDK(t, client, silgotel.WithLogFallback(fallback))
+	logger := slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler))
+
+	down.Store(true)
+
+	ctx, span := sdk.client.Tracer("fallback").Start(t.Context(), "op")
+	logger.InfoContext(ctx, "during outage", "order.id", "o-1")
+	span.End()
+
+	_ = sdk.client.ForceFlush(t.Context())
+
+	lines := decodeJSONLines(t, bytes.NewBufferString(fallback.String()))
+	if len(lines) != 1 {
+		t.Fatalf("fallback received %d records, want 1:\n%s", len(lines), fallback.String())
+	}
+
+	record := lines[0]
+	attrs, _ := record["attributes"].(map[string]any)
+
+	if record["body"] != "during outage" || attrs["order.id"] != "o-1" {
+		t.Errorf("fallback record = %v, want the body and attributes of the lost record", record)
+	}
+
+	if record["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("fallback trace_id = %v, want %s", record["trace_id"], span.SpanContext().TraceID())
+	}
+
+	down.Store(false)
+
+	logger.InfoContext(t.Context(), "after recovery")
+	sdk.flush(t)
+
+	mu.Lock()
+	delivered := received
+	mu.Unlock()
+
+	if delivered == 0 {
+		t.Error("collector received no logs after it recovered")
+	}
+
+	if strings.Contains(fallback.String(), "after recovery") {
+		t.Error("a record delivered after recovery was written to the fallback")
+	}
+
+	m, ok := findMetric(sdk.Metrics(t), "silgotel.log.fallback.records")
+	if !ok {
+		t.Fatal("silgotel.log.fallback.records was not recorded")
+	}
+
+	if value := m.Data.(metricdata.Sum[int64]).DataPoints[0].Value; value != 1 { //nolint:forcetypeassert
+		t.Errorf("silgotel.log.fallback.records = %d, want 1", value)
+	}
+}
@@ -0,0 +1,212 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// Log attribute keys added by the correlation handler.
+const (
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceFlagsKey = "trace_flags"
+)
+
+// correlationHandler enriches each record with the trace and span IDs of the
+// span held in the record's context.
+type correlationHandler struct {
+	next slog.Handler
+}
+
+// NewCorrelationHandler wraps h so that every record logged with a context
+// carrying a valid span gets trace_id, span_id and trace_flags attributes,
+// and a tenant.id attribute when the context carries a tenant. Other records
+// are passed through untouched.
+func NewCorrelationHandler(h slog.Handler) slog.Handler {
+	return &correlationHandler{next: h}
+}
+
+func (h *correlationHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+//nolint:gocritic
+func (h *correlationHandler) Handle(ctx context.Context, record slog.Record) error {
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		record = record.Clone()
+		record.AddAttrs(
+			slog.String(TraceIDKey, sc.TraceID().String()),
+			slog.String(SpanIDKey, sc.SpanID().String()),
+			slog.String(TraceFlagsKey, sc.TraceFlags().String()),
+		)
+	}
+
+	if attr, ok := tenantAttr(ctx); ok {
+		record = record.Clone()
+		record.AddAttrs(attr)
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &correlationHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *correlationHandler) WithGroup(name string) slog.Handler {
+	return &correlationHandler{next: h.next.WithGroup(name)}
+}
+
+// NewSlogHandler returns an slog.Handler bridging records of every level to
+// the global logger provider under scope, stamping trace_id, span_id and
+// trace_flags from the context like NewCorrelationHandler. Unlike
+// Client.NewSlogHandler it writes nothing to the console.
+//
+//	logger := slog.New(silgotel.NewSlogHandler("github.com/acme/orders"))
+//
+//nolint:ireturn
+func NewSlogHandler(scope string) slog.Handler {
+	return newSlogHandler(global.GetLoggerProvider(), scope, scopeVersion())
+}
+
+// newSlogHandler builds the handler behind NewSlogHandler and NewLogger on
+// provider.
+//
+//nolint:ireturn
+func newSlogHandler(provider otelLog.LoggerProvider, scope, version string) slog.Handler {
+	return NewCorrelationHandler(
+		otelslog.NewHandler(scope,
+			otelslog.WithLoggerProvider(provider),
+			otelslog.WithVersion(version),
+			otelslog.WithSchemaURL(semconv.SchemaURL),
+		),
+	)
+}
+
+// Log emits msg at level through the OTel-bridged logger for packageName.
+// kv holds alternating key-value pairs or slog.Attr values and is handled
+// exactly like slog's own variadic arguments, including !BADKEY for a
+// trailing key without a value.
+func Log(ctx context.Context, level slog.Level, packageName, msg string, kv ...any) {
+	NewLogger(packageName).Log(ctx, level, msg, kv...)
+}
+
+// LogDebug emits msg at debug level followed by kv, like Log.
+func LogDebug(ctx context.Context, packageName, msg string, kv ...any) {
+	Log(ctx, slog.LevelDebug, packageName, msg, kv...)
+}
+
+// LogInfo emits msg at info level followed by kv, like Log.
+func LogInfo(ctx context.Context, packageName, msg string, kv ...any) {
+	Log(ctx, slog.LevelInfo, packageName, msg, kv...)
+}
+
+// LogWarn emits msg at warn level followed by kv, like Log.
+func LogWarn(ctx context.Context, packageName, msg string, kv ...any) {
+	Log(ctx, slog.LevelWarn, packageName, msg, kv...)
+}
+
+// LogError emits msg at error level with err recorded as the
+// exception.message, exception.type, error.type and error.class attributes,
+// followed by kv.
+func LogError(ctx context.Context, packageName, msg string, err error, kv ...any) {
+	if err != nil {
+		classAttrs := classifyError(ctx, err)
+
+		withErr := make([]any, 0, len(kv)+2+len(classAttrs))
+		withErr = append(withErr,
+			slog.String(string(semconv.ExceptionMessageKey), err.Error()),
+			slog.String(string(semconv.ExceptionTypeKey), fmt.Sprintf("%T", err)),
+		)
+
+		for _, attr := range classAttrs {
+			withErr = append(withErr, slog.String(string(attr.Key), attr.Value.AsString()))
+		}
+
+		kv = append(withErr, kv...)
+	}
+
+	Log(ctx, slog.LevelError, packageName, msg, kv...)
+}
+
+// fanoutHandler sends each record to every handler that is enabled for it.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//nolint:gocritic
+func (h *fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var err error
+
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, record.Level) {
+			err = errors.Join(err, handler.Handle(ctx, record.Clone()))
+		}
+	}
+
+	return err
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+
+	return &fanoutHandler{handlers: handlers}
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+
+	return &fanoutHandler{handlers: handlers}
+}
+
+// NewSlogHandler returns a handler that writes every record both to console,
+// with trace correlation attributes, and to the client's OTLP logger
+// provider. A nil console defaults to a text handler on stderr.
+//
+//	slog.SetDefault(slog.New(otelClient.NewSlogHandler(nil)))
+//
+//nolint:ireturn
+func (c *Client) NewSlogHandler(console slog.Handler) slog.Handler {
+	if console == nil {
+		console = slog.NewTextHandler(os.Stderr, nil)
+	}
+
+	if c.gcpProjectID != "" {
+		console = &gcpHandler{next: console, projectID: c.gcpProjectID}
+	}
+
+	return &fanoutHandler{handlers: []slog.Handler{
+		NewCorrelationHandler(console),
+		otelslog.NewHandler(c.ServiceName,
+			otelslog.WithLoggerProvider(c.LoggerProvider()),
+			otelslog.WithVersion(c.Version),
+			otelslog.WithSchemaURL(semconv.SchemaURL),
+		),
+	}}
+}
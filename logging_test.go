@@ -0,0 +1,215 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// logAttr returns the value of the attribute key of record.
+func logAttr(record log.Record, key string) (otelLog.Value, bool) {
+	var (
+		value otelLog.Value
+		found bool
+	)
+
+	record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		if kv.Key == key {
+			value, found = kv.Value, true
+
+			return false
+		}
+
+		return true
+	})
+
+	return value, found
+}
+
+// decodeJSONLines decodes the records a slog.JSONHandler wrote to buf.
+func decodeJSONLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var record map[string]any
+
+		err := decoder.Decode(&record)
+		if err != nil {
+			t.Fatalf("decoding log output: %v", err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestCorrelationHandler(t *testing.T) {
+	siltest.NewTestSDK(t)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(silgotel.NewCorrelationHandler(slog.NewJSONHandler(&buf, nil)))
+
+	ctx, span := silgotel.Trace(t.Context(), "api", "handle")
+	logger.InfoContext(ctx, "traced")
+	span.End()
+
+	logger.InfoContext(t.Context(), "untraced")
+
+	records := decodeJSONLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(records))
+	}
+
+	traced, untraced := records[0], records[1]
+
+	if traced[silgotel.TraceIDKey] != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id = %v, want %s", traced[silgotel.TraceIDKey], span.SpanContext().TraceID())
+	}
+
+	if traced[silgotel.SpanIDKey] != span.SpanContext().SpanID().String() {
+		t.Errorf("span_id = %v, want %s", traced[silgotel.SpanIDKey], span.SpanContext().SpanID())
+	}
+
+	if traced[silgotel.TraceFlagsKey] != "01" {
+		t.Errorf("trace_flags = %v, want 01", traced[silgotel.TraceFlagsKey])
+	}
+
+	for _, key := range []string{silgotel.TraceIDKey, silgotel.SpanIDKey, silgotel.TraceFlagsKey} {
+		if _, ok := untraced[key]; ok {
+			t.Errorf("untraced record has %s", key)
+		}
+	}
+}
+
+func TestNewLoggerCorrelatesRecords(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "api", "handle")
+	silgotel.NewLogger("api").InfoContext(ctx, "traced")
+	span.End()
+
+	logs := sdk.Logs()
+	if len(logs) != 1 {
+		t.Fatalf("recorded %d logs, want 1", len(logs))
+	}
+
+	if logs[0].TraceID() != span.SpanContext().TraceID() || logs[0].SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("record trace context = %s/%s, want the span's", logs[0].TraceID(), logs[0].SpanID())
+	}
+
+	if value, ok := logAttr(logs[0], silgotel.TraceIDKey); !ok || value.AsString() != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id attribute = %v, want %s", value, span.SpanContext().TraceID())
+	}
+}
+
+func TestLogHelpersForwardAttributes(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	silgotel.LogInfo(t.Context(), "orders", "order placed", "order.id", 42, slog.String("region", "eu"), "dangling")
+
+	record := sdk.Logs()[0]
+
+	if record.Severity() != otelLog.SeverityInfo || record.Body().AsString() != "order placed" {
+		t.Errorf("record = %v %q, want an info record with the message as body", record.Severity(), record.Body().AsString())
+	}
+
+	if value, _ := logAttr(record, "order.id"); value.AsInt64() != 42 {
+		t.Errorf("order.id = %v, want 42", value)
+	}
+
+	if value, _ := logAttr(record, "region"); value.AsString() != "eu" {
+		t.Errorf("region = %v, want eu", value)
+	}
+
+	if value, _ := logAttr(record, "!BADKEY"); value.AsString() != "dangling" {
+		t.Errorf("!BADKEY = %v, want the trailing key handled like slog", value)
+	}
+}
+
+func TestLogError(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	silgotel.LogError(t.Context(), "orders", "charging card", errors.New("card declined"), "order.id", 42)
+
+	record := sdk.Logs()[0]
+
+	if record.Severity() != otelLog.SeverityError {
+		t.Errorf("severity = %v, want error", record.Severity())
+	}
+
+	for key, want := range map[string]string{
+		"exception.message": "card declined",
+		"exception.type":    "*errors.errorString",
+	} {
+		if value, _ := logAttr(record, key); value.AsString() != want {
+			t.Errorf("%s = %q, want %q", key, value.AsString(), want)
+		}
+	}
+
+	if value, _ := logAttr(record, "order.id"); value.AsInt64() != 42 {
+		t.Errorf("order.id = %v, want the key-value pairs after the error attributes", value)
+	}
+}
+
+func TestLogErrorNil(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	silgotel.LogError(t.Context(), "orders", "nothing failed", nil)
+
+	if _, found := logAttr(sdk.Logs()[0], "exception.message"); found {
+		t.Error("LogError(nil) recorded exception.message")
+	}
+}
+
+func TestClientSlogHandlerFansOut(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	var buf bytes.Buffer
+
+	console := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	logger := slog.New(sdk.client.NewSlogHandler(console)).With("tenant", "acme").WithGroup("req")
+
+	logger.ErrorContext(t.Context(), "request failed", "path", "/orders")
+	logger.InfoContext(t.Context(), "request served", "path", "/orders")
+
+	lines := decodeJSONLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("console wrote %d records, want only the error above its warn level", len(lines))
+	}
+
+	group, _ := lines[0]["req"].(map[string]any)
+	if lines[0]["level"] != "ERROR" || lines[0]["tenant"] != "acme" || group["path"] != "/orders" {
+		t.Errorf("console record = %v, want the error with tenant and req.path", lines[0])
+	}
+
+	logs := sdk.Logs(t)
+	if len(logs) != 2 {
+		t.Fatalf("exported %d records, want both", len(logs))
+	}
+
+	record := logs[0]
+	if record.Severity() != otelLog.SeverityError || record.Body().AsString() != "request failed" {
+		t.Errorf("exported record = %v %q, want the error", record.Severity(), record.Body().AsString())
+	}
+
+	if value, _ := logAttr(record, "tenant"); value.AsString() != "acme" {
+		t.Errorf("exported tenant = %v, want acme", value)
+	}
+
+	req, _ := logAttr(record, "req")
+	if kvs := req.AsMap(); len(kvs) != 1 || kvs[0].Key != "path" || kvs[0].Value.AsString() != "/orders" {
+		t.Errorf("exported req = %v, want the group holding path", req)
+	}
+}
@@ -0,0 +1,73 @@
+package silgotel
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// severityProcessor drops records below a minimum severity before they reach
+// the wrapped processor.
+type severityProcessor struct {
+	log.Processor
+
+	min otelLog.Severity
+}
+
+func newSeverityProcessor(next log.Processor, level slog.Level) *severityProcessor {
+	return &severityProcessor{Processor: next, min: slogSeverity(level)}
+}
+
+func (p *severityProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	if !p.allowed(param.Severity) {
+		return false
+	}
+
+	return p.Processor.Enabled(ctx, param)
+}
+
+func (p *severityProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	if !p.allowed(record.Severity()) {
+		return nil
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// allowed reports whether severity passes the filter. Records without a
+// severity are kept since their importance is unknown.
+func (p *severityProcessor) allowed(severity otelLog.Severity) bool {
+	return severity == otelLog.SeverityUndefined || severity >= p.min
+}
+
+// slogSeverity maps a slog level to an OTel severity the same way the
+// otelslog bridge does.
+func slogSeverity(level slog.Level) otelLog.Severity {
+	return otelLog.Severity(level + 9) //nolint: gosec
+}
+
+// logLevel returns the configured minimum log level, defaulting to Info in
+// production and Debug everywhere else.
+func (c *Client) logLevel() slog.Level {
+	if c.options.logLevel != nil {
+		return *c.options.logLevel
+	}
+
+	if isProduction(c.Environment) {
+		return slog.LevelInfo
+	}
+
+	return slog.LevelDebug
+}
+
+func isProduction(environment string) bool {
+	switch strings.ToLower(environment) {
+	case "prod", "production":
+		return true
+	default:
+		return false
+	}
+}
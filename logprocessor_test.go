@@ -0,0 +1,66 @@
+package silgotel_test
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	otelLog "go.opentelemetry.io/otel/log"
+)
+
+// exportedSeverities logs one record per slog level through sdk's client
+// and returns the severities that were exported.
+func exportedSeverities(t *testing.T, sdk *testSDK) []otelLog.Severity {
+	t.Helper()
+
+	logger := slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler))
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		logger.Log(t.Context(), level, "message")
+	}
+
+	var severities []otelLog.Severity
+	for _, record := range sdk.Logs(t) {
+		severities = append(severities, record.Severity())
+	}
+
+	return severities
+}
+
+func TestLogLevel(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithLogLevel(slog.LevelWarn))
+
+	got := exportedSeverities(t, sdk)
+	if want := []otelLog.Severity{otelLog.SeverityWarn, otelLog.SeverityError}; !slices.Equal(got, want) {
+		t.Errorf("exported severities = %v, want %v", got, want)
+	}
+}
+
+func TestLogLevelDefaults(t *testing.T) {
+	for environment, want := range map[string][]otelLog.Severity{
+		"test": {otelLog.SeverityDebug, otelLog.SeverityInfo, otelLog.SeverityWarn, otelLog.SeverityError},
+		"prod": {otelLog.SeverityInfo, otelLog.SeverityWarn, otelLog.SeverityError},
+	} {
+		t.Run(environment, func(t *testing.T) {
+			client := newClient(t)
+			client.Environment = environment
+
+			if got := exportedSeverities(t, startSDK(t, client)); !slices.Equal(got, want) {
+				t.Errorf("exported severities = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLogLevelKeepsRecordsWithoutSeverity(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithLogLevel(slog.LevelError))
+
+	var record otelLog.Record
+
+	record.SetBody(otelLog.StringValue("no severity"))
+	sdk.client.Logger("severity").Emit(t.Context(), record)
+
+	if logs := sdk.Logs(t); len(logs) != 1 {
+		t.Errorf("exported %d records, want the record without severity kept", len(logs))
+	}
+}
@@ -0,0 +1,157 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+//nolint:gochecknoglobals
+var (
+	histogramCache sync.Map // cacheKey -> otelMetric.Float64Histogram
+	upDownCache    sync.Map // cacheKey -> otelMetric.Int64UpDownCounter
+	gaugeCache     sync.Map // cacheKey -> otelMetric.Float64Gauge
+)
+
+// instrumentName matches the instrument name syntax of the OTel API.
+var instrumentName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.\-/]{0,254}$`) //nolint:gochecknoglobals
+
+// cachedHistogram returns the seconds histogram name on the global meter
+// named scope, creating it on first use.
+//
+//nolint:ireturn
+func cachedHistogram(scope, name string) (otelMetric.Float64Histogram, error) {
+	provider := otel.GetMeterProvider()
+	key := cacheKey{provider: provider, scope: scope, version: scopeVersion(), name: name}
+
+	if histogram, ok := histogramCache.Load(key); ok {
+		return histogram.(otelMetric.Float64Histogram), nil //nolint:forcetypeassert
+	}
+
+	histogram, err := cachedMeter(scope).Float64Histogram(
+		name,
+		otelMetric.WithDescription("Duration of "+name),
+		otelMetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating histogram %s: %w", name, err)
+	}
+
+	actual, _ := histogramCache.LoadOrStore(key, histogram)
+
+	return actual.(otelMetric.Float64Histogram), nil //nolint:forcetypeassert
+}
+
+// MeasureDuration runs fn inside a span named name and records how long it
+// took on the <name>.duration histogram of the meter named scope, with a
+// status attribute of "ok" or "error". The span status is set to error when
+// fn fails and left unset otherwise; the error is returned unchanged. Instruments are cached, so calling it on a
+// hot path costs a map lookup rather than an instrument lookup.
+func MeasureDuration(
+	ctx context.Context,
+	scope, name string,
+	fn func(ctx context.Context) error,
+	attrs ...attribute.KeyValue,
+) error {
+	ctx, span := Trace(ctx, scope, name)
+	defer span.End()
+
+	start := time.Now()
+	err := fn(ctx)
+	elapsed := time.Since(start)
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+
+		CaptureTraceStatusAndError(span, err)
+	}
+
+	histogram, herr := cachedHistogram(scope, name+".duration")
+	if herr != nil {
+		otel.Handle(herr)
+
+		return err
+	}
+
+	histogram.Record(ctx, elapsed.Seconds(), otelMetric.WithAttributes(
+		append([]attribute.KeyValue{attribute.String("status", status)}, attrs...)...,
+	))
+
+	return err
+}
+
+// AddUpDown adds delta, which may be negative, to the up-down counter name on
+// meter, for values such as active workers or pool size. Invalid names are
+// reported to the OTel error handler and nothing is recorded.
+func AddUpDown(ctx context.Context, meter otelMetric.Meter, name string, delta int64, attrs ...attribute.KeyValue) {
+	counter, err := cachedInstrument(&upDownCache, meter, name,
+		func() (otelMetric.Int64UpDownCounter, error) {
+			return meter.Int64UpDownCounter(name, otelMetric.WithUnit("1"))
+		},
+	)
+	if err != nil {
+		otel.Handle(err)
+
+		return
+	}
+
+	counter.Add(ctx, delta, otelMetric.WithAttributes(attrs...))
+}
+
+// SetGauge records value as the current value of the synchronous gauge name
+// on meter, for values such as queue depth. Each attribute set reports the
+// last value set. Invalid names are reported to the OTel error handler and
+// nothing is recorded.
+func SetGauge(ctx context.Context, meter otelMetric.Meter, name string, value float64, attrs ...attribute.KeyValue) {
+	gauge, err := cachedInstrument(&gaugeCache, meter, name,
+		func() (otelMetric.Float64Gauge, error) {
+			return meter.Float64Gauge(name, otelMetric.WithUnit("1"))
+		},
+	)
+	if err != nil {
+		otel.Handle(err)
+
+		return
+	}
+
+	gauge.Record(ctx, value, otelMetric.WithAttributes(attrs...))
+}
+
+// cachedInstrument returns the instrument name of meter from cache, creating
+// it with create on first use.
+//
+//nolint:ireturn,forcetypeassert
+func cachedInstrument[T any](
+	cache *sync.Map,
+	meter otelMetric.Meter,
+	name string,
+	create func() (T, error),
+) (T, error) {
+	key := cacheKey{provider: meter, name: name}
+
+	if instrument, ok := cache.Load(key); ok {
+		return instrument.(T), nil
+	}
+
+	if !instrumentName.MatchString(name) {
+		var zero T
+
+		return zero, fmt.Errorf("silgotel: invalid instrument name %q", name) //nolint: err113
+	}
+
+	instrument, err := create()
+	if err != nil {
+		return instrument, fmt.Errorf("creating instrument %s: %w", name, err)
+	}
+
+	actual, _ := cache.LoadOrStore(key, instrument)
+
+	return actual.(T), nil
+}
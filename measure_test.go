@@ -0,0 +1,160 @@
+package silgotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMeasureDuration(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	errFailed := errors.New("warehouse offline")
+
+	for _, fail := range []bool{false, false, true} {
+		err := silgotel.MeasureDuration(t.Context(), "orders", "reserve.stock", func(context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+
+			if fail {
+				return errFailed
+			}
+
+			return nil
+		}, attribute.String("warehouse", "nairobi"))
+
+		if fail != errors.Is(err, errFailed) {
+			t.Errorf("MeasureDuration() error = %v, want fn's error returned unchanged", err)
+		}
+	}
+
+	m, ok := findMetric(sdk.Metrics(), "reserve.stock.duration")
+	if !ok {
+		t.Fatal("reserve.stock.duration was not recorded")
+	}
+
+	if m.Unit != "s" {
+		t.Errorf("reserve.stock.duration unit = %q, want s", m.Unit)
+	}
+
+	counts := make(map[string]uint64)
+
+	for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+		status, _ := point.Attributes.Value("status")
+		counts[status.AsString()] = point.Count
+
+		if warehouse, _ := point.Attributes.Value("warehouse"); warehouse.AsString() != "nairobi" {
+			t.Errorf("data point warehouse = %q, want the extra attributes kept", warehouse.AsString())
+		}
+
+		if minimum := float64(point.Count) * 0.005; point.Sum < minimum {
+			t.Errorf("%s sum = %v, want at least %v seconds", status.AsString(), point.Sum, minimum)
+		}
+	}
+
+	if counts["ok"] != 2 || counts["error"] != 1 {
+		t.Errorf("observations by status = %v, want 2 ok and 1 error", counts)
+	}
+
+	var statuses []codes.Code
+	for _, span := range sdk.Spans() {
+		if span.Name() == "reserve.stock" {
+			statuses = append(statuses, span.Status().Code)
+		}
+	}
+
+	if len(statuses) != 3 || statuses[0] != codes.Unset || statuses[1] != codes.Unset || statuses[2] != codes.Error {
+		t.Errorf("span statuses = %v, want unset, unset, error", statuses)
+	}
+}
+
+func TestAddUpDown(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+	meter := sdk.client.Meter("workers")
+
+	for _, delta := range []int64{1, 1, 1, -1} {
+		silgotel.AddUpDown(t.Context(), meter, "workers.active", delta, attribute.String("pool", "email"))
+	}
+
+	silgotel.AddUpDown(t.Context(), meter, "workers.active", 4, attribute.String("pool", "sms"))
+	silgotel.AddUpDown(t.Context(), meter, "workers.active", -4, attribute.String("pool", "sms"))
+
+	m, ok := findMetric(sdk.Metrics(t), "workers.active")
+	if !ok {
+		t.Fatal("workers.active was not recorded")
+	}
+
+	if m.Unit != "1" {
+		t.Errorf("workers.active unit = %q, want 1", m.Unit)
+	}
+
+	sum := m.Data.(metricdata.Sum[int64]) //nolint:forcetypeassert
+	if sum.IsMonotonic {
+		t.Error("workers.active is monotonic, want an up-down counter")
+	}
+
+	values := make(map[string]int64)
+
+	for _, point := range sum.DataPoints {
+		pool, _ := point.Attributes.Value("pool")
+		values[pool.AsString()] = point.Value
+	}
+
+	if values["email"] != 2 || values["sms"] != 0 {
+		t.Errorf("workers.active by pool = %v, want email 2 and sms 0", values)
+	}
+}
+
+func TestSetGauge(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+	meter := sdk.client.Meter("queues")
+
+	for _, depth := range []float64{10, 25, 3} {
+		silgotel.SetGauge(t.Context(), meter, "queue.depth", depth, attribute.String("queue", "orders"))
+	}
+
+	silgotel.SetGauge(t.Context(), meter, "queue.depth", 7, attribute.String("queue", "refunds"))
+
+	m, ok := findMetric(sdk.Metrics(t), "queue.depth")
+	if !ok {
+		t.Fatal("queue.depth was not recorded")
+	}
+
+	values := make(map[string]float64)
+
+	for _, point := range m.Data.(metricdata.Gauge[float64]).DataPoints { //nolint:forcetypeassert
+		queue, _ := point.Attributes.Value("queue")
+		values[queue.AsString()] = point.Value
+	}
+
+	if values["orders"] != 3 || values["refunds"] != 7 {
+		t.Errorf("queue.depth by queue = %v, want the last value set: orders 3 and refunds 7", values)
+	}
+}
+
+func TestInstrumentNameInvalid(t *testing.T) {
+	var handled []error
+
+	sdk := startGlobalSDK(t, newClient(t), silgotel.WithErrorHandler(func(err error) { handled = append(handled, err) }))
+	meter := sdk.client.Meter("invalid")
+
+	silgotel.AddUpDown(t.Context(), meter, "1workers", 1)
+	silgotel.SetGauge(t.Context(), meter, "queue depth", 1)
+
+	if len(handled) != 2 {
+		t.Errorf("error handler received %v, want one error per invalid name", handled)
+	}
+
+	rm := sdk.Metrics(t)
+	for _, name := range []string{"1workers", "queue depth"} {
+		if _, ok := findMetric(rm, name); ok {
+			t.Errorf("%s was recorded, want nothing for an invalid name", name)
+		}
+	}
+}
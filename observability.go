@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -14,14 +17,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	pyroscope "github.com/grafana/pyroscope-go"
-	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/log/global"
 	otelMetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
@@ -33,73 +34,341 @@ import (
 	otelTrace "go.opentelemetry.io/otel/trace"
 )
 
+// instrumentationScope names the tracers and meters the package uses for
+// its own telemetry.
+const instrumentationScope = "silgotel"
+
 type ctxKey string
 
 var loggerKey ctxKey = "LoggingMiddlewareKey" //nolint: gochecknoglobals
 
+// Export modes accepted by the Client's Mode field.
+const (
+	ModeOTLP   = "otlp"
+	ModeStdout = "stdout"
+)
+
+const (
+	tracesPath  = "/v1/traces"
+	metricsPath = "/v1/metrics"
+	logsPath    = "/v1/logs"
+)
+
+// Standard OTel environment variables consulted for signals the Client does
+// not configure explicitly.
+const (
+	envOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPHeaders  = "OTEL_EXPORTER_OTLP_HEADERS"
+)
+
 //nolint:gochecknoglobals
-var headers = map[string]string{
-	"content-type": "application/json",
+var signalEndpointEnv = map[string]string{
+	tracesPath:  "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	metricsPath: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+	logsPath:    "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+}
+
+// signalURL returns the OTLP endpoint for a signal. Explicit Client fields
+// win: the override when set, otherwise the signal path joined to
+// OTLPBaseURL. Without either, the signal specific OTEL_EXPORTER_OTLP_*_ENDPOINT
+// is used as is and OTEL_EXPORTER_OTLP_ENDPOINT is joined like OTLPBaseURL.
+// Trailing slashes and path prefixes such as "/otlp" are preserved correctly,
+// and a base URL already ending in a signal path is used as is for that
+// signal.
+func (c *Client) signalURL(override, path string) string {
+	if override != "" {
+		return override
+	}
+
+	base := c.OTLPBaseURL
+	if base == "" {
+		if endpoint := os.Getenv(signalEndpointEnv[path]); endpoint != "" {
+			return endpoint
+		}
+
+		base = os.Getenv(envOTLPEndpoint)
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + path
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+
+	for _, signalPath := range []string{tracesPath, metricsPath, logsPath} {
+		if trimmed, ok := strings.CutSuffix(u.Path, signalPath); ok {
+			u.Path = trimmed
+
+			break
+		}
+	}
+
+	u.Path += path
+	u.RawPath = ""
+
+	return u.String()
+}
+
+// hasSignalEndpoint reports whether signalURL has a configured source for a
+// signal, either on the Client or in the environment.
+func (c *Client) hasSignalEndpoint(override, path string) bool {
+	return override != "" ||
+		c.OTLPBaseURL != "" ||
+		os.Getenv(signalEndpointEnv[path]) != "" ||
+		os.Getenv(envOTLPEndpoint) != ""
+}
+
+// stdoutMode reports whether the Client's Mode replaces the OTLP exporters
+// with stdout ones.
+func (c *Client) stdoutMode() bool {
+	return c.Mode == ModeStdout
+}
+
+// hasEndpoints reports whether every signal has an endpoint.
+func (c *Client) hasEndpoints() bool {
+	return c.hasSignalEndpoint(c.TracesURL, tracesPath) &&
+		c.hasSignalEndpoint(c.MetricsURL, metricsPath) &&
+		c.hasSignalEndpoint(c.LogsURL, logsPath)
+}
+
+// otlpEnvHeaders parses OTEL_EXPORTER_OTLP_HEADERS, a comma separated list of
+// URL encoded key=value pairs. The exporters read it themselves; this is for
+// requests the package sends on its own, such as the startup check.
+func otlpEnvHeaders() map[string]string {
+	headers := make(map[string]string)
+
+	for pair := range strings.SplitSeq(os.Getenv(envOTLPHeaders), ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		key, errKey := url.PathUnescape(strings.TrimSpace(key))
+		value, errValue := url.PathUnescape(strings.TrimSpace(value))
+
+		if errKey != nil || errValue != nil || key == "" {
+			continue
+		}
+
+		headers[key] = value
+	}
+
+	return headers
 }
 
 func (c *Client) setupOtelSDK(ctx context.Context) (func(context.Context) error, error) {
-	var shutdownFuncs []func(context.Context) error
+	var shutdownSteps []providerShutdown
+
+	restoreErrorHandler := func() {}
+
+	if !c.options.skipSelfObservability {
+		c.exportMetrics = &exportMetrics{client: c}
+	}
+
+	restoreDiagnostics := c.installDiagnostics()
+	restoreErrorClassifier := c.installErrorClassifier()
+	restoreScopeVersion := c.installScopeVersion()
 
 	shutdown := func(ctx context.Context) error {
-		var err error
-		for _, fn := range shutdownFuncs {
-			err = errors.Join(err, fn(ctx))
-		}
+		err := errors.Join(c.UnregisterAll(), c.shutdownProviders(ctx, shutdownSteps))
+
+		shutdownSteps = nil
 
-		shutdownFuncs = nil
+		evictProviders(c.tracerProvider, c.meterProvider, c.loggerProvider)
+
+		restoreErrorHandler()
+		restoreDiagnostics()
+		restoreErrorClassifier()
+		restoreScopeVersion()
 
 		return err
 	}
 
-	res, err := c.newResource(ctx)
+	res, err := c.buildResource(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("creating resource: %w", err)
 	}
 
-	otel.SetTextMapPropagator(newPropagator())
+	if c.registerGlobals() {
+		otel.SetTextMapPropagator(newPropagator())
+	}
+
+	var initErr InitError
+
+	// fail aborts setup unless best-effort init lets the signal degrade to
+	// its no-op provider, in which case setup carries on with the others.
+	fail := func(signal string, err error) error {
+		if !c.options.bestEffortInit {
+			return errors.Join(err, shutdown(ctx))
+		}
+
+		initErr.add(signal, err)
+
+		return nil
+	}
 
 	tracerProvider, err := c.newTracerProvider(ctx, res)
 	if err != nil {
-		return nil, errors.Join(err, shutdown(ctx))
+		err = fail(SignalTraces, err)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		shutdownSteps = append(shutdownSteps, providerShutdown{name: "tracer", shutdown: tracerProvider.Shutdown})
+		c.tracerProvider = tracerProvider
 	}
 
-	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
-	otel.SetTracerProvider(tracerProvider)
+	if c.registerGlobals() {
+		otel.SetTracerProvider(c.TracerProvider())
+	}
 
 	meterProvider, err := c.newMeterProvider(ctx, res)
 	if err != nil {
-		return nil, errors.Join(err, shutdown(ctx))
+		err = fail(SignalMetrics, err)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		shutdownSteps = append(shutdownSteps, providerShutdown{name: "meter", shutdown: meterProvider.Shutdown})
+		c.meterProvider = meterProvider
 	}
 
-	shutdownFuncs = append(shutdownFuncs, meterProvider.Shutdown)
-	otel.SetMeterProvider(meterProvider)
+	if c.registerGlobals() {
+		otel.SetMeterProvider(c.MeterProvider())
+	}
+
+	if c.options.processMetrics && c.meterProvider != nil {
+		err = c.registerProcessMetrics(c.Meter(instrumentationScope))
+		if err != nil {
+			err = fail(SignalMetrics, err)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if c.options.runtimeMetrics && c.meterProvider != nil {
+		err = c.startRuntimeMetrics()
+		if err != nil {
+			err = fail(SignalMetrics, err)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	restoreErrorHandler = c.installErrorHandler()
+
+	if c.options.gcpCorrelation {
+		c.gcpProjectID, err = c.resolveGCPProject(ctx)
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
 
 	loggerProvider, err := c.newLoggerProvider(ctx, res)
 	if err != nil {
-		return nil, errors.Join(err, shutdown(ctx))
+		err = fail(SignalLogs, err)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		shutdownSteps = append(shutdownSteps, providerShutdown{name: "logger", shutdown: loggerProvider.Shutdown})
+		c.loggerProvider = loggerProvider
 	}
 
-	shutdownFuncs = append(shutdownFuncs, loggerProvider.Shutdown)
-	global.SetLoggerProvider(loggerProvider)
+	if c.registerGlobals() {
+		global.SetLoggerProvider(c.LoggerProvider())
+	}
+
+	if len(initErr.Signals) > 0 {
+		return shutdown, &initErr
+	}
 
 	return shutdown, nil
 }
 
-func (c *Client) newResource(_ context.Context) (*resource.Resource, error) {
-	return resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(c.ServiceName),
-			semconv.ServiceVersion(c.Version),
-			semconv.DeploymentEnvironmentName(c.Environment),
-		),
+// buildResource returns the resource shared by the tracer, meter and logger
+// providers so that every signal carries an identical attribute set.
+// Detected attributes, those set through WithResourceAttributes and those
+// from OTEL_RESOURCE_ATTRIBUTES are merged in, in increasing precedence, with
+// the Client's service attributes taking precedence over all of them.
+func (c *Client) buildResource(ctx context.Context) (*resource.Resource, error) {
+	env, err := resource.New(ctx, resource.WithFromEnv())
+	if err != nil {
+		return nil, fmt.Errorf("reading resource from environment: %w", err)
+	}
+
+	service := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(c.ServiceName),
+		semconv.ServiceVersion(c.Version),
+		semconv.DeploymentEnvironmentName(c.Environment),
+		semconv.ServiceInstanceID(c.serviceInstanceID()),
 	)
+
+	base, err := mergeResources(ctx, resource.Default(), c.detectedResource(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	base, err = mergeResources(ctx, base, resource.NewSchemaless(c.options.resourceAttributes...))
+	if err != nil {
+		return nil, err
+	}
+
+	base, err = mergeResources(ctx, base, env)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := mergeResources(ctx, base, service)
+	if err != nil {
+		return nil, err
+	}
+
+	c.schemaURL = res.SchemaURL()
+
+	return res, nil
+}
+
+// mergeResources merges b into a like resource.Merge, except that diverging
+// schema URLs do not fail setup. resource.Default is built against the SDK's
+// own semconv version, which drifts from ours on upgrades; in that case the
+// attributes are merged, b winning, into a resource without a schema URL and
+// a warning is logged.
+func mergeResources(ctx context.Context, a, b *resource.Resource) (*resource.Resource, error) {
+	res, err := resource.Merge(a, b)
+	if !errors.Is(err, resource.ErrSchemaURLConflict) {
+		return res, err
+	}
+
+	slog.WarnContext(ctx, "silgotel: resource schema URLs conflict, dropping the schema URL",
+		"schema_url", a.SchemaURL(),
+		"other_schema_url", b.SchemaURL(),
+	)
+
+	return resource.NewSchemaless(append(a.Attributes(), b.Attributes()...)...), nil
+}
+
+// serviceInstanceID resolves the instance ID once so that every signal and
+// every setup of the Client reports the same value.
+func (c *Client) serviceInstanceID() string {
+	if c.instanceID != "" {
+		return c.instanceID
+	}
+
+	switch {
+	case c.InstanceID != "":
+		c.instanceID = c.InstanceID
+	case os.Getenv("HOSTNAME") != "":
+		c.instanceID = os.Getenv("HOSTNAME")
+	default:
+		c.instanceID = uuid.New().String()
+	}
+
+	return c.instanceID
 }
 
 //nolint:ireturn
@@ -111,57 +380,234 @@ func newPropagator() propagation.TextMapPropagator {
 }
 
 func (c *Client) newTracerProvider(ctx context.Context, res *resource.Resource) (*trace.TracerProvider, error) {
-	exporter, err := otlptrace.New(
-		ctx,
-		otlptracehttp.NewClient(
-			otlptracehttp.WithEndpointURL(fmt.Sprintf("%s/v1/traces", c.OTLPBaseURL)),
-			otlptracehttp.WithHeaders(headers),
-		),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	var exporters []trace.SpanExporter
+
+	if !c.stdoutMode() {
+		otlpExporter, err := c.newTraceExporter(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		exporters = append(exporters, otlpExporter)
 	}
 
-	return trace.NewTracerProvider(
-		trace.WithBatcher(exporter,
-			trace.WithMaxExportBatchSize(trace.DefaultMaxExportBatchSize),
-			trace.WithBatchTimeout(trace.DefaultScheduleDelay),
-			trace.WithExportTimeout(10*time.Second),
-		),
+	exporters = append(exporters, c.options.spanExporters...)
+
+	if c.options.teeToStdout || c.stdoutMode() {
+		stdoutExporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("creating stdout trace exporter: %w", err)
+		}
+
+		exporters = append(exporters, stdoutExporter)
+	}
+
+	opts := []trace.TracerProviderOption{
 		trace.WithResource(res),
-	), nil
+		trace.WithSpanProcessor(tenantSpanProcessor{}),
+		trace.WithSpanProcessor(ctxAttributesProcessor{}),
+	}
+
+	// Custom processors run before the exporting ones so that attributes
+	// they set in OnStart are exported.
+	for _, processor := range c.options.spanProcessors {
+		opts = append(opts, trace.WithSpanProcessor(processor))
+	}
+
+	if c.options.leakDetector != nil {
+		opts = append(opts, trace.WithSpanProcessor(c.options.leakDetector))
+	}
+
+	for _, exporter := range exporters {
+		if len(c.options.spanAttributeDenyList) > 0 {
+			exporter = newDenyListExporter(exporter, c.options.spanAttributeDenyList)
+		}
+
+		if c.exportMetrics != nil {
+			exporter = &selfObservedSpanExporter{SpanExporter: exporter, metrics: c.exportMetrics}
+		}
+
+		var processor trace.SpanProcessor
+		if c.options.syncExport {
+			processor = trace.NewSimpleSpanProcessor(exporter)
+		} else {
+			processor = c.newBatchSpanProcessor(exporter)
+		}
+
+		if c.options.alwaysSampleErrors {
+			processor = &errorSpanProcessor{next: processor}
+		}
+
+		opts = append(opts, trace.WithSpanProcessor(processor))
+	}
+
+	if sampler := c.sampler(); sampler != nil {
+		opts = append(opts, trace.WithSampler(sampler))
+	}
+
+	return trace.NewTracerProvider(opts...), nil
 }
 
 func (c *Client) newMeterProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
-	exporter, err := otlpmetrichttp.New(
-		ctx,
-		otlpmetrichttp.WithEndpointURL(fmt.Sprintf("%s/v1/metrics", c.OTLPBaseURL)),
-		otlpmetrichttp.WithHeaders(headers),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating metric exporter: %w", err)
+	var readers []sdkmetric.Reader
+
+	if c.options.prometheus {
+		reader, err := c.newPrometheusReader()
+		if err != nil {
+			return nil, err
+		}
+
+		readers = append(readers, reader)
+	}
+
+	if !c.stdoutMode() && !c.options.prometheusOnly {
+		exporter, err := c.newMetricExporter(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		readers = append(readers, c.newPeriodicReader(exporter))
 	}
 
-	return sdkmetric.NewMeterProvider(
+	readers = append(readers, c.options.metricReaders...)
+
+	if c.options.teeToStdout || c.stdoutMode() {
+		stdoutExporter, err := stdoutmetric.New()
+		if err != nil {
+			return nil, fmt.Errorf("creating stdout metric exporter: %w", err)
+		}
+
+		readers = append(readers, c.newPeriodicReader(stdoutExporter))
+	}
+
+	opts := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(
-			sdkmetric.NewPeriodicReader(exporter,
-				sdkmetric.WithInterval(30*time.Second),
-				sdkmetric.WithTimeout(10*time.Second),
-			),
-		),
-		WithHTTPViews(),
-	), nil
+		sdkmetric.WithExemplarFilter(c.exemplarFilter()),
+		c.metricViews(),
+	}
+
+	for _, reader := range readers {
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+
+	return sdkmetric.NewMeterProvider(opts...), nil
+}
+
+// spanExportTimeout bounds each export of the batch span processors.
+const spanExportTimeout = 10 * time.Second
+
+// defaultMetricInterval is how often metrics are exported unless
+// WithMetricInterval says otherwise.
+const defaultMetricInterval = 30 * time.Second
+
+// batchTimeout returns the span batch timeout set by WithBatchTimeout, or the
+// SDK default.
+func (c *Client) batchTimeout() time.Duration {
+	if c.options.batchTimeout > 0 {
+		return c.options.batchTimeout
+	}
+
+	return trace.DefaultScheduleDelay * time.Millisecond
+}
+
+// checkSyncExport rejects batch tuning combined with WithSyncSpanExport,
+// which exports without batching and would silently ignore it.
+func (c *Client) checkSyncExport() error {
+	if !c.options.syncExport {
+		return nil
+	}
+
+	var conflicts []string
+
+	if c.options.batchTimeout > 0 {
+		conflicts = append(conflicts, "WithBatchTimeout")
+	}
+
+	if c.LogMaxQueueSize > 0 || c.LogMaxBatchSize > 0 || c.LogExportInterval > 0 || c.LogExportTimeout > 0 {
+		conflicts = append(conflicts, "the Log batch fields")
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf( //nolint: err113
+			"silgotel: WithSyncSpanExport cannot be combined with %s; sync export does not batch",
+			strings.Join(conflicts, " or "),
+		)
+	}
+
+	return nil
+}
+
+// metricInterval returns the export interval set by WithMetricInterval, or
+// 30 seconds.
+func (c *Client) metricInterval() time.Duration {
+	if c.options.metricInterval > 0 {
+		return c.options.metricInterval
+	}
+
+	return defaultMetricInterval
+}
+
+func (c *Client) newPeriodicReader(exporter sdkmetric.Exporter) *sdkmetric.PeriodicReader {
+	if c.exportMetrics != nil {
+		exporter = &selfObservedMetricExporter{Exporter: exporter, metrics: c.exportMetrics}
+	}
+
+	opts := []sdkmetric.PeriodicReaderOption{
+		sdkmetric.WithInterval(c.metricInterval()),
+		sdkmetric.WithTimeout(10 * time.Second),
+	}
+
+	if producer := c.runtimeProducer(); producer != nil {
+		opts = append(opts, sdkmetric.WithProducer(producer))
+	}
+
+	return sdkmetric.NewPeriodicReader(exporter, opts...)
 }
 
 //nolint:godoclint,ireturn
 func WithHTTPViews() sdkmetric.Option {
-	return sdkmetric.WithView(
+	return sdkmetric.WithView(httpViews()...)
+}
+
+func httpViews() []sdkmetric.View {
+	return []sdkmetric.View{
 		sdkmetric.NewView(
 			sdkmetric.Instrument{
 				Name: "http.server.request_duration",
 				Kind: sdkmetric.InstrumentKindHistogram,
 			},
+			sdkmetric.Stream{
+				Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+					Boundaries: []float64{
+						0.005, 0.01, 0.025, 0.05, 0.1,
+						0.25, 0.5, 1, 2.5, 5, 10,
+					},
+				},
+				Unit:            "s",
+				AttributeFilter: attribute.NewDenyKeysFilter(deniedHTTPAttributes...),
+			},
+		),
+		sdkmetric.NewView(
+			sdkmetric.Instrument{
+				Name: "http.server.request.duration",
+				Kind: sdkmetric.InstrumentKindHistogram,
+			},
+			sdkmetric.Stream{
+				Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+					Boundaries: []float64{
+						0.005, 0.01, 0.025, 0.05, 0.1,
+						0.25, 0.5, 1, 2.5, 5, 10,
+					},
+				},
+				Unit:            "s",
+				AttributeFilter: attribute.NewDenyKeysFilter(deniedHTTPAttributes...),
+			},
+		),
+		sdkmetric.NewView(
+			sdkmetric.Instrument{
+				Name: "http.client.request.duration",
+				Kind: sdkmetric.InstrumentKindHistogram,
+			},
 			sdkmetric.Stream{
 				Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
 					Boundaries: []float64{
@@ -202,56 +648,225 @@ func WithHTTPViews() sdkmetric.Option {
 				Unit: "By",
 			},
 		),
-	)
+	}
 }
 
 func (c *Client) newLoggerProvider(ctx context.Context, res *resource.Resource) (*log.LoggerProvider, error) {
-	exporter, err := otlploghttp.New(
-		ctx,
-		otlploghttp.WithEndpointURL(fmt.Sprintf("%s/v1/logs", c.OTLPBaseURL)),
-		otlploghttp.WithHeaders(headers),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating log exporter: %w", err)
+	var exporters []log.Exporter
+
+	if !c.stdoutMode() {
+		exporter, err := c.newLogExporter(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.options.logFallback != nil {
+			exporter = newFallbackExporter(exporter, c.options.logFallback, c.Meter(instrumentationScope))
+		}
+
+		exporters = append(exporters, exporter)
+	}
+
+	exporters = append(exporters, c.options.logExporters...)
+
+	if c.options.teeToStdout || c.stdoutMode() {
+		stdoutExporter, err := stdoutlog.New()
+		if err != nil {
+			return nil, fmt.Errorf("creating stdout log exporter: %w", err)
+		}
+
+		exporters = append(exporters, stdoutExporter)
 	}
 
-	return log.NewLoggerProvider(
+	opts := []log.LoggerProviderOption{
 		log.WithResource(res),
-		log.WithProcessor(log.NewBatchProcessor(exporter)),
-	), nil
+	}
+
+	for _, exporter := range exporters {
+		if c.exportMetrics != nil {
+			exporter = &selfObservedLogExporter{Exporter: exporter, metrics: c.exportMetrics}
+		}
+
+		processor, err := c.newLogProcessor(exporter)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, log.WithProcessor(processor))
+	}
+
+	return log.NewLoggerProvider(opts...), nil
+}
+
+// newLogProcessor batches records for exporter, or exports them one by one
+// in sync mode, behind the redaction and severity filters. Audit records
+// skip both filters and only get the GCP and tenant attributes added.
+//
+//nolint:ireturn
+func (c *Client) newLogProcessor(exporter log.Exporter) (log.Processor, error) {
+	var export log.Processor
+
+	if c.options.syncExport {
+		export = log.NewSimpleProcessor(exporter)
+	} else {
+		export = c.newBatchLogProcessor(exporter)
+	}
+
+	processor := export
+
+	if c.options.redactLogs {
+		redactor, err := newRedactProcessor(processor, c.options.redactPatterns, c.options.redactDenyKeys)
+		if err != nil {
+			return nil, fmt.Errorf("creating log redaction processor: %w", err)
+		}
+
+		processor = redactor
+	}
+
+	return &auditRouter{
+		Processor: newSeverityProcessor(c.annotateLogs(processor), c.logLevel()),
+		audit:     c.annotateLogs(export),
+	}, nil
+}
+
+// annotateLogs adds the GCP correlation and tenant attributes to the records
+// passed to next.
+//
+//nolint:ireturn
+func (c *Client) annotateLogs(next log.Processor) log.Processor {
+	if c.gcpProjectID != "" {
+		next = &gcpProcessor{Processor: next, projectID: c.gcpProjectID}
+	}
+
+	return &tenantLogProcessor{Processor: next}
+}
+
+// Log batch processor defaults, matching the SDK's.
+const (
+	defaultLogMaxQueueSize = 2048
+	defaultLogMaxBatchSize = 512
+)
+
+// logQueueSize returns the effective log batch queue size.
+func (c *Client) logQueueSize() int {
+	if c.LogMaxQueueSize > 0 {
+		return c.LogMaxQueueSize
+	}
+
+	return defaultLogMaxQueueSize
+}
+
+// logBatchSize returns the effective log export batch size.
+func (c *Client) logBatchSize() int {
+	if c.LogMaxBatchSize > 0 {
+		return c.LogMaxBatchSize
+	}
+
+	return defaultLogMaxBatchSize
+}
+
+// logBatchOptions maps the Log* Client fields to batch processor options,
+// leaving unset ones to the SDK and its OTEL_BLRP_* environment variables.
+func (c *Client) logBatchOptions() []log.BatchProcessorOption {
+	var opts []log.BatchProcessorOption
+
+	if c.LogMaxQueueSize > 0 {
+		opts = append(opts, log.WithMaxQueueSize(c.LogMaxQueueSize))
+	}
+
+	if c.LogMaxBatchSize > 0 {
+		opts = append(opts, log.WithExportMaxBatchSize(c.LogMaxBatchSize))
+	}
+
+	if c.LogExportInterval > 0 {
+		opts = append(opts, log.WithExportInterval(c.LogExportInterval))
+	}
+
+	if c.LogExportTimeout > 0 {
+		opts = append(opts, log.WithExportTimeout(c.LogExportTimeout))
+	}
+
+	return opts
 }
 
-// Trace starts a new span and returns the updated context.
+// Trace starts a new span and returns the updated context. The caller owns
+// the span and must end it; WithSpan and StartSpan do so on its behalf. The
+// tracer has the scope defaults of Client.Tracer, taking the version from
+// the Client that registered the globals.
 //
 //nolint:ireturn
 func Trace(ctx context.Context, packageName, spanName string) (context.Context, otelTrace.Span) {
-	tracer := otel.Tracer(packageName)
-	ctx, span := tracer.Start(ctx, spanName) //nolint:spancheck
+	tracer := cachedTracer(packageName)
 
-	//nolint:spancheck
-	return ctx, span
+	// Passing no options when ctx has no attributes keeps the variadic
+	// slice, which escapes through the Tracer interface, off the heap.
+	if len(CtxAttributes(ctx)) == 0 {
+		return tracer.Start(ctx, spanName) //nolint:spancheck
+	}
+
+	return tracer.Start(ctx, spanName, ctxAttributesOption(ctx)) //nolint:spancheck
 }
 
-// RecordError sets the span status to error and records the error event.
-func RecordError(span otelTrace.Span, err error) {
+// CaptureTraceStatusAndError sets the span status to error, records the
+// error event and tags the span with error.type and error.class.
+func CaptureTraceStatusAndError(span otelTrace.Span, err error) {
 	if err == nil {
 		return
 	}
 
 	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(classifyError(context.Background(), err)...)
 	span.RecordError(err)
 }
 
-// NewLogger returns a reusable slog.Logger bridged to OTel. Cache the result.
+// RecordError records err with a stack trace on the span held in ctx, sets
+// the span status to error and returns err unchanged so it can be used inline:
+//
+//	return silgotel.RecordError(ctx, err)
+//
+// The span is tagged with error.type and error.class (see ClassifyError).
+// When ctx carries a request logger (see LoggingMiddleware) the error is also
+// logged at error level. A nil err is returned as is and nothing is recorded.
+func RecordError(ctx context.Context, err error, attrs ...attribute.KeyValue) error {
+	if err == nil {
+		return nil
+	}
+
+	span := otelTrace.SpanFromContext(ctx)
+	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(classifyError(ctx, err)...)
+	span.RecordError(err,
+		otelTrace.WithStackTrace(true),
+		otelTrace.WithAttributes(attrs...),
+	)
+
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		args := make([]any, 0, len(attrs)+1)
+		args = append(args, slog.Any("err", err))
+
+		for _, kv := range attrs {
+			args = append(args, slog.Any(string(kv.Key), kv.Value.AsInterface()))
+		}
+
+		logger.ErrorContext(ctx, err.Error(), args...)
+	}
+
+	return err
+}
+
+// NewLogger returns a reusable slog.Logger bridged to OTel. Loggers are cached
+// per name, so calling it on every request is cheap. Records logged with a
+// traced context carry trace_id, span_id and trace_flags.
 func NewLogger(packageName string) *slog.Logger {
-	return otelslog.NewLogger(packageName)
+	return cachedLogger(packageName)
 }
 
-// Meter returns a named meter for recording metrics.
+// Meter returns a named meter for recording metrics, with the same scope
+// defaults as Trace.
 //
 //nolint:ireturn
 func Meter(serviceName string) otelMetric.Meter {
-	return otel.Meter(serviceName)
+	return cachedMeter(serviceName)
 }
 
 // Gin HTTP middleware configuration
@@ -619,6 +1234,32 @@ func GinLoggingMiddleware(serviceName string) gin.HandlerFunc {
 	}
 }
 
+// TraceIDHeader is the response header written by TraceIDMiddleware.
+const TraceIDHeader = "X-Trace-Id"
+
+// TraceIDMiddleware writes the current trace ID to the X-Trace-Id response
+// header so support can look the request up in the tracing backend. Mount it
+// after the middleware that starts the server span.
+func TraceIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if traceID := GetTraceID(r.Context()); traceID != "" {
+			w.Header().Set(TraceIDHeader, traceID)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LoggerFromContext returns the request-scoped logger stored by
+// LoggingMiddleware, falling back to slog.Default.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
 func normalizedRoutePattern(r *http.Request) string {
 	if r.Pattern != "" {
 		return r.Pattern
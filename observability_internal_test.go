@@ -0,0 +1,134 @@
+package silgotel
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// foreignSchemaURL stands in for the schema of a semconv version other than
+// the package's, as resource.Default carries after an SDK upgrade.
+const foreignSchemaURL = "https://opentelemetry.io/schemas/1.0.0"
+
+// captureDefaultLogger redirects slog's default logger to a buffer for the
+// duration of the test.
+func captureDefaultLogger(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &buf
+}
+
+func TestMergeResourcesSchemaConflict(t *testing.T) {
+	logs := captureDefaultLogger(t)
+
+	a := resource.NewWithAttributes(foreignSchemaURL, attribute.String("host.name", "a"), attribute.String("os.type", "linux"))
+	b := resource.NewWithAttributes(semconv.SchemaURL, attribute.String("host.name", "b"))
+
+	res, err := mergeResources(t.Context(), a, b)
+	if err != nil {
+		t.Fatalf("mergeResources() error = %v, want the conflict handled", err)
+	}
+
+	if res.SchemaURL() != "" {
+		t.Errorf("schema URL = %q, want none after a conflict", res.SchemaURL())
+	}
+
+	set := res.Set()
+
+	if host, _ := set.Value("host.name"); host.AsString() != "b" {
+		t.Errorf("host.name = %q, want the second resource to win", host.AsString())
+	}
+
+	if osType, _ := set.Value("os.type"); osType.AsString() != "linux" {
+		t.Errorf("os.type = %q, want attributes of the first resource kept", osType.AsString())
+	}
+
+	if out := logs.String(); !strings.Contains(out, "level=WARN") || !strings.Contains(out, foreignSchemaURL) {
+		t.Errorf("logged %q, want a warning naming the conflicting schema URLs", out)
+	}
+}
+
+func TestMergeResourcesSameSchema(t *testing.T) {
+	logs := captureDefaultLogger(t)
+
+	a := resource.NewWithAttributes(semconv.SchemaURL, attribute.String("host.name", "a"))
+	b := resource.NewWithAttributes(semconv.SchemaURL, attribute.String("os.type", "linux"))
+
+	res, err := mergeResources(t.Context(), a, b)
+	if err != nil {
+		t.Fatalf("mergeResources() error = %v", err)
+	}
+
+	if res.SchemaURL() != semconv.SchemaURL {
+		t.Errorf("schema URL = %q, want %q kept", res.SchemaURL(), semconv.SchemaURL)
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("logged %q without a conflict, want nothing", logs.String())
+	}
+}
+
+// foreignSchemaDetector detects a resource built against foreignSchemaURL.
+type foreignSchemaDetector struct{}
+
+func (foreignSchemaDetector) Detect(context.Context) (*resource.Resource, error) {
+	return resource.NewWithAttributes(foreignSchemaURL, attribute.String("cloud.provider", "elsewhere")), nil
+}
+
+func TestNewOtelSDKSurvivesSchemaConflict(t *testing.T) {
+	captureDefaultLogger(t)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := &Client{OTLPBaseURL: collector.URL, ServiceName: "test-service", Environment: "test", Version: "1.0.0"}
+
+	spans := tracetest.NewInMemoryExporter()
+
+	shutdown, err := NewOtelSDK(t.Context(), client, WithoutGlobalRegistration(), WithAdditionalTraceExporter(spans),
+		WithResourceDetectors(foreignSchemaDetector{}))
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v, want setup to survive diverging schema URLs", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	if client.SchemaURL() != semconv.SchemaURL {
+		t.Errorf("SchemaURL() = %q, want the package's %q", client.SchemaURL(), semconv.SchemaURL)
+	}
+
+	_, span := client.Tracer("schema").Start(t.Context(), "op")
+	span.End()
+
+	if err := client.ForceFlush(t.Context()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	exported := spans.GetSpans()
+	if len(exported) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(exported))
+	}
+
+	if provider, _ := exported[0].Resource.Set().Value("cloud.provider"); provider.AsString() != "elsewhere" {
+		t.Errorf("cloud.provider = %q, want the detected attribute kept", provider.AsString())
+	}
+}
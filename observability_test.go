@@ -0,0 +1,354 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+func TestRecordError(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+	errCharge := errors.New("card declined")
+
+	ctx, span := silgotel.Trace(t.Context(), "checkout", "charge")
+	got := silgotel.RecordError(ctx, errCharge, attribute.String("order.id", "42"))
+	span.End()
+
+	if !errors.Is(got, errCharge) {
+		t.Errorf("RecordError() = %v, want the error passed in", got)
+	}
+
+	recorded := sdk.Spans()[0]
+	if recorded.Status().Code != codes.Error || recorded.Status().Description != "card declined" {
+		t.Errorf("span status = %+v, want an error with the message", recorded.Status())
+	}
+
+	events := recorded.Events()
+	if len(events) != 1 || events[0].Name != semconv.ExceptionEventName {
+		t.Fatalf("span events = %v, want one exception event", events)
+	}
+
+	attrs := attribute.NewSet(events[0].Attributes...)
+	for _, key := range []attribute.Key{semconv.ExceptionMessageKey, semconv.ExceptionStacktraceKey, "order.id"} {
+		if _, ok := attrs.Value(key); !ok {
+			t.Errorf("exception event has no %s attribute", key)
+		}
+	}
+
+	spanAttrs := attribute.NewSet(recorded.Attributes()...)
+	if _, ok := spanAttrs.Value(semconv.ErrorTypeKey); !ok {
+		t.Errorf("span attributes = %v, want error.type", spanAttrs.ToSlice())
+	}
+}
+
+func TestRecordErrorNil(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "checkout", "charge")
+
+	if err := silgotel.RecordError(ctx, nil); err != nil {
+		t.Errorf("RecordError(nil) = %v, want nil", err)
+	}
+
+	span.End()
+
+	recorded := sdk.Spans()[0]
+	if recorded.Status().Code != codes.Unset || len(recorded.Events()) != 0 {
+		t.Errorf("RecordError(nil) recorded status %+v and events %v", recorded.Status(), recorded.Events())
+	}
+}
+
+func TestRecordErrorLogsToRequestLogger(t *testing.T) {
+	siltest.NewTestSDK(t)
+
+	var buf bytes.Buffer
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	handler := silgotel.LoggingMiddleware("checkout")(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_ = silgotel.RecordError(r.Context(), errors.New("card declined"))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/charge", nil))
+
+	if out := buf.String(); !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "card declined") {
+		t.Errorf("request log = %q, want the error at error level", out)
+	}
+}
+
+// emitAll records a span, a metric and a log through sdk's client and
+// flushes them to the exporters.
+func emitAll(t *testing.T, sdk *testSDK) {
+	t.Helper()
+
+	_, span := sdk.client.Tracer("emit").Start(t.Context(), "op")
+	span.End()
+
+	counter, err := sdk.client.Meter("emit").Int64Counter("emit.count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "emitted")
+
+	sdk.flush(t)
+}
+
+func TestPerSignalEndpoints(t *testing.T) {
+	base := newRecordingCollector(t)
+	traces := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = base.URL
+	client.TracesURL = traces.URL + "/custom/traces"
+
+	emitAll(t, startSDK(t, client))
+
+	if got, want := traces.Paths(), []string{"/custom/traces"}; !slices.Equal(got, want) {
+		t.Errorf("traces collector paths = %v, want %v", got, want)
+	}
+
+	if got, want := base.Paths(), []string{"/v1/logs", "/v1/metrics"}; !slices.Equal(got, want) {
+		t.Errorf("base collector paths = %v, want %v", got, want)
+	}
+}
+
+func TestPerSignalEndpointsWithoutBaseURL(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = ""
+	client.TracesURL = collector.URL + "/t"
+	client.MetricsURL = collector.URL + "/m"
+	client.LogsURL = collector.URL + "/l"
+
+	emitAll(t, startSDK(t, client))
+
+	if got, want := collector.Paths(), []string{"/l", "/m", "/t"}; !slices.Equal(got, want) {
+		t.Errorf("collector paths = %v, want %v", got, want)
+	}
+}
+
+func TestPerSignalEndpointsMissingSignal(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "")
+
+	client := newClient(t)
+	client.OTLPBaseURL = ""
+	client.TracesURL = "http://localhost:4318/v1/traces"
+	client.MetricsURL = "http://localhost:4318/v1/metrics"
+
+	if err := client.Validate(); err == nil {
+		t.Error("Validate() = nil, want an error for the missing logs endpoint")
+	}
+}
+
+// namingExporter records the names of the spans it exports and fails its
+// shutdown with shutdownErr.
+type namingExporter struct {
+	shutdownErr error
+
+	mu    sync.Mutex
+	names []string
+}
+
+func (e *namingExporter) ExportSpans(_ context.Context, spans []trace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		e.names = append(e.names, span.Name())
+	}
+
+	return nil
+}
+
+func (e *namingExporter) Shutdown(context.Context) error {
+	return e.shutdownErr
+}
+
+func (e *namingExporter) Names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return slices.Clone(e.names)
+}
+
+func TestAdditionalTraceExporter(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	sdk := startSDK(t, client)
+
+	tracer := sdk.client.Tracer("fanout")
+	for _, name := range []string{"checkout", "charge"} {
+		_, span := tracer.Start(t.Context(), name)
+		span.End()
+	}
+
+	var inMemory []string
+	for _, span := range sdk.Spans(t) {
+		inMemory = append(inMemory, span.Name)
+	}
+
+	if want := []string{"checkout", "charge"}; !slices.Equal(inMemory, want) {
+		t.Errorf("in-memory exporter spans = %v, want %v", inMemory, want)
+	}
+
+	if got := collector.SpanNames(t); !slices.Equal(got, inMemory) {
+		t.Errorf("collector spans = %v, want the same as the in-memory exporter %v", got, inMemory)
+	}
+}
+
+func TestShutdownFlushesEveryExporter(t *testing.T) {
+	errShutdown := errors.New("exporter shutdown failed")
+	collector := newRecordingCollector(t)
+	exporter := &namingExporter{shutdownErr: errShutdown}
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithAdditionalTraceExporter(exporter),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_, span := client.Tracer("fanout").Start(t.Context(), "pending")
+	span.End()
+
+	err = shutdown(t.Context())
+	if !errors.Is(err, errShutdown) {
+		t.Errorf("shutdown() error = %v, want the exporter's shutdown error joined", err)
+	}
+
+	if got := exporter.Names(); !slices.Equal(got, []string{"pending"}) {
+		t.Errorf("additional exporter spans = %v, want the span flushed on shutdown", got)
+	}
+
+	if got := collector.SpanNames(t); !slices.Equal(got, []string{"pending"}) {
+		t.Errorf("collector spans = %v, want the span flushed on shutdown", got)
+	}
+}
+
+func TestSyncSpanExport(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithSyncSpanExport(),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	_, span := client.Tracer("cli").Start(t.Context(), "migrate")
+	span.End()
+
+	slog.New(client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "migrated")
+
+	// No ForceFlush: both exports happen as the span and record end.
+	if names := collector.SpanNames(t); !slices.Equal(names, []string{"migrate"}) {
+		t.Errorf("collector received spans %v, want [migrate]", names)
+	}
+
+	if paths := collector.Paths(); !slices.Contains(paths, "/v1/logs") {
+		t.Errorf("collector received %v, want a log export", paths)
+	}
+}
+
+func TestSyncSpanExportConflicts(t *testing.T) {
+	tests := map[string]func(*silgotel.Client) []silgotel.Option{
+		"batch timeout": func(*silgotel.Client) []silgotel.Option {
+			return []silgotel.Option{silgotel.WithBatchTimeout(time.Second)}
+		},
+		"log batch fields": func(c *silgotel.Client) []silgotel.Option {
+			c.LogMaxBatchSize = 10
+
+			return nil
+		},
+	}
+
+	for name, configure := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t)
+
+			opts := append([]silgotel.Option{
+				silgotel.WithoutGlobalRegistration(),
+				silgotel.WithSyncSpanExport(),
+			}, configure(client)...)
+
+			_, err := silgotel.NewOtelSDK(t.Context(), client, opts...)
+			if err == nil || !strings.Contains(err.Error(), "WithSyncSpanExport") {
+				t.Errorf("NewOtelSDK() error = %v, want the sync export conflict", err)
+			}
+		})
+	}
+}
+
+func TestOTLPBaseURLShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		suffix string
+		want   []string
+	}{
+		{"bare", "", []string{"/v1/logs", "/v1/metrics", "/v1/traces"}},
+		{"trailing slash", "/", []string{"/v1/logs", "/v1/metrics", "/v1/traces"}},
+		{"path prefix", "/otlp", []string{"/otlp/v1/logs", "/otlp/v1/metrics", "/otlp/v1/traces"}},
+		{"path prefix with trailing slash", "/otlp/", []string{"/otlp/v1/logs", "/otlp/v1/metrics", "/otlp/v1/traces"}},
+		{"signal path", "/otlp/v1/traces", []string{"/otlp/v1/logs", "/otlp/v1/metrics", "/otlp/v1/traces"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := newRecordingCollector(t)
+
+			client := newClient(t)
+			client.OTLPBaseURL = collector.URL + tt.suffix
+
+			emitAll(t, startSDK(t, client))
+
+			if got := collector.Paths(); !slices.Equal(got, tt.want) {
+				t.Errorf("collector paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOTLPBaseURLScheme(t *testing.T) {
+	for _, base := range []string{"ftp://collector:4318", "collector:4318", "http://"} {
+		client := newClient(t)
+		client.OTLPBaseURL = base
+
+		if err := client.Validate(); err == nil || !strings.Contains(err.Error(), "otlpBaseURL") {
+			t.Errorf("Validate() with %q = %v, want an otlpBaseURL error", base, err)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package silgotel
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+// Option configures optional SDK behaviour when calling NewOtelSDK.
+type Option func(*options)
+
+type options struct {
+	startupCheckTimeout time.Duration
+
+	sampler             trace.Sampler
+	environmentSampling map[string]float64
+	fallbackSampling    float64
+
+	attributeAllowLists map[string][]attribute.Key
+
+	logLevel *slog.Level
+
+	redactLogs     bool
+	redactPatterns []string
+	redactDenyKeys []string
+
+	spanAttributeDenyList []string
+
+	spanExporters  []trace.SpanExporter
+	spanProcessors []trace.SpanProcessor
+	metricReaders  []sdkmetric.Reader
+	logExporters   []log.Exporter
+	teeToStdout    bool
+
+	errorHandler func(error)
+
+	skipGlobals bool
+
+	exemplarFilter string
+
+	syncExport bool
+
+	alwaysSampleErrors bool
+
+	diagnosticsLevel  int
+	diagnosticsWriter io.Writer
+
+	processMetrics bool
+	runtimeMetrics bool
+
+	ignoredPaths   []string
+	ignoreRequests []func(*http.Request) bool
+
+	preset string
+	gzip   bool
+
+	clientCertificate *tls.Certificate
+	tlsConfig         *tls.Config
+
+	proxy func(*http.Request) (*url.URL, error)
+
+	exporterHTTPClient *http.Client
+
+	logFallback io.Writer
+
+	gcpCorrelation bool
+	gcpProjectID   string
+
+	skipSelfObservability bool
+
+	leakDetector *LeakDetector
+
+	bestEffortInit bool
+
+	errorClassifier func(error) string
+
+	headers            map[string]string
+	batchTimeout       time.Duration
+	metricInterval     time.Duration
+	resourceAttributes []attribute.KeyValue
+	resourceDetectors  []resource.Detector
+
+	grpcDialOptions []grpc.DialOption
+
+	prometheus     bool
+	prometheusOnly bool
+}
+
+// WithStartupCheck probes the OTLP endpoint during NewOtelSDK and fails
+// initialization when it is unreachable or answers with an unexpected status,
+// a 404 for a wrong collector path included. The probe is an empty trace
+// export POSTed to the traces URL only; the metrics and logs URLs are not
+// checked. It is opt-in so that air-gapped startup keeps working.
+func WithStartupCheck(timeout time.Duration) Option {
+	return func(o *options) {
+		o.startupCheckTimeout = timeout
+	}
+}
+
+// WithSampler sets the sampler used by the tracer provider. It takes
+// precedence over WithEnvironmentSampling.
+func WithSampler(sampler trace.Sampler) Option {
+	return func(o *options) {
+		o.sampler = sampler
+	}
+}
+
+// WithEnvironmentSampling samples a ratio of root traces chosen by the
+// client's Environment, e.g. {"staging": 1, "prod": 0.1}. Environments that
+// are not listed use the fallback ratio. Child spans follow their parent.
+func WithEnvironmentSampling(ratios map[string]float64, fallback float64) Option {
+	return func(o *options) {
+		o.environmentSampling = ratios
+		o.fallbackSampling = fallback
+	}
+}
+
+// WithAttributeAllowList caps the cardinality of the named instrument by
+// keeping only the listed attribute keys on its data points. It can be
+// repeated and composes with the views installed by WithHTTPViews.
+func WithAttributeAllowList(instrumentName string, allowedKeys ...string) Option {
+	return func(o *options) {
+		if o.attributeAllowLists == nil {
+			o.attributeAllowLists = make(map[string][]attribute.Key)
+		}
+
+		for _, key := range allowedKeys {
+			o.attributeAllowLists[instrumentName] = append(
+				o.attributeAllowLists[instrumentName], attribute.Key(key),
+			)
+		}
+	}
+}
+
+// WithLogLevel drops log records below level before they are exported. The
+// default is Info in production environments and Debug otherwise.
+func WithLogLevel(level slog.Level) Option {
+	return func(o *options) {
+		o.logLevel = &level
+	}
+}
+
+// WithLogRedaction scrubs emails, phone numbers and national ID numbers from
+// exported log bodies and string attributes, along with any value matching
+// the extra patterns. National IDs are recognized after a "national ID",
+// "ID no", "ID number" or "NID" label, and as the value of the national_id,
+// national_id_number and id_number attributes; other numbers are left alone.
+// Attributes whose key is in denyKeys are always replaced.
+func WithLogRedaction(patterns []string, denyKeys []string) Option {
+	return func(o *options) {
+		o.redactLogs = true
+		o.redactPatterns = append(o.redactPatterns, patterns...)
+		o.redactDenyKeys = append(o.redactDenyKeys, denyKeys...)
+	}
+}
+
+// WithSpanAttributeDenyList strips the listed attribute keys, matched case
+// insensitively, from spans and span events before they are exported.
+func WithSpanAttributeDenyList(keys ...string) Option {
+	return func(o *options) {
+		o.spanAttributeDenyList = append(o.spanAttributeDenyList, keys...)
+	}
+}
+
+// WithAdditionalTraceExporter sends spans to exporter alongside the OTLP
+// exporter. It can be repeated.
+func WithAdditionalTraceExporter(exporter trace.SpanExporter) Option {
+	return func(o *options) {
+		o.spanExporters = append(o.spanExporters, exporter)
+	}
+}
+
+// WithSpanProcessor registers processor on the tracer provider ahead of the
+// exporting processors. It can be repeated; processors run in the order
+// given and are flushed and shut down with the provider.
+func WithSpanProcessor(processor trace.SpanProcessor) Option {
+	return func(o *options) {
+		o.spanProcessors = append(o.spanProcessors, processor)
+	}
+}
+
+// WithAdditionalMetricReader registers reader on the meter provider
+// alongside the OTLP periodic reader. It can be repeated.
+func WithAdditionalMetricReader(reader sdkmetric.Reader) Option {
+	return func(o *options) {
+		o.metricReaders = append(o.metricReaders, reader)
+	}
+}
+
+// WithAdditionalLogExporter sends log records to exporter alongside the OTLP
+// exporter. It can be repeated.
+func WithAdditionalLogExporter(exporter log.Exporter) Option {
+	return func(o *options) {
+		o.logExporters = append(o.logExporters, exporter)
+	}
+}
+
+// WithTeeToStdout writes every span, metric and log record to stdout in
+// addition to exporting it over OTLP.
+func WithTeeToStdout() Option {
+	return func(o *options) {
+		o.teeToStdout = true
+	}
+}
+
+// WithErrorHandler routes errors the SDK cannot return, such as export
+// failures, to handler instead of the default handler, which counts them on
+// silgotel.export.errors and logs them at a limited rate. The OTel error handler
+// is a process-wide global, so it is only installed when the Client registers
+// globals; the previous handler is restored on shutdown.
+func WithErrorHandler(handler func(error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// WithoutGlobalRegistration keeps the providers and propagator off the OTel
+// globals so several Clients can coexist in one process. Use the Client's
+// Tracer, Meter and provider accessors instead of the package-level helpers.
+func WithoutGlobalRegistration() Option {
+	return func(o *options) {
+		o.skipGlobals = true
+	}
+}
+
+// WithSyncSpanExport exports every span and log record synchronously as it
+// ends instead of batching. Nothing is lost when a short-lived CLI exits
+// before the batch delay, at the cost of an export round trip per record, so
+// it is meant for tests and CLIs rather than servers. Combining it with
+// WithBatchTimeout or the Client's Log batch fields fails NewOtelSDK.
+func WithSyncSpanExport() Option {
+	return func(o *options) {
+		o.syncExport = true
+	}
+}
+
+// WithAlwaysSampleErrors exports spans that ended with an error status even
+// when the sampler decided not to sample them. Unsampled spans are recorded
+// in-process for this, which costs memory and CPU on every dropped span.
+// Error spans join the exporters' batch queues like sampled spans, so ending
+// them never waits on the collector.
+func WithAlwaysSampleErrors() Option {
+	return func(o *options) {
+		o.alwaysSampleErrors = true
+	}
+}
+
+// WithDiagnostics enables the OTel SDK's internal logging at the given
+// verbosity: 1 shows warnings, 4 informational messages and 8 debug output.
+// Output goes to stderr unless WithDiagnosticsWriter is used. Diagnostics are
+// off by default and the SDK's default logger is restored on shutdown.
+func WithDiagnostics(level int) Option {
+	return func(o *options) {
+		o.diagnosticsLevel = level
+	}
+}
+
+// WithDiagnosticsWriter sends the output enabled by WithDiagnostics to w
+// instead of stderr.
+func WithDiagnosticsWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.diagnosticsWriter = w
+	}
+}
+
+// WithProcessMetrics reports the process CPU time, resident memory and open
+// file descriptor count as process.cpu.time, process.memory.usage and
+// process.open_file_descriptors. The values are read from /proc, so nothing
+// is registered on platforms other than Linux.
+func WithProcessMetrics() Option {
+	return func(o *options) {
+		o.processMetrics = true
+	}
+}
+
+// WithIgnoredPaths makes the package's HTTP middleware pass requests whose
+// path starts with one of prefixes, such as "/health" or "/metrics",
+// straight to the next handler without a span or metric sample. It can be
+// repeated.
+func WithIgnoredPaths(prefixes ...string) Option {
+	return func(o *options) {
+		o.ignoredPaths = append(o.ignoredPaths, prefixes...)
+	}
+}
+
+// WithIgnoredRequests is like WithIgnoredPaths for requests matched by
+// ignore. It can be repeated.
+func WithIgnoredRequests(ignore func(*http.Request) bool) Option {
+	return func(o *options) {
+		o.ignoreRequests = append(o.ignoreRequests, ignore)
+	}
+}
+
+// WithClientCertificate presents cert to the collector on every exporter
+// connection, for collectors behind an mTLS gateway. It takes precedence
+// over the ClientCertFile and ClientKeyFile fields.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *options) {
+		o.clientCertificate = &cert
+	}
+}
+
+// WithTLSConfig uses a copy of config for every exporter connection and the
+// startup check. The CAFile, ClientCertFile and WithClientCertificate
+// settings are applied on top of it.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithProxy routes every exporter request through the proxy chosen by proxy,
+// which has the signature of http.Transport.Proxy. It takes precedence over
+// the ProxyURL field.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(o *options) {
+		o.proxy = proxy
+	}
+}
+
+// WithExporterHTTPClient sends every OTLP export, and the startup check,
+// through client, for transports that sign requests or tune connection
+// pooling. The client owns its TLS and proxy settings, so combining it with
+// WithClientCertificate, WithTLSConfig, WithProxy, CAFile, ClientCertFile or
+// ProxyURL fails NewOtelSDK. Compression from WithPreset still applies.
+func WithExporterHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.exporterHTTPClient = client
+	}
+}
+
+// WithLogFallback writes log batches the OTLP exporter could not deliver,
+// after its retries, to w as JSON lines so a collector outage does not lose
+// them. The volume is counted on silgotel.log.fallback.records, and normal
+// export resumes on its own once the collector is back.
+func WithLogFallback(w io.Writer) Option {
+	return func(o *options) {
+		o.logFallback = w
+	}
+}
+
+// WithGCPLogCorrelation adds the logging.googleapis.com/trace, spanId and
+// trace_sampled fields to exported log records and to the console output of
+// NewSlogHandler, so Cloud Logging links entries to their traces. An empty
+// projectID is looked up on the GCP metadata server; when that fails the
+// fields are left out.
+func WithGCPLogCorrelation(projectID string) Option {
+	return func(o *options) {
+		o.gcpCorrelation = true
+		o.gcpProjectID = projectID
+	}
+}
+
+// WithoutSelfObservability stops the package from recording metrics about
+// its own exports: silgotel.spans.exported, silgotel.spans.dropped,
+// silgotel.export.errors and silgotel.export.duration. Use it when the metric
+// pipeline itself is failing and those metrics add to the noise.
+func WithoutSelfObservability() Option {
+	return func(o *options) {
+		o.skipSelfObservability = true
+	}
+}
+
+// WithLeakDetection tracks every span until it ends and reports the ones
+// never ended, with the file:line that started them, as an error from
+// shutdown and through Client.SpanLeaks. It costs a stack capture per span,
+// so enable it in tests and debugging sessions only.
+func WithLeakDetection() Option {
+	return func(o *options) {
+		o.leakDetector = NewLeakDetector()
+	}
+}
+
+// WithBestEffortInit keeps NewOtelSDK going when a signal's provider cannot
+// be built, for example because its exporter rejects the endpoint. The failed
+// signal falls back to a no-op provider, the others come up as usual, and
+// NewOtelSDK returns a working shutdown function together with an
+// *InitError naming what failed, which the caller can log and carry on.
+func WithBestEffortInit() Option {
+	return func(o *options) {
+		o.bestEffortInit = true
+	}
+}
+
+// WithErrorClassifier replaces DefaultErrorClassifier as the source of the
+// error.class attribute and silgotel.errors class set by RecordError,
+// CaptureTraceStatusAndError and LogError. The previous classifier is
+// restored on shutdown; nothing is installed under WithoutGlobalRegistration.
+func WithErrorClassifier(classify func(error) string) Option {
+	return func(o *options) {
+		o.errorClassifier = classify
+	}
+}
+
+// WithHeaders sends headers, such as an API key, with every OTLP export and
+// with the startup check, on top of those in OTEL_EXPORTER_OTLP_HEADERS and
+// the Client's Headers. It can be repeated; later values win.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		if o.headers == nil {
+			o.headers = make(map[string]string, len(headers))
+		}
+
+		maps.Copy(o.headers, headers)
+	}
+}
+
+// WithBatchTimeout sets how long the span batch processor waits before
+// exporting a partial batch. The default is 5 seconds; LogExportInterval
+// plays the same role for logs.
+func WithBatchTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.batchTimeout = timeout
+	}
+}
+
+// WithMetricInterval sets how often metrics are collected and exported. The
+// default is 30 seconds.
+func WithMetricInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.metricInterval = interval
+	}
+}
+
+// WithResourceAttributes adds attrs to the resource describing the service.
+// OTEL_RESOURCE_ATTRIBUTES and the Client's service fields take precedence
+// over them. It can be repeated.
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *options) {
+		o.resourceAttributes = append(o.resourceAttributes, attrs...)
+	}
+}
+
+// Exemplar filters accepted by WithExemplars.
+const (
+	ExemplarsAlwaysOn   = "always_on"
+	ExemplarsTraceBased = "trace_based"
+	ExemplarsAlwaysOff  = "always_off"
+)
+
+// WithExemplars selects which measurements carry trace exemplars: every
+// measurement (always_on), those made inside a sampled span (trace_based, the
+// default) or none (always_off).
+func WithExemplars(filter string) Option {
+	return func(o *options) {
+		o.exemplarFilter = filter
+	}
+}
+
+// validate reports option values that cannot be used to build the SDK.
+func (o *options) validate() error {
+	switch o.exemplarFilter {
+	case "", ExemplarsAlwaysOn, ExemplarsTraceBased, ExemplarsAlwaysOff:
+	default:
+		return fmt.Errorf("silgotel: unknown exemplar filter %q", o.exemplarFilter) //nolint: err113
+	}
+
+	if o.batchTimeout < 0 || o.metricInterval < 0 {
+		return fmt.Errorf("silgotel: batch timeout and metric interval must not be negative") //nolint: err113
+	}
+
+	if o.diagnosticsLevel < 0 {
+		return fmt.Errorf("silgotel: diagnostics level must not be negative, got %d", o.diagnosticsLevel) //nolint: err113
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package silgotel
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Presets accepted by WithPreset. PresetAuto picks one from the client's
+// Environment.
+const (
+	PresetAuto       = "auto"
+	PresetLocal      = "local"
+	PresetStaging    = "staging"
+	PresetProduction = "production"
+)
+
+// productionSamplingRatio is the share of root traces the production preset
+// samples.
+const productionSamplingRatio = 0.1
+
+// WithPreset applies opinionated defaults for an environment:
+//
+//   - local: unless the Client's Mode is set, spans, metrics and logs are
+//     written to stdout instead of exported, so no collector or endpoint is
+//     needed. Every trace is sampled and Debug logs are kept.
+//   - staging: every trace is sampled, Info logs are exported and payloads
+//     are gzip compressed.
+//   - production: 10% of root traces are sampled, Info logs are exported and
+//     payloads are gzip compressed.
+//
+// Options set explicitly, such as WithSampler or WithLogLevel, and the
+// Client's Sampler and SamplingRatio fields win over the preset regardless of
+// their order. Unknown names fail NewOtelSDK.
+func WithPreset(name string) Option {
+	return func(o *options) {
+		o.preset = name
+	}
+}
+
+// presetFor maps an Environment value to the preset PresetAuto applies.
+func presetFor(environment string) string {
+	switch {
+	case isProduction(environment):
+		return PresetProduction
+	case strings.EqualFold(environment, "staging"), strings.EqualFold(environment, "testing"):
+		return PresetStaging
+	default:
+		return PresetLocal
+	}
+}
+
+// applyPreset fills the options left unset with the preset's defaults.
+func (c *Client) applyPreset() error {
+	preset := c.options.preset
+	if preset == "" {
+		return nil
+	}
+
+	if preset == PresetAuto {
+		preset = presetFor(c.Environment)
+	}
+
+	explicitSampler := c.options.sampler != nil || c.options.environmentSampling != nil ||
+		c.configuredSampler() != nil
+
+	var (
+		sampler trace.Sampler
+		level   slog.Level
+	)
+
+	switch preset {
+	case PresetLocal:
+		sampler = trace.AlwaysSample()
+		level = slog.LevelDebug
+
+		if c.Mode == "" {
+			c.Mode = ModeStdout
+		}
+	case PresetStaging:
+		sampler = trace.ParentBased(trace.AlwaysSample())
+		level = slog.LevelInfo
+		c.options.gzip = true
+	case PresetProduction:
+		sampler = trace.ParentBased(trace.TraceIDRatioBased(productionSamplingRatio))
+		level = slog.LevelInfo
+		c.options.gzip = true
+	default:
+		return fmt.Errorf("silgotel: unknown preset %q", c.options.preset) //nolint: err113
+	}
+
+	if !explicitSampler {
+		c.options.sampler = sampler
+	}
+
+	if c.options.logLevel == nil {
+		c.options.logLevel = &level
+	}
+
+	return nil
+}
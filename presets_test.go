@@ -0,0 +1,166 @@
+package silgotel
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// presetClient returns a client for environment with opts applied and its
+// preset resolved.
+func presetClient(t *testing.T, environment string, opts ...Option) *Client {
+	t.Helper()
+
+	client := &Client{ServiceName: "test-service", Environment: environment, Version: "1.0.0"}
+	for _, opt := range opts {
+		opt(&client.options)
+	}
+
+	err := client.applyPreset()
+	if err != nil {
+		t.Fatalf("applyPreset() error = %v", err)
+	}
+
+	return client
+}
+
+func TestPresets(t *testing.T) {
+	tests := []struct {
+		preset      string
+		environment string
+		sampler     string
+		level       slog.Level
+		gzip        bool
+		mode        string
+	}{
+		{
+			preset: PresetLocal, sampler: "AlwaysOnSampler",
+			level: slog.LevelDebug, mode: ModeStdout,
+		},
+		{
+			preset: PresetStaging, sampler: trace.ParentBased(trace.AlwaysSample()).Description(),
+			level: slog.LevelInfo, gzip: true,
+		},
+		{
+			preset: PresetProduction, sampler: trace.ParentBased(trace.TraceIDRatioBased(0.1)).Description(),
+			level: slog.LevelInfo, gzip: true,
+		},
+		{
+			preset: PresetAuto, environment: "prod", sampler: trace.ParentBased(trace.TraceIDRatioBased(0.1)).Description(),
+			level: slog.LevelInfo, gzip: true,
+		},
+		{
+			preset: PresetAuto, environment: "testing", sampler: trace.ParentBased(trace.AlwaysSample()).Description(),
+			level: slog.LevelInfo, gzip: true,
+		},
+		{
+			preset: PresetAuto, environment: "dev", sampler: "AlwaysOnSampler",
+			level: slog.LevelDebug, mode: ModeStdout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset+" "+tt.environment, func(t *testing.T) {
+			c := presetClient(t, tt.environment, WithPreset(tt.preset))
+
+			if got := c.options.sampler.Description(); got != tt.sampler {
+				t.Errorf("sampler = %s, want %s", got, tt.sampler)
+			}
+
+			if *c.options.logLevel != tt.level {
+				t.Errorf("log level = %v, want %v", *c.options.logLevel, tt.level)
+			}
+
+			if c.options.gzip != tt.gzip || c.Mode != tt.mode {
+				t.Errorf("gzip, mode = %v, %q, want %v, %q", c.options.gzip, c.Mode, tt.gzip, tt.mode)
+			}
+		})
+	}
+}
+
+func TestPresetExplicitSettingsWin(t *testing.T) {
+	c := presetClient(t, "production",
+		WithLogLevel(slog.LevelWarn),
+		WithPreset(PresetProduction),
+		WithSampler(trace.NeverSample()),
+	)
+
+	if got := c.options.sampler.Description(); got != "AlwaysOffSampler" {
+		t.Errorf("sampler = %s, want WithSampler to win whatever the order", got)
+	}
+
+	if *c.options.logLevel != slog.LevelWarn {
+		t.Errorf("log level = %v, want WithLogLevel to win", *c.options.logLevel)
+	}
+
+	client := &Client{ServiceName: "test-service", Environment: "dev", Version: "1.0.0", Mode: ModeOTLP, Sampler: "always_off"}
+	WithPreset(PresetLocal)(&client.options)
+
+	err := client.applyPreset()
+	if err != nil {
+		t.Fatalf("applyPreset() error = %v", err)
+	}
+
+	if client.Mode != ModeOTLP {
+		t.Errorf("mode = %q, want the Client's Mode kept", client.Mode)
+	}
+
+	if client.options.sampler != nil {
+		t.Errorf("preset sampler = %s, want the Client's Sampler field to win", client.options.sampler.Description())
+	}
+}
+
+func TestPresetUnknown(t *testing.T) {
+	client := &Client{}
+	WithPreset("qa")(&client.options)
+
+	if err := client.applyPreset(); err == nil {
+		t.Error("applyPreset() = nil, want an error for the unknown preset")
+	}
+}
+
+func TestPresetProductionCompressesExports(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		encodings []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		mu.Lock()
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{OTLPBaseURL: server.URL, ServiceName: "test-service", Environment: "production", Version: "1.0.0"}
+
+	shutdown, err := NewOtelSDK(t.Context(), client, WithoutGlobalRegistration(), WithPreset(PresetAuto))
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_ = client.ForceFlush(t.Context())
+	_ = shutdown(t.Context())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(encodings) == 0 {
+		t.Fatal("collector received no exports")
+	}
+
+	for _, encoding := range encodings {
+		if encoding != "gzip" {
+			t.Errorf("export Content-Encoding = %q, want gzip", encoding)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package silgotel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/self/stat. It is
+// 100 on every Linux architecture Go supports.
+const clockTicks = 100
+
+// registerProcessMetrics registers the WithProcessMetrics instruments on
+// meter. The registration is removed by UnregisterAll on shutdown.
+func (c *Client) registerProcessMetrics(meter otelMetric.Meter) error {
+	cpuTime, err := meter.Float64ObservableCounter(
+		"process.cpu.time",
+		otelMetric.WithDescription("Total CPU seconds broken down by mode"),
+		otelMetric.WithUnit("s"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating process.cpu.time: %w", err)
+	}
+
+	memoryUsage, err := meter.Int64ObservableUpDownCounter(
+		"process.memory.usage",
+		otelMetric.WithDescription("The amount of physical memory in use"),
+		otelMetric.WithUnit("By"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating process.memory.usage: %w", err)
+	}
+
+	openFDs, err := meter.Int64ObservableUpDownCounter(
+		"process.open_file_descriptors",
+		otelMetric.WithDescription("Number of file descriptors in use by the process"),
+		otelMetric.WithUnit("{file_descriptor}"),
+	)
+	if err != nil {
+		return fmt.Errorf("creating process.open_file_descriptors: %w", err)
+	}
+
+	registration, err := meter.RegisterCallback(
+		func(_ context.Context, observer otelMetric.Observer) error {
+			if user, system, err := readProcessCPUTime(); err == nil {
+				observer.ObserveFloat64(cpuTime, user,
+					otelMetric.WithAttributes(attribute.String("cpu.mode", "user")))
+				observer.ObserveFloat64(cpuTime, system,
+					otelMetric.WithAttributes(attribute.String("cpu.mode", "system")))
+			}
+
+			if rss, err := readProcessRSS(); err == nil {
+				observer.ObserveInt64(memoryUsage, rss)
+			}
+
+			if entries, err := os.ReadDir("/proc/self/fd"); err == nil {
+				observer.ObserveInt64(openFDs, int64(len(entries)))
+			}
+
+			return nil
+		},
+		cpuTime, memoryUsage, openFDs,
+	)
+	if err != nil {
+		return fmt.Errorf("registering process metrics: %w", err)
+	}
+
+	c.gaugesMu.Lock()
+	c.gauges = append(c.gauges, registration)
+	c.gaugesMu.Unlock()
+
+	return nil
+}
+
+var errUnexpectedProcStat = errors.New("silgotel: unexpected /proc/self/stat format") //nolint: gochecknoglobals
+
+// readProcessCPUTime returns the user and system CPU seconds of the process
+// from /proc/self/stat.
+func readProcessCPUTime() (float64, float64, error) {
+	stat, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The command name may contain spaces, so the fields are counted from
+	// the closing parenthesis. utime and stime are fields 14 and 15.
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, 0, errUnexpectedProcStat
+	}
+
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 13 {
+		return 0, 0, errUnexpectedProcStat
+	}
+
+	user, err := strconv.ParseFloat(fields[11], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	system, err := strconv.ParseFloat(fields[12], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return user / clockTicks, system / clockTicks, nil
+}
+
+// readProcessRSS returns the resident set size of the process in bytes from
+// /proc/self/statm.
+func readProcessRSS() (int64, error) {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("silgotel: unexpected /proc/self/statm format") //nolint: err113
+	}
+
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return pages * int64(os.Getpagesize()), nil
+}
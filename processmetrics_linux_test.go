@@ -0,0 +1,50 @@
+package silgotel_test
+
+import (
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithProcessMetrics())
+
+	// Burn enough CPU for the clock-tick resolution of /proc/self/stat.
+	for start := time.Now(); time.Since(start) < 30*time.Millisecond; {
+	}
+
+	rm := sdk.Metrics(t)
+
+	cpu, ok := findMetric(rm, "process.cpu.time")
+	if !ok {
+		t.Fatal("process.cpu.time was not collected")
+	}
+
+	var seconds float64
+	for _, point := range cpu.Data.(metricdata.Sum[float64]).DataPoints { //nolint:forcetypeassert
+		seconds += point.Value
+	}
+
+	if seconds <= 0 {
+		t.Errorf("process.cpu.time = %v, want a positive number of seconds", seconds)
+	}
+
+	for name, minimum := range map[string]int64{
+		"process.memory.usage":          1 << 20,
+		"process.open_file_descriptors": 3,
+	} {
+		m, ok := findMetric(rm, name)
+		if !ok {
+			t.Errorf("%s was not collected", name)
+
+			continue
+		}
+
+		points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+		if len(points) != 1 || points[0].Value < minimum {
+			t.Errorf("%s = %v, want one point of at least %d", name, points, minimum)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package silgotel
+
+import otelMetric "go.opentelemetry.io/otel/metric"
+
+// registerProcessMetrics is a no-op: the process metrics are read from /proc,
+// which only exists on Linux.
+func (c *Client) registerProcessMetrics(_ otelMetric.Meter) error {
+	return nil
+}
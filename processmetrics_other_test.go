@@ -0,0 +1,19 @@
+//go:build !linux
+
+package silgotel_test
+
+import (
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithProcessMetrics())
+
+	for _, name := range []string{"process.cpu.time", "process.memory.usage", "process.open_file_descriptors"} {
+		if _, ok := findMetric(sdk.Metrics(t), name); ok {
+			t.Errorf("%s was registered off Linux", name)
+		}
+	}
+}
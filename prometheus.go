@@ -0,0 +1,66 @@
+package silgotel
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// WithPrometheus also exposes metrics for Prometheus to scrape, through the
+// handler returned by Client.MetricsHandler. Metrics keep being pushed over
+// OTLP; use WithPrometheusOnly to stop that.
+func WithPrometheus() Option {
+	return func(o *options) {
+		o.prometheus = true
+	}
+}
+
+// WithPrometheusOnly is like WithPrometheus but replaces the periodic OTLP
+// metric export instead of adding to it. Traces and logs are still
+// exported over OTLP.
+func WithPrometheusOnly() Option {
+	return func(o *options) {
+		o.prometheus = true
+		o.prometheusOnly = true
+	}
+}
+
+// newPrometheusReader creates the pull reader behind MetricsHandler. Each
+// Client gets its own registry so that several can coexist in a process.
+//
+//nolint:ireturn
+func (c *Client) newPrometheusReader() (sdkmetric.Reader, error) {
+	c.promRegistry = prometheus.NewRegistry()
+
+	opts := []otelprom.Option{otelprom.WithRegisterer(c.promRegistry)}
+
+	if producer := c.runtimeProducer(); producer != nil {
+		opts = append(opts, otelprom.WithProducer(producer))
+	}
+
+	reader, err := otelprom.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating prometheus exporter: %w", err)
+	}
+
+	return reader, nil
+}
+
+// MetricsHandler returns the handler serving metrics in the Prometheus
+// exposition format, to be mounted on /metrics:
+//
+//	mux.Handle("/metrics", otelClient.MetricsHandler())
+//
+// It answers 404 unless NewOtelSDK ran with WithPrometheus or
+// WithPrometheusOnly.
+func (c *Client) MetricsHandler() http.Handler {
+	if c.promRegistry == nil {
+		return http.NotFoundHandler()
+	}
+
+	return promhttp.HandlerFor(c.promRegistry, promhttp.HandlerOpts{})
+}
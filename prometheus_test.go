@@ -0,0 +1,97 @@
+package silgotel_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+// scrape fetches the client's MetricsHandler and returns the status and
+// body.
+func scrape(t *testing.T, client *silgotel.Client) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	client.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+
+	return rec.Code, string(body)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithPrometheus())
+
+	counter, err := sdk.client.Meter("orders").Int64Counter("orders.created")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+
+	counter.Add(t.Context(), 3)
+
+	status, body := scrape(t, sdk.client)
+	if status != http.StatusOK {
+		t.Fatalf("GET /metrics = %d, want 200", status)
+	}
+
+	if !strings.Contains(body, "# TYPE orders_created_total counter") {
+		t.Errorf("exposition lacks the counter's TYPE line:\n%s", body)
+	}
+
+	var sample string
+
+	for line := range strings.SplitSeq(body, "\n") {
+		if strings.HasPrefix(line, "orders_created_total{") {
+			sample = line
+		}
+	}
+
+	if !strings.HasSuffix(sample, " 3") || !strings.Contains(sample, `otel_scope_name="orders"`) {
+		t.Errorf("orders_created_total sample = %q, want 3 from the orders scope", sample)
+	}
+
+	if !strings.Contains(body, `service_name="test-service"`) {
+		t.Errorf("exposition lacks the service's target_info:\n%s", body)
+	}
+}
+
+func TestMetricsHandlerWithoutPrometheus(t *testing.T) {
+	client := newClient(t)
+
+	if status, _ := scrape(t, client); status != http.StatusNotFound {
+		t.Errorf("GET /metrics before setup = %d, want 404", status)
+	}
+
+	startSDK(t, client)
+
+	if status, _ := scrape(t, client); status != http.StatusNotFound {
+		t.Errorf("GET /metrics without WithPrometheus = %d, want 404", status)
+	}
+}
+
+func TestWithPrometheusOnly(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	sdk := startSDK(t, client, silgotel.WithPrometheusOnly())
+
+	_, span := sdk.client.Tracer("orders").Start(t.Context(), "create")
+	span.End()
+
+	sdk.flush(t)
+
+	if paths := collector.Paths(); slices.Contains(paths, "/v1/metrics") || !slices.Contains(paths, "/v1/traces") {
+		t.Errorf("collector paths = %v, want traces pushed and metrics left to Prometheus", paths)
+	}
+
+	if status, _ := scrape(t, sdk.client); status != http.StatusOK {
+		t.Errorf("GET /metrics = %d, want 200", status)
+	}
+}
@@ -0,0 +1,84 @@
+package silgotel
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// InjectHTTPHeaders writes the trace context and baggage held in ctx into h
+// using the globally configured propagator. A nil h is ignored.
+//
+// NewOtelSDK installs that propagator only when the Client registers
+// globals. Under WithoutGlobalRegistration the global one is left alone,
+// a no-op unless set elsewhere, so use Client.Propagator with a
+// propagation.HeaderCarrier or MapCarrier there instead of these helpers.
+func InjectHTTPHeaders(ctx context.Context, h http.Header) {
+	if h == nil {
+		return
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// ExtractHTTPHeaders returns a copy of ctx carrying the trace context and
+// baggage found in h, using the global propagator like InjectHTTPHeaders. A
+// nil h returns ctx unchanged.
+func ExtractHTTPHeaders(ctx context.Context, h http.Header) context.Context {
+	if h == nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+}
+
+// InjectMap returns the trace context and baggage held in ctx as a map,
+// suitable for message attributes on queues such as Pub/Sub. Like
+// InjectHTTPHeaders it uses the global propagator.
+func InjectMap(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	return carrier
+}
+
+// ExtractMap returns a copy of ctx carrying the trace context and baggage
+// found in attrs with the global propagator. A nil attrs returns ctx
+// unchanged.
+func ExtractMap(ctx context.Context, attrs map[string]string) context.Context {
+	if attrs == nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(attrs))
+}
+
+// StartConsumerSpan extracts the producer's trace context from attrs and
+// starts a consumer span that continues the producer's trace and links to
+// the producer span.
+//
+//	ctx, span := silgotel.StartConsumerSpan(ctx, msg.Attributes, "orders receive")
+//	defer span.End()
+//
+//nolint:ireturn
+func StartConsumerSpan(
+	ctx context.Context,
+	attrs map[string]string,
+	name string,
+) (context.Context, otelTrace.Span) {
+	ctx = ExtractMap(ctx, attrs)
+
+	opts := []otelTrace.SpanStartOption{
+		otelTrace.WithSpanKind(otelTrace.SpanKindConsumer),
+		ctxAttributesOption(ctx),
+	}
+
+	if producer := otelTrace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: producer}))
+	}
+
+	return cachedTracer(instrumentationScope).Start(ctx, name, opts...) //nolint:spancheck
+}
@@ -0,0 +1,174 @@
+package silgotel_test
+
+import (
+	"net/http"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// usePropagator installs the W3C trace context and baggage propagator that
+// NewOtelSDK registers, restoring the previous one when the test finishes.
+func usePropagator(t *testing.T) {
+	t.Helper()
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+}
+
+func TestHTTPHeadersRoundTrip(t *testing.T) {
+	siltest.NewTestSDK(t)
+	usePropagator(t)
+
+	member, err := baggage.NewMember("tenant.id", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bag, err := baggage.New(member)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := silgotel.Trace(baggage.ContextWithBaggage(t.Context(), bag), "client", "call")
+	defer span.End()
+
+	header := http.Header{}
+	silgotel.InjectHTTPHeaders(ctx, header)
+
+	if header.Get("traceparent") == "" {
+		t.Fatalf("InjectHTTPHeaders() wrote %v, want a traceparent header", header)
+	}
+
+	extracted := silgotel.ExtractHTTPHeaders(t.Context(), header)
+
+	got := otelTrace.SpanContextFromContext(extracted)
+	if got.TraceID() != span.SpanContext().TraceID() || got.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("extracted span context = %v, want %v", got, span.SpanContext())
+	}
+
+	if !got.IsRemote() {
+		t.Error("extracted span context is not marked remote")
+	}
+
+	if tenant := baggage.FromContext(extracted).Member("tenant.id").Value(); tenant != "acme" {
+		t.Errorf("extracted baggage tenant.id = %q, want %q", tenant, "acme")
+	}
+}
+
+func TestHTTPHeadersNil(t *testing.T) {
+	usePropagator(t)
+
+	ctx := t.Context()
+
+	silgotel.InjectHTTPHeaders(ctx, nil)
+
+	if got := silgotel.ExtractHTTPHeaders(ctx, nil); got != ctx {
+		t.Error("ExtractHTTPHeaders(nil) did not return ctx unchanged")
+	}
+}
+
+func TestMapRoundTrip(t *testing.T) {
+	siltest.NewTestSDK(t)
+	usePropagator(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "producer", "publish")
+	defer span.End()
+
+	attrs := silgotel.InjectMap(ctx)
+	if attrs["traceparent"] == "" {
+		t.Fatalf("InjectMap() = %v, want a traceparent entry", attrs)
+	}
+
+	got := otelTrace.SpanContextFromContext(silgotel.ExtractMap(t.Context(), attrs))
+	if got.TraceID() != span.SpanContext().TraceID() || got.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("extracted span context = %v, want %v", got, span.SpanContext())
+	}
+
+	if ctx := t.Context(); silgotel.ExtractMap(ctx, nil) != ctx {
+		t.Error("ExtractMap(nil) did not return ctx unchanged")
+	}
+}
+
+func TestStartConsumerSpan(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+	usePropagator(t)
+
+	ctx, producer := silgotel.Trace(t.Context(), "producer", "orders publish")
+	attrs := silgotel.InjectMap(ctx)
+	producer.End()
+
+	_, consumer := silgotel.StartConsumerSpan(t.Context(), attrs, "orders receive")
+	consumer.End()
+
+	var got trace.ReadOnlySpan
+
+	for _, span := range sdk.Spans() {
+		if span.Name() == "orders receive" {
+			got = span
+		}
+	}
+
+	if got == nil {
+		t.Fatal("StartConsumerSpan() recorded no span")
+	}
+
+	if got.SpanKind() != otelTrace.SpanKindConsumer {
+		t.Errorf("consumer span kind = %v, want consumer", got.SpanKind())
+	}
+
+	if got.Parent().SpanID() != producer.SpanContext().SpanID() {
+		t.Errorf("consumer parent = %v, want the producer span", got.Parent().SpanID())
+	}
+
+	if links := got.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != producer.SpanContext().SpanID() {
+		t.Errorf("consumer links = %v, want one link to the producer span", links)
+	}
+}
+
+func TestStartConsumerSpanWithoutProducer(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+	usePropagator(t)
+
+	_, span := silgotel.StartConsumerSpan(t.Context(), map[string]string{}, "orders receive")
+	span.End()
+
+	spans := sdk.Spans()
+	if len(spans) != 1 || spans[0].Parent().IsValid() || len(spans[0].Links()) != 0 {
+		t.Errorf("spans = %v, want one root span without links", spans)
+	}
+}
+
+func TestPropagationWithoutGlobalRegistration(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	sdk := startSDK(t, newClient(t))
+
+	ctx, span := sdk.client.Tracer("producer").Start(t.Context(), "publish")
+	defer span.End()
+
+	// The helpers read the global propagator, which the client left alone.
+	if attrs := silgotel.InjectMap(ctx); len(attrs) != 0 {
+		t.Errorf("InjectMap() = %v, want nothing from the untouched global propagator", attrs)
+	}
+
+	carrier := propagation.MapCarrier{}
+	sdk.client.Propagator().Inject(ctx, carrier)
+
+	got := otelTrace.SpanContextFromContext(sdk.client.Propagator().Extract(t.Context(), carrier))
+	if got.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("Propagator() round trip = %v, want %v", got, span.SpanContext())
+	}
+}
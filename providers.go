@@ -0,0 +1,118 @@
+package silgotel
+
+import (
+	otelLog "go.opentelemetry.io/otel/log"
+	logNoop "go.opentelemetry.io/otel/log/noop"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	metricNoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+	traceNoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerProvider returns the tracer provider built by NewOtelSDK, or a no-op
+// provider when tracing has not been set up.
+//
+//nolint:ireturn
+func (c *Client) TracerProvider() otelTrace.TracerProvider {
+	if c.tracerProvider == nil {
+		return traceNoop.NewTracerProvider()
+	}
+
+	return c.tracerProvider
+}
+
+// MeterProvider returns the meter provider built by NewOtelSDK, or a no-op
+// provider when metrics have not been set up.
+//
+//nolint:ireturn
+func (c *Client) MeterProvider() otelMetric.MeterProvider {
+	if c.meterProvider == nil {
+		return metricNoop.NewMeterProvider()
+	}
+
+	return c.meterProvider
+}
+
+// LoggerProvider returns the logger provider built by NewOtelSDK, or a no-op
+// provider when logging has not been set up.
+//
+//nolint:ireturn
+func (c *Client) LoggerProvider() otelLog.LoggerProvider {
+	if c.loggerProvider == nil {
+		return logNoop.NewLoggerProvider()
+	}
+
+	return c.loggerProvider
+}
+
+// Propagator returns the W3C trace context and baggage propagator NewOtelSDK
+// installs globally, for instrumentation that needs it under
+// WithoutGlobalRegistration.
+//
+//nolint:ireturn
+func (c *Client) Propagator() propagation.TextMapPropagator {
+	return newPropagator()
+}
+
+// Tracer returns a tracer from the client's own tracer provider. The scope
+// version defaults to the client's Version and the schema URL to the semantic
+// conventions the package follows; opts override both.
+//
+//nolint:ireturn
+func (c *Client) Tracer(scope string, opts ...otelTrace.TracerOption) otelTrace.Tracer {
+	opts = append([]otelTrace.TracerOption{
+		otelTrace.WithInstrumentationVersion(c.Version),
+		otelTrace.WithSchemaURL(semconv.SchemaURL),
+	}, opts...)
+
+	return c.TracerProvider().Tracer(scope, opts...)
+}
+
+// Meter returns a meter from the client's own meter provider, with the same
+// scope defaults as Tracer.
+//
+//nolint:ireturn
+func (c *Client) Meter(scope string, opts ...otelMetric.MeterOption) otelMetric.Meter {
+	opts = append([]otelMetric.MeterOption{
+		otelMetric.WithInstrumentationVersion(c.Version),
+		otelMetric.WithSchemaURL(semconv.SchemaURL),
+	}, opts...)
+
+	if c.meterProvider == nil {
+		return c.MeterProvider().Meter(scope, opts...)
+	}
+
+	meter := c.meterProvider.Meter(scope, opts...)
+	meterOwners.LoadOrStore(meter, c.meterProvider)
+
+	return meter
+}
+
+// Logger returns a logger from the client's own logger provider, with the
+// same scope defaults as Tracer.
+//
+//nolint:ireturn
+func (c *Client) Logger(scope string, opts ...otelLog.LoggerOption) otelLog.Logger {
+	opts = append([]otelLog.LoggerOption{
+		otelLog.WithInstrumentationVersion(c.Version),
+		otelLog.WithSchemaURL(semconv.SchemaURL),
+	}, opts...)
+
+	return c.LoggerProvider().Logger(scope, opts...)
+}
+
+// SchemaURL returns the schema URL of the resource built by NewOtelSDK, or
+// an empty string before setup. When detected resources carry a conflicting
+// schema URL their attributes are kept without it, and the service
+// attributes merged last still set the package's semconv schema URL.
+func (c *Client) SchemaURL() string {
+	return c.schemaURL
+}
+
+// registerGlobals reports whether setup should install the providers and
+// propagator as the OTel globals.
+func (c *Client) registerGlobals() bool {
+	return !c.options.skipGlobals
+}
@@ -0,0 +1,231 @@
+package silgotel_test
+
+import (
+	"slices"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestProvidersBeforeSetup(t *testing.T) {
+	client := newClient(t)
+
+	_, span := client.TracerProvider().Tracer("providers").Start(t.Context(), "noop")
+	defer span.End()
+
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Error("TracerProvider() before NewOtelSDK returned a recording provider")
+	}
+
+	if client.MeterProvider() == nil || client.LoggerProvider() == nil {
+		t.Error("MeterProvider() or LoggerProvider() before NewOtelSDK returned nil")
+	}
+}
+
+func TestProvidersAfterSetup(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	if _, ok := sdk.client.TracerProvider().(*trace.TracerProvider); !ok {
+		t.Errorf("TracerProvider() = %T, want the SDK provider", sdk.client.TracerProvider())
+	}
+
+	if _, ok := sdk.client.MeterProvider().(*sdkmetric.MeterProvider); !ok {
+		t.Errorf("MeterProvider() = %T, want the SDK provider", sdk.client.MeterProvider())
+	}
+
+	if _, ok := sdk.client.LoggerProvider().(*sdklog.LoggerProvider); !ok {
+		t.Errorf("LoggerProvider() = %T, want the SDK provider", sdk.client.LoggerProvider())
+	}
+
+	_, span := sdk.client.TracerProvider().Tracer("providers").Start(t.Context(), "exported")
+	span.End()
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].Name != "exported" {
+		t.Errorf("exported spans = %v, want the span started on TracerProvider()", spans)
+	}
+}
+
+func TestPropagatorFields(t *testing.T) {
+	fields := (&silgotel.Client{}).Propagator().Fields()
+
+	for _, want := range []string{"traceparent", "tracestate", "baggage"} {
+		if !slices.Contains(fields, want) {
+			t.Errorf("Propagator().Fields() = %v, want %s", fields, want)
+		}
+	}
+}
+
+func TestWithoutGlobalRegistration(t *testing.T) {
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	loggerProvider := global.GetLoggerProvider()
+	propagator := otel.GetTextMapPropagator()
+
+	first := startSDK(t, newClient(t))
+	second := startSDK(t, newClient(t))
+
+	if otel.GetTracerProvider() != tracerProvider || otel.GetMeterProvider() != meterProvider ||
+		global.GetLoggerProvider() != loggerProvider || otel.GetTextMapPropagator() != propagator {
+		t.Error("NewOtelSDK() replaced a global under WithoutGlobalRegistration")
+	}
+
+	_, span := first.client.Tracer("globals").Start(t.Context(), "first only")
+	span.End()
+
+	if spans := first.Spans(t); len(spans) != 1 {
+		t.Errorf("first client exported %d spans, want 1", len(spans))
+	}
+
+	if spans := second.Spans(t); len(spans) != 0 {
+		t.Errorf("second client exported %d spans of the first, want none", len(spans))
+	}
+}
+
+func TestGlobalRegistration(t *testing.T) {
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	loggerProvider := global.GetLoggerProvider()
+	propagator := otel.GetTextMapPropagator()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+		global.SetLoggerProvider(loggerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	client := newClient(t)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	defer func() { _ = shutdown(t.Context()) }()
+
+	if otel.GetTracerProvider() != client.TracerProvider() {
+		t.Error("NewOtelSDK() did not install the client's tracer provider globally")
+	}
+
+	if global.GetLoggerProvider() != client.LoggerProvider() {
+		t.Error("NewOtelSDK() did not install the client's logger provider globally")
+	}
+}
+
+func TestClientScopeDefaults(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	_, span := sdk.client.Tracer("orders").Start(t.Context(), "place")
+	span.End()
+
+	counter, err := sdk.client.Meter("orders").Int64Counter("orders.placed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	var record otelLog.Record
+	record.SetBody(otelLog.StringValue("placed"))
+	sdk.client.Logger("orders").Emit(t.Context(), record)
+
+	want := instrumentation.Scope{Name: "orders", Version: "1.0.0", SchemaURL: semconv.SchemaURL}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].InstrumentationScope != want {
+		t.Errorf("span scopes = %v, want %v", spans, want)
+	}
+
+	var metricScopes []instrumentation.Scope
+	for _, sm := range sdk.Metrics(t).ScopeMetrics {
+		if sm.Scope.Name == "orders" {
+			metricScopes = append(metricScopes, sm.Scope)
+		}
+	}
+
+	if len(metricScopes) != 1 || metricScopes[0] != want {
+		t.Errorf("metric scopes = %v, want %v", metricScopes, want)
+	}
+
+	if logs := sdk.Logs(t); len(logs) != 1 || logs[0].InstrumentationScope() != want {
+		t.Errorf("log scopes = %v, want %v", logs, want)
+	}
+}
+
+func TestClientScopeOptionsOverride(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	_, span := sdk.client.Tracer("orders",
+		otelTrace.WithInstrumentationVersion("2.3.4"),
+		otelTrace.WithSchemaURL("https://example.com/schemas/1.0.0"),
+	).Start(t.Context(), "place")
+	span.End()
+
+	want := instrumentation.Scope{Name: "orders", Version: "2.3.4", SchemaURL: "https://example.com/schemas/1.0.0"}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].InstrumentationScope != want {
+		t.Errorf("span scopes = %v, want %v", spans, want)
+	}
+}
+
+func TestTraceHelperSchemaURL(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	_, span := silgotel.Trace(t.Context(), "orders", "place")
+	span.End()
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	if scope := spans[0].InstrumentationScope(); scope.Name != "orders" || scope.SchemaURL != semconv.SchemaURL {
+		t.Errorf("Trace() scope = %v, want orders with schema URL %s", scope, semconv.SchemaURL)
+	}
+}
+
+func TestPackageScopeDefaults(t *testing.T) {
+	sdk := startGlobalSDK(t, newClient(t))
+
+	_, span := silgotel.Trace(t.Context(), "orders", "place")
+	span.End()
+
+	counter, err := silgotel.Meter("orders").Int64Counter("orders.placed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	silgotel.NewLogger("orders").InfoContext(t.Context(), "placed")
+
+	want := instrumentation.Scope{Name: "orders", Version: "1.0.0", SchemaURL: semconv.SchemaURL}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].InstrumentationScope != want {
+		t.Errorf("span scopes = %v, want %v", spans, want)
+	}
+
+	var metricScopes []instrumentation.Scope
+	for _, sm := range sdk.Metrics(t).ScopeMetrics {
+		if sm.Scope.Name == "orders" {
+			metricScopes = append(metricScopes, sm.Scope)
+		}
+	}
+
+	if len(metricScopes) != 1 || metricScopes[0] != want {
+		t.Errorf("metric scopes = %v, want %v", metricScopes, want)
+	}
+
+	if logs := sdk.Logs(t); len(logs) != 1 || logs[0].InstrumentationScope() != want {
+		t.Errorf("log scopes = %v, want %v", logs, want)
+	}
+}
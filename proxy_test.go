@@ -0,0 +1,239 @@
+package silgotel_test
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+// recordingProxy is a forward proxy that answers every absolute-form
+// request itself, as a collector would, tunnels CONNECT requests to their
+// target, and remembers what it was asked for.
+type recordingProxy struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	targets []string
+	authz   []string
+}
+
+func newRecordingProxy(t *testing.T) *recordingProxy {
+	t.Helper()
+
+	p := &recordingProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		target := r.URL.String()
+		if r.Method == http.MethodConnect {
+			target = "connect " + r.Host
+		}
+
+		p.mu.Lock()
+		p.targets = append(p.targets, target)
+		p.authz = append(p.authz, r.Header.Get("Proxy-Authorization"))
+		p.mu.Unlock()
+
+		if r.Method == http.MethodConnect {
+			tunnel(t, w, r.Host)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+// tunnel answers a CONNECT request for host and relays bytes both ways.
+func tunnel(t *testing.T, w http.ResponseWriter, host string) {
+	upstream, err := net.Dial("tcp", host)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+
+		return
+	}
+
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		_ = upstream.Close()
+
+		t.Errorf("hijacking CONNECT: %v", err)
+
+		return
+	}
+
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+
+	// Closing both ends as soon as either side finishes unblocks the other
+	// copy, so the proxy never outlives the client or the collector.
+	var once sync.Once
+
+	closeBoth := func() {
+		_ = conn.Close()
+		_ = upstream.Close()
+	}
+
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		once.Do(closeBoth)
+	}()
+
+	_, _ = io.Copy(conn, upstream)
+	once.Do(closeBoth)
+}
+
+// Targets returns the distinct absolute URLs requested through the proxy,
+// sorted.
+func (p *recordingProxy) Targets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	targets := slices.Clone(p.targets)
+	slices.Sort(targets)
+
+	return slices.Compact(targets)
+}
+
+// Authorizations returns the Proxy-Authorization headers received so far.
+func (p *recordingProxy) Authorizations() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.authz)
+}
+
+// proxiedClient returns a client whose collector can only be reached through
+// a proxy.
+func proxiedClient(t *testing.T) *silgotel.Client {
+	t.Helper()
+
+	client := newClient(t)
+	client.OTLPBaseURL = "http://collector.invalid:4318"
+
+	return client
+}
+
+func TestProxyURL(t *testing.T) {
+	proxy := newRecordingProxy(t)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyURL.User = url.UserPassword("otel", "s3cret")
+
+	client := proxiedClient(t)
+	client.ProxyURL = proxyURL.String()
+
+	emitAll(t, startSDK(t, client))
+
+	want := []string{
+		"http://collector.invalid:4318/v1/logs",
+		"http://collector.invalid:4318/v1/metrics",
+		"http://collector.invalid:4318/v1/traces",
+	}
+	if got := proxy.Targets(); !slices.Equal(got, want) {
+		t.Errorf("proxy forwarded %v, want %v", got, want)
+	}
+
+	credentials := "Basic " + base64.StdEncoding.EncodeToString([]byte("otel:s3cret"))
+	for _, authz := range proxy.Authorizations() {
+		if authz != credentials {
+			t.Errorf("Proxy-Authorization = %q, want the credentials from ProxyURL", authz)
+		}
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy := newRecordingProxy(t)
+
+	var mu sync.Mutex
+
+	asked := 0
+
+	client := proxiedClient(t)
+	client.ProxyURL = "http://ignored.invalid:3128"
+
+	emitAll(t, startSDK(t, client, silgotel.WithProxy(func(*http.Request) (*url.URL, error) {
+		mu.Lock()
+		asked++
+		mu.Unlock()
+
+		return url.Parse(proxy.URL)
+	})))
+
+	if got := proxy.Targets(); len(got) != 3 {
+		t.Errorf("proxy forwarded %v, want every signal", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if asked == 0 {
+		t.Error("WithProxy's function was never asked, want it to win over ProxyURL")
+	}
+}
+
+func TestProxyURLInvalid(t *testing.T) {
+	for _, proxyURL := range []string{"://proxy", "socks5://proxy:1080", "proxy:3128"} {
+		client := newClient(t)
+		client.ProxyURL = proxyURL
+
+		if err := client.Validate(); err == nil || !strings.Contains(err.Error(), "proxyURL") {
+			t.Errorf("Validate() with %q = %v, want a proxyURL error", proxyURL, err)
+		}
+	}
+}
+
+func TestProxyURLTunnelsTLS(t *testing.T) {
+	proxy := newRecordingProxy(t)
+
+	var exports atomic.Int32
+
+	collector := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		exports.Add(1)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: collector.Certificate().Raw}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.CAFile = caFile
+	client.ProxyURL = proxy.URL
+
+	emitAll(t, startSDK(t, client))
+
+	if got, want := proxy.Targets(), []string{"connect " + collector.Listener.Addr().String()}; !slices.Equal(got, want) {
+		t.Errorf("proxy received %v, want only CONNECT tunnels to the collector", got)
+	}
+
+	if got := exports.Load(); got != 3 {
+		t.Errorf("collector received %d exports through the tunnel, want 3", got)
+	}
+}
@@ -0,0 +1,61 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// RecoverPanic recovers a panic, records it on the span held in ctx as an
+// exception event and logs it on the global logger provider, correlated with
+// that span. It must be deferred directly:
+//
+//	defer silgotel.RecoverPanic(ctx)
+func RecoverPanic(ctx context.Context) {
+	if v := recover(); v != nil {
+		recordPanic(ctx, v)
+	}
+}
+
+// RecoverAndRepanic behaves like RecoverPanic but panics again with the
+// original value once the panic has been recorded.
+//
+//	defer silgotel.RecoverAndRepanic(ctx)
+func RecoverAndRepanic(ctx context.Context) {
+	if v := recover(); v != nil {
+		recordPanic(ctx, v)
+		panic(v)
+	}
+}
+
+// GoWithRecovery runs fn in a new goroutine, recording any panic on the span
+// held in ctx instead of crashing the process.
+func GoWithRecovery(ctx context.Context, fn func(ctx context.Context)) {
+	go func() {
+		defer RecoverPanic(ctx)
+
+		fn(ctx)
+	}()
+}
+
+func recordPanic(ctx context.Context, v any) {
+	message := fmt.Sprint(v)
+	stack := string(debug.Stack())
+
+	span := otelTrace.SpanFromContext(ctx)
+	span.AddEvent(semconv.ExceptionEventName, otelTrace.WithAttributes(
+		semconv.ExceptionType(fmt.Sprintf("%T", v)),
+		semconv.ExceptionMessage(message),
+		semconv.ExceptionStacktrace(stack),
+	))
+	span.SetStatus(codes.Error, "panic: "+message)
+
+	cachedLogger(instrumentationScope).ErrorContext(ctx, "recovered panic",
+		"panic", message,
+		"stack", stack,
+	)
+}
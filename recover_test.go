@@ -0,0 +1,116 @@
+package silgotel_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// silenceDefaultLogger discards what the code under test logs through
+// slog.Default.
+func silenceDefaultLogger(t *testing.T) {
+	t.Helper()
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+}
+
+func assertPanicRecorded(t *testing.T, span trace.ReadOnlySpan, message string) {
+	t.Helper()
+
+	if span.Status().Code != codes.Error || span.Status().Description != "panic: "+message {
+		t.Errorf("span status = %+v, want an error for the panic", span.Status())
+	}
+
+	// The SDK adds its own exception event when a span ends while
+	// panicking; the first is the one recorded by silgotel.
+	events := span.Events()
+	if len(events) == 0 || events[0].Name != semconv.ExceptionEventName {
+		t.Fatalf("span events = %v, want an exception event", events)
+	}
+
+	attrs := attribute.NewSet(events[0].Attributes...)
+	if value, _ := attrs.Value(semconv.ExceptionMessageKey); value.AsString() != message {
+		t.Errorf("exception.message = %q, want %q", value.AsString(), message)
+	}
+
+	if value, _ := attrs.Value(semconv.ExceptionStacktraceKey); value.AsString() == "" {
+		t.Error("exception event has no stack trace")
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	func() {
+		ctx, span := silgotel.Trace(t.Context(), "worker", "process")
+		defer span.End()
+		defer silgotel.RecoverPanic(ctx)
+
+		panic("boom")
+	}()
+
+	span := sdk.Spans()[0]
+	assertPanicRecorded(t, span, "boom")
+
+	records := sdk.Logs()
+	if len(records) != 1 || records[0].Body().AsString() != "recovered panic" {
+		t.Fatalf("logged %d records, want the recovered panic", len(records))
+	}
+
+	if records[0].TraceID() != span.SpanContext().TraceID() || records[0].SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("panic record trace, span = %s, %s, want the panicking span's",
+			records[0].TraceID(), records[0].SpanID())
+	}
+}
+
+func TestRecoverAndRepanic(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	var recovered any
+
+	func() {
+		defer func() { recovered = recover() }()
+
+		ctx, span := silgotel.Trace(t.Context(), "worker", "process")
+		defer span.End()
+		defer silgotel.RecoverAndRepanic(ctx)
+
+		panic("boom")
+	}()
+
+	if recovered != "boom" {
+		t.Errorf("recovered %v, want the original panic value", recovered)
+	}
+
+	assertPanicRecorded(t, sdk.Spans()[0], "boom")
+}
+
+func TestGoWithRecovery(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "worker", "process")
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	silgotel.GoWithRecovery(ctx, func(context.Context) {
+		defer wg.Done()
+
+		panic("boom")
+	})
+	wg.Wait()
+	span.End()
+
+	assertPanicRecorded(t, sdk.Spans()[0], "boom")
+}
@@ -0,0 +1,142 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// RedactedPlaceholder replaces any value scrubbed by the log redaction
+// processor.
+const RedactedPlaceholder = "[REDACTED]"
+
+// redactionRule replaces the matches of pattern with replacement, which may
+// refer to the pattern's groups like regexp.Regexp.ReplaceAllString.
+type redactionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// builtinRedactionRules match emails, Kenyan MSISDNs and national ID numbers.
+// A bare 7 or 8 digit number is too often an order ID, amount or timestamp,
+// so national IDs are only matched after a label such as "national ID",
+// "ID no" or "NID", which is kept.
+//
+//nolint:gochecknoglobals
+var builtinRedactionRules = []redactionRule{
+	{
+		pattern:     regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+		replacement: RedactedPlaceholder,
+	},
+	{
+		pattern:     regexp.MustCompile(`(?:\+?254|\b0)[17]\d{8}\b`),
+		replacement: RedactedPlaceholder,
+	},
+	{
+		pattern: regexp.MustCompile(
+			`(?i)(\b(?:national[ _.\-]?id(?:[ _.\-]?(?:no|number))?|id[ _.\-]?(?:no|number)|nid)\b\.?\s*[:=#]?\s*)\d{7,8}\b`,
+		),
+		replacement: "${1}" + RedactedPlaceholder,
+	},
+}
+
+// builtinRedactionDenyKeys are attribute keys whose values are always
+// national ID numbers.
+//
+//nolint:gochecknoglobals
+var builtinRedactionDenyKeys = []string{"national_id", "national_id_number", "id_number"}
+
+// redactProcessor scrubs PII from log bodies and string attributes before the
+// record reaches the wrapped processor.
+type redactProcessor struct {
+	log.Processor
+
+	rules    []redactionRule
+	denyKeys map[string]struct{}
+}
+
+func newRedactProcessor(next log.Processor, patterns, denyKeys []string) (*redactProcessor, error) {
+	p := &redactProcessor{
+		Processor: next,
+		denyKeys:  make(map[string]struct{}, len(denyKeys)),
+	}
+
+	p.rules = slices.Clone(builtinRedactionRules)
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compiling redaction pattern %q: %w", pattern, err)
+		}
+
+		p.rules = append(p.rules, redactionRule{pattern: re, replacement: RedactedPlaceholder})
+	}
+
+	for _, key := range slices.Concat(builtinRedactionDenyKeys, denyKeys) {
+		p.denyKeys[strings.ToLower(key)] = struct{}{}
+	}
+
+	return p, nil
+}
+
+func (p *redactProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	record.SetBody(p.redactValue(record.Body()))
+
+	attrs := make([]otelLog.KeyValue, 0, record.AttributesLen())
+	record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		attrs = append(attrs, p.redactKeyValue(kv))
+
+		return true
+	})
+	record.SetAttributes(attrs...)
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+func (p *redactProcessor) redactKeyValue(kv otelLog.KeyValue) otelLog.KeyValue {
+	if _, denied := p.denyKeys[strings.ToLower(kv.Key)]; denied {
+		return otelLog.String(kv.Key, RedactedPlaceholder)
+	}
+
+	return otelLog.KeyValue{Key: kv.Key, Value: p.redactValue(kv.Value)}
+}
+
+func (p *redactProcessor) redactValue(v otelLog.Value) otelLog.Value {
+	switch v.Kind() { //nolint:exhaustive
+	case otelLog.KindString:
+		return otelLog.StringValue(p.redactString(v.AsString()))
+	case otelLog.KindSlice:
+		values := v.AsSlice()
+		redacted := make([]otelLog.Value, len(values))
+
+		for i, item := range values {
+			redacted[i] = p.redactValue(item)
+		}
+
+		return otelLog.SliceValue(redacted...)
+	case otelLog.KindMap:
+		kvs := v.AsMap()
+		redacted := make([]otelLog.KeyValue, len(kvs))
+
+		for i, kv := range kvs {
+			redacted[i] = p.redactKeyValue(kv)
+		}
+
+		return otelLog.MapValue(redacted...)
+	default:
+		return v
+	}
+}
+
+func (p *redactProcessor) redactString(s string) string {
+	for _, rule := range p.rules {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+
+	return s
+}
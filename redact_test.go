@@ -0,0 +1,87 @@
+package silgotel_test
+
+import (
+	"log/slog"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestLogRedactionBody(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithLogRedaction([]string{`ACC-\d+`}, nil))
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"contact jane.doe@example.com", "contact [REDACTED]"},
+		{"call +254712345678 or 0712345678", "call [REDACTED] or [REDACTED]"},
+		{"national ID: 12345678", "national ID: [REDACTED]"},
+		{"ID no 1234567 verified", "ID no [REDACTED] verified"},
+		{"NID#23456789", "NID#[REDACTED]"},
+		{"order 12345678 paid", "order 12345678 paid"},
+		{"took 1234567 ns at 20261014", "took 1234567 ns at 20261014"},
+		{"account ACC-991", "account [REDACTED]"},
+	}
+
+	logger := slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler))
+	for _, tt := range tests {
+		logger.InfoContext(t.Context(), tt.body)
+	}
+
+	logs := sdk.Logs(t)
+	if len(logs) != len(tests) {
+		t.Fatalf("exported %d records, want %d", len(logs), len(tests))
+	}
+
+	for i, tt := range tests {
+		if got := logs[i].Body().AsString(); got != tt.want {
+			t.Errorf("body %q redacted to %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestLogRedactionAttributes(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithLogRedaction(nil, []string{"Password"}))
+
+	slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "signup",
+		"email", "jane@example.com",
+		"national_id", "12345678",
+		"order_id", "12345678",
+		"password", "hunter2",
+		slog.Group("contact", "phone", "0712345678"),
+		"attempts", 3,
+	)
+
+	record := sdk.Logs(t)[0]
+
+	for key, want := range map[string]string{
+		"email":       silgotel.RedactedPlaceholder,
+		"national_id": silgotel.RedactedPlaceholder,
+		"order_id":    "12345678",
+		"password":    silgotel.RedactedPlaceholder,
+	} {
+		if value, _ := logAttr(record, key); value.AsString() != want {
+			t.Errorf("%s = %q, want %q", key, value.AsString(), want)
+		}
+	}
+
+	contact, _ := logAttr(record, "contact")
+	if kvs := contact.AsMap(); len(kvs) != 1 || kvs[0].Value.AsString() != silgotel.RedactedPlaceholder {
+		t.Errorf("contact = %v, want the nested phone redacted", contact)
+	}
+
+	if attempts, _ := logAttr(record, "attempts"); attempts.AsInt64() != 3 {
+		t.Errorf("attempts = %v, want non-string attributes untouched", attempts)
+	}
+}
+
+func TestLogRedactionInvalidPattern(t *testing.T) {
+	_, err := silgotel.NewOtelSDK(t.Context(), newClient(t),
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithLogRedaction([]string{"("}, nil),
+	)
+	if err == nil {
+		t.Error("NewOtelSDK() = nil, want an error for the invalid pattern")
+	}
+}
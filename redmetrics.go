@@ -0,0 +1,101 @@
+package silgotel
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// unmatchedRoute labels requests that no route pattern matched, keeping raw
+// paths out of metric attributes.
+const unmatchedRoute = "unmatched"
+
+type redMetrics struct {
+	duration otelMetric.Float64Histogram
+	requests otelMetric.Int64Counter
+	inFlight otelMetric.Int64UpDownCounter
+}
+
+func newREDMetrics(meter otelMetric.Meter) *redMetrics {
+	return &redMetrics{
+		duration: mustInstrument(meter.Float64Histogram(
+			"http.server.request.duration",
+			otelMetric.WithDescription("Duration of HTTP server requests"),
+			otelMetric.WithUnit("s"),
+		)),
+		requests: mustInstrument(meter.Int64Counter(
+			"http.server.request.count",
+			otelMetric.WithDescription("Number of HTTP server requests by status class"),
+			otelMetric.WithUnit("1"),
+		)),
+		inFlight: mustInstrument(meter.Int64UpDownCounter(
+			"http.server.active_requests",
+			otelMetric.WithDescription("Number of HTTP server requests in flight"),
+			otelMetric.WithUnit("1"),
+		)),
+	}
+}
+
+// MetricsMiddleware records rate, error and duration metrics per route on the
+// client's meter provider. Routes are labelled with the matched pattern only,
+// so it composes with any tracing middleware without raising cardinality. A
+// panicking handler is recorded as a 500 before the panic is propagated. The
+// span in the request context, if any, gets its status from the response
+// code via SetSpanStatusFromHTTPStatus. Requests ignored through
+// WithIgnoredPaths are not recorded.
+func (c *Client) MetricsMiddleware(next http.Handler) http.Handler {
+	m := newREDMetrics(c.Meter(instrumentationScope))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.IgnoresRequest(r) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		ctx := r.Context()
+		method := attribute.String("http.request.method", r.Method)
+
+		m.inFlight.Add(ctx, 1, otelMetric.WithAttributes(method))
+
+		rw := newResponseWriter(w)
+		start := time.Now()
+
+		defer func() {
+			status := rw.status
+
+			v := recover()
+			if v != nil {
+				status = http.StatusInternalServerError
+			}
+
+			route := r.Pattern
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			attrs := otelMetric.WithAttributes(
+				method,
+				attribute.String("http.route", route),
+				attribute.Int("http.response.status_code", status),
+				attribute.String("http.response.status_class", strconv.Itoa(status/100)+"xx"),
+			)
+
+			SetSpanStatusFromHTTPStatus(otelTrace.SpanFromContext(ctx), otelTrace.SpanKindServer, status)
+
+			m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+			m.requests.Add(ctx, 1, attrs)
+			m.inFlight.Add(ctx, -1, otelMetric.WithAttributes(method))
+
+			if v != nil {
+				panic(v)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
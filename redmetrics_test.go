@@ -0,0 +1,133 @@
+package silgotel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orders/{id}", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("GET /boom", func(http.ResponseWriter, *http.Request) { panic("boom") })
+
+	handler := sdk.client.MetricsMiddleware(mux)
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/missing", "/boom"} {
+		func() {
+			defer func() { _ = recover() }()
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}()
+	}
+
+	rm := sdk.Metrics(t)
+
+	requests, ok := findMetric(rm, "http.server.request.count")
+	if !ok {
+		t.Fatal("http.server.request.count was not recorded")
+	}
+
+	counts := make(map[string]int64)
+
+	for _, point := range requests.Data.(metricdata.Sum[int64]).DataPoints { //nolint:forcetypeassert
+		route, _ := point.Attributes.Value("http.route")
+		class, _ := point.Attributes.Value("http.response.status_class")
+		counts[route.AsString()+" "+class.AsString()] += point.Value
+	}
+
+	for key, want := range map[string]int64{
+		"GET /orders/{id} 2xx": 2,
+		"unmatched 4xx":        1,
+		"GET /boom 5xx":        1,
+	} {
+		if counts[key] != want {
+			t.Errorf("requests for %s = %d, want %d (all: %v)", key, counts[key], want, counts)
+		}
+	}
+
+	duration, ok := findMetric(rm, "http.server.request.duration")
+	if !ok {
+		t.Fatal("http.server.request.duration was not recorded")
+	}
+
+	var observed uint64
+	for _, point := range duration.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+		observed += point.Count
+	}
+
+	if observed != 4 {
+		t.Errorf("http.server.request.duration observed %d requests, want 4", observed)
+	}
+
+	inFlight, ok := findMetric(rm, "http.server.active_requests")
+	if !ok {
+		t.Fatal("http.server.active_requests was not recorded")
+	}
+
+	for _, point := range inFlight.Data.(metricdata.Sum[int64]).DataPoints { //nolint:forcetypeassert
+		if point.Value != 0 {
+			t.Errorf("http.server.active_requests = %d after all requests finished, want 0", point.Value)
+		}
+	}
+}
+
+func TestMetricsMiddlewareRepanics(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	handler := sdk.client.MetricsMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MetricsMiddleware() swallowed the handler's panic")
+		}
+
+		m, _ := findMetric(sdk.Metrics(t), "http.server.request.count")
+
+		points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+		if len(points) != 1 {
+			t.Fatalf("http.server.request.count points = %v, want one", points)
+		}
+
+		if status, _ := points[0].Attributes.Value("http.response.status_code"); status.AsInt64() != http.StatusInternalServerError {
+			t.Errorf("panicking request recorded with status %d, want 500", status.AsInt64())
+		}
+	}()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestMetricsMiddlewareSetsSpanStatus(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	handler := sdk.client.MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/boom" {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	for _, path := range []string{"/missing", "/boom"} {
+		ctx, span := sdk.client.Tracer("red").Start(t.Context(), path)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil))
+		span.End()
+	}
+
+	want := map[string]codes.Code{"/missing": codes.Unset, "/boom": codes.Error}
+
+	for _, span := range sdk.Spans(t) {
+		if span.Status.Code != want[span.Name] {
+			t.Errorf("%s status = %v, want %v", span.Name, span.Status.Code, want[span.Name])
+		}
+	}
+}
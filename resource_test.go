@@ -0,0 +1,128 @@
+package silgotel_test
+
+import (
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// signalResources emits one span, metric and log through sdk and returns the
+// resource each of them was exported with.
+func signalResources(t *testing.T, sdk *testSDK) (traces, metrics, logs *resource.Resource) {
+	t.Helper()
+
+	emitAll(t, sdk)
+
+	spans := sdk.Spans(t)
+	records := sdk.Logs(t)
+
+	if len(spans) == 0 || len(records) == 0 {
+		t.Fatalf("exported %d spans and %d logs, want at least one of each", len(spans), len(records))
+	}
+
+	return spans[0].Resource, sdk.Metrics(t).Resource, records[0].Resource()
+}
+
+func TestResourceSharedBySignals(t *testing.T) {
+	traces, metrics, logs := signalResources(t, startSDK(t, newClient(t)))
+
+	if !traces.Equal(metrics) || !traces.Equal(logs) {
+		t.Errorf("resources differ:\ntraces  %v\nmetrics %v\nlogs    %v",
+			traces.Attributes(), metrics.Attributes(), logs.Attributes())
+	}
+
+	if traces.SchemaURL() != semconv.SchemaURL {
+		t.Errorf("schema URL = %q, want %q", traces.SchemaURL(), semconv.SchemaURL)
+	}
+
+	set := traces.Set()
+	for key, want := range map[attribute.Key]string{
+		semconv.ServiceNameKey:               "test-service",
+		semconv.ServiceVersionKey:            "1.0.0",
+		semconv.DeploymentEnvironmentNameKey: "test",
+	} {
+		if got, _ := set.Value(key); got.AsString() != want {
+			t.Errorf("%s = %q, want %q", key, got.AsString(), want)
+		}
+	}
+}
+
+func TestResourcePrecedence(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments,service.name=from-env")
+
+	sdk := startSDK(t, newClient(t), silgotel.WithResourceAttributes(
+		attribute.String("team", "from-option"),
+		attribute.String("region", "eu"),
+	))
+
+	traces, _, _ := signalResources(t, sdk)
+	attrs := traces.Set()
+
+	for key, want := range map[attribute.Key]string{
+		"region":               "eu",
+		"team":                 "payments",
+		semconv.ServiceNameKey: "test-service",
+	} {
+		if got, _ := attrs.Value(key); got.AsString() != want {
+			t.Errorf("%s = %q, want %q", key, got.AsString(), want)
+		}
+	}
+}
+
+// instanceID returns the service.instance.id the SDK started for client
+// exports.
+func instanceID(t *testing.T, client *silgotel.Client) string {
+	t.Helper()
+
+	sdk := startSDK(t, client)
+
+	_, span := sdk.client.Tracer("resource").Start(t.Context(), "op")
+	span.End()
+
+	value, _ := sdk.Spans(t)[0].Resource.Set().Value(semconv.ServiceInstanceIDKey)
+
+	return value.AsString()
+}
+
+func TestServiceInstanceID(t *testing.T) {
+	t.Run("client field", func(t *testing.T) {
+		t.Setenv("HOSTNAME", "pod-1")
+
+		client := newClient(t)
+		client.InstanceID = "replica-7"
+
+		if got := instanceID(t, client); got != "replica-7" {
+			t.Errorf("service.instance.id = %q, want the InstanceID field", got)
+		}
+	})
+
+	t.Run("hostname", func(t *testing.T) {
+		t.Setenv("HOSTNAME", "pod-1")
+
+		if got := instanceID(t, newClient(t)); got != "pod-1" {
+			t.Errorf("service.instance.id = %q, want HOSTNAME", got)
+		}
+	})
+
+	t.Run("random and stable", func(t *testing.T) {
+		t.Setenv("HOSTNAME", "")
+
+		client := newClient(t)
+
+		first := instanceID(t, client)
+		if len(first) != 36 {
+			t.Errorf("service.instance.id = %q, want a UUID", first)
+		}
+
+		if second := instanceID(t, client); second != first {
+			t.Errorf("service.instance.id changed from %q to %q across setups of one client", first, second)
+		}
+
+		if other := instanceID(t, newClient(t)); other == first {
+			t.Errorf("two clients share the random service.instance.id %q", first)
+		}
+	})
+}
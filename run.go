@@ -0,0 +1,59 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// RunWithSignals initializes the SDK, invokes run with a context that is
+// cancelled on SIGINT or SIGTERM, then flushes and shuts the SDK down within
+// the client's ShutdownTimeout. Errors from run and from shutdown are joined.
+// Options are forwarded to NewOtelSDK; an *InitError from WithBestEffortInit
+// is reported through the OTel error handler instead of aborting.
+//
+//	err := silgotel.RunWithSignals(ctx, otelClient, func(ctx context.Context) error {
+//		return server.Run(ctx)
+//	})
+func RunWithSignals(
+	ctx context.Context,
+	client *Client,
+	run func(ctx context.Context) error,
+	opts ...Option,
+) error {
+	shutdown, err := NewOtelSDK(ctx, client, opts...)
+
+	var initErr *InitError
+	if errors.As(err, &initErr) {
+		otel.Handle(err)
+	} else if err != nil {
+		return err
+	}
+
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runErr := run(runCtx)
+
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), client.shutdownTimeout())
+	defer cancel()
+
+	return errors.Join(runErr, shutdown(shutdownCtx))
+}
+
+func (c *Client) shutdownTimeout() time.Duration {
+	if c.ShutdownTimeout > 0 {
+		return c.ShutdownTimeout
+	}
+
+	return defaultShutdownTimeout
+}
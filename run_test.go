@@ -0,0 +1,79 @@
+package silgotel_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestRunWithSignalsReturnsRunError(t *testing.T) {
+	errRun := errors.New("run failed")
+
+	err := silgotel.RunWithSignals(t.Context(), newClient(t), func(context.Context) error {
+		return errRun
+	}, silgotel.WithoutGlobalRegistration())
+	if !errors.Is(err, errRun) {
+		t.Errorf("RunWithSignals() error = %v, want %v", err, errRun)
+	}
+}
+
+func TestRunWithSignalsCancelsOnSIGTERM(t *testing.T) {
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	err := silgotel.RunWithSignals(t.Context(), client, func(ctx context.Context) error {
+		_, span := client.Tracer("run").Start(ctx, "drain queue")
+		span.End()
+
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			return err
+		}
+
+		err = process.Signal(syscall.SIGTERM)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("context not cancelled by SIGTERM")
+		}
+	}, silgotel.WithoutGlobalRegistration())
+	if err != nil {
+		t.Errorf("RunWithSignals() error = %v", err)
+	}
+
+	// The batch processor would hold the span for seconds; only the
+	// shutdown flush gets it to the collector this early.
+	if names := collector.SpanNames(t); !slices.Contains(names, "drain queue") {
+		t.Errorf("collector received spans %v, want the span run emitted before the signal", names)
+	}
+}
+
+func TestRunWithSignalsInvalidClient(t *testing.T) {
+	called := false
+
+	err := silgotel.RunWithSignals(t.Context(), &silgotel.Client{}, func(context.Context) error {
+		called = true
+
+		return nil
+	})
+	if err == nil {
+		t.Error("RunWithSignals() error = nil, want a validation error")
+	}
+
+	if called {
+		t.Error("run was called although the SDK failed to start")
+	}
+}
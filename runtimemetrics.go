@@ -0,0 +1,44 @@
+package silgotel
+
+import (
+	"fmt"
+
+	otelruntime "go.opentelemetry.io/contrib/instrumentation/runtime"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// WithRuntimeMetrics starts the OpenTelemetry Go runtime instrumentation on
+// the Client's meter provider, reporting heap usage, allocations, the GC
+// goal, goroutine count and GOMAXPROCS as the go.* metrics, and the
+// scheduler latency histogram go.schedule.duration. GC pauses are already
+// reported as system.memory.gc_pause_time. The scheduler latency is only
+// added to the readers built by the package, not to those passed through
+// WithMetricReader.
+func WithRuntimeMetrics() Option {
+	return func(o *options) {
+		o.runtimeMetrics = true
+	}
+}
+
+// startRuntimeMetrics registers the runtime instruments on the meter
+// provider.
+func (c *Client) startRuntimeMetrics() error {
+	err := otelruntime.Start(otelruntime.WithMeterProvider(c.meterProvider))
+	if err != nil {
+		return fmt.Errorf("starting runtime metrics: %w", err)
+	}
+
+	return nil
+}
+
+// runtimeProducer returns the producer of the scheduler latency histogram,
+// or nil when WithRuntimeMetrics is not set.
+//
+//nolint:ireturn
+func (c *Client) runtimeProducer() sdkmetric.Producer {
+	if !c.options.runtimeMetrics {
+		return nil
+	}
+
+	return otelruntime.NewProducer()
+}
@@ -0,0 +1,52 @@
+package silgotel_test
+
+import (
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// goMetrics returns the names of the go.* runtime metrics in rm.
+func goMetrics(rm metricdata.ResourceMetrics) []string {
+	var names []string
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if strings.HasPrefix(m.Name, "go.") {
+				names = append(names, m.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+func TestWithRuntimeMetrics(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithRuntimeMetrics())
+
+	rm := sdk.Metrics(t)
+
+	names := goMetrics(rm)
+	if len(names) == 0 {
+		t.Fatal("no go.* runtime metrics were collected")
+	}
+
+	m, ok := findMetric(rm, "go.goroutine.count")
+	if !ok {
+		t.Fatalf("runtime metrics = %v, want go.goroutine.count among them", names)
+	}
+
+	if points := m.Data.(metricdata.Sum[int64]).DataPoints; len(points) != 1 || points[0].Value < 1 { //nolint:forcetypeassert
+		t.Errorf("go.goroutine.count = %v, want the running goroutines", points)
+	}
+}
+
+func TestRuntimeMetricsOff(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	if names := goMetrics(sdk.Metrics(t)); len(names) != 0 {
+		t.Errorf("runtime metrics = %v without WithRuntimeMetrics, want none", names)
+	}
+}
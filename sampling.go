@@ -0,0 +1,225 @@
+package silgotel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampler resolves the tracer provider's sampler. An explicit WithSampler
+// wins over environment sampling; nil keeps the SDK default.
+//
+//nolint:ireturn
+func (c *Client) sampler() trace.Sampler {
+	sampler := c.baseSampler()
+
+	if c.options.alwaysSampleErrors {
+		if sampler == nil {
+			sampler = trace.ParentBased(trace.AlwaysSample())
+		}
+
+		return recordUnsampled{base: sampler}
+	}
+
+	return sampler
+}
+
+//nolint:ireturn
+func (c *Client) baseSampler() trace.Sampler {
+	if c.options.sampler != nil {
+		return c.options.sampler
+	}
+
+	if c.options.environmentSampling != nil {
+		ratio, ok := c.options.environmentSampling[c.Environment]
+		if !ok {
+			ratio = c.options.fallbackSampling
+		}
+
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio))
+	}
+
+	return c.configuredSampler()
+}
+
+// configuredSampler builds the sampler named by the Sampler and
+// SamplingRatio fields, or nil when neither is set.
+//
+//nolint:ireturn
+func (c *Client) configuredSampler() trace.Sampler {
+	name := c.Sampler
+	if name == "" && c.SamplingRatio > 0 {
+		name = "parentbased_traceidratio"
+	}
+
+	switch name {
+	case "always_on":
+		return trace.AlwaysSample()
+	case "always_off":
+		return trace.NeverSample()
+	case "traceidratio":
+		return trace.TraceIDRatioBased(c.SamplingRatio)
+	case "parentbased_always_on":
+		return trace.ParentBased(trace.AlwaysSample())
+	case "parentbased_always_off":
+		return trace.ParentBased(trace.NeverSample())
+	case "parentbased_traceidratio":
+		return trace.ParentBased(trace.TraceIDRatioBased(c.SamplingRatio))
+	default:
+		return nil
+	}
+}
+
+// routeSampler drops spans for noise routes such as health checks and
+// delegates everything else to base.
+type routeSampler struct {
+	base       trace.Sampler
+	dropRoutes []string
+}
+
+// NewRouteSampler returns a sampler that never samples spans whose
+// http.route or url.path start attribute begins with one of dropRoutes, and
+// defers to base for every other span. Pass it to WithSampler.
+//
+//	silgotel.WithSampler(silgotel.NewRouteSampler(
+//		trace.ParentBased(trace.TraceIDRatioBased(0.1)),
+//		[]string{"/health", "/readyz"},
+//	))
+//
+//nolint:ireturn
+func NewRouteSampler(base trace.Sampler, dropRoutes []string) trace.Sampler {
+	return &routeSampler{base: base, dropRoutes: dropRoutes}
+}
+
+//nolint:gocritic
+func (s *routeSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	for _, kv := range p.Attributes {
+		if kv.Key != "http.route" && kv.Key != "url.path" {
+			continue
+		}
+
+		for _, prefix := range s.dropRoutes {
+			if strings.HasPrefix(kv.Value.AsString(), prefix) {
+				return trace.SamplingResult{
+					Decision:   trace.Drop,
+					Tracestate: otelTrace.SpanContextFromContext(p.ParentContext).TraceState(),
+				}
+			}
+		}
+	}
+
+	return s.base.ShouldSample(p)
+}
+
+func (s *routeSampler) Description() string {
+	return fmt.Sprintf("RouteSampler{%s,drop=%v}", s.base.Description(), s.dropRoutes)
+}
+
+// perOperationSampler samples root spans at a ratio chosen by span name.
+type perOperationSampler struct {
+	fallback  trace.Sampler
+	overrides map[string]trace.Sampler
+}
+
+// NewPerOperationSampler samples root spans named in overrides at their
+// ratio and every other root span at defaultRatio; child spans follow their
+// parent. The ratio samplers are built up front, so a decision costs one map
+// lookup. Pass it to WithSampler:
+//
+//	silgotel.WithSampler(silgotel.NewPerOperationSampler(0.1, map[string]float64{
+//		"cache.get":  0.001,
+//		"auth.check": 0.01,
+//	}))
+//
+//nolint:ireturn
+func NewPerOperationSampler(defaultRatio float64, overrides map[string]float64) trace.Sampler {
+	s := &perOperationSampler{
+		fallback:  trace.TraceIDRatioBased(defaultRatio),
+		overrides: make(map[string]trace.Sampler, len(overrides)),
+	}
+
+	for name, ratio := range overrides {
+		s.overrides[name] = trace.TraceIDRatioBased(ratio)
+	}
+
+	return trace.ParentBased(s)
+}
+
+//nolint:gocritic
+func (s *perOperationSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	if sampler, ok := s.overrides[p.Name]; ok {
+		return sampler.ShouldSample(p)
+	}
+
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *perOperationSampler) Description() string {
+	return fmt.Sprintf("PerOperationSampler{%s,overrides=%d}", s.fallback.Description(), len(s.overrides))
+}
+
+// recordUnsampled turns the drop decisions of base into record-only ones so
+// that unsampled spans can still be exported if they end in error.
+type recordUnsampled struct {
+	base trace.Sampler
+}
+
+//nolint:gocritic
+func (s recordUnsampled) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	result := s.base.ShouldSample(p)
+	if result.Decision == trace.Drop {
+		result.Decision = trace.RecordOnly
+	}
+
+	return result
+}
+
+func (s recordUnsampled) Description() string {
+	return "RecordUnsampled{" + s.base.Description() + "}"
+}
+
+// errorSpanProcessor hands the exporting processor next the spans it would
+// receive anyway, the sampled ones, plus those that were recorded but not
+// sampled and ended with an error status. These are marked sampled so that
+// next queues them like any other span: they share its bounded queue, export
+// timeout and exporter instead of being exported inline in span.End.
+type errorSpanProcessor struct {
+	next trace.SpanProcessor
+}
+
+func (p *errorSpanProcessor) OnStart(ctx context.Context, s trace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *errorSpanProcessor) OnEnd(s trace.ReadOnlySpan) {
+	switch {
+	case s.SpanContext().IsSampled():
+		p.next.OnEnd(s)
+	case s.Status().Code == codes.Error:
+		p.next.OnEnd(sampledSpan{ReadOnlySpan: s})
+	}
+}
+
+func (p *errorSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *errorSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// sampledSpan reports an unsampled span as sampled so that the SDK's span
+// processors, which skip unsampled spans, export it.
+type sampledSpan struct {
+	trace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() otelTrace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
@@ -0,0 +1,213 @@
+package silgotel_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampledParent returns a context holding a sampled remote span context, as
+// extracted from an incoming request.
+func sampledParent(ctx context.Context) context.Context {
+	return otelTrace.ContextWithRemoteSpanContext(ctx, otelTrace.NewSpanContext(otelTrace.SpanContextConfig{
+		TraceID:    otelTrace.TraceID{1},
+		SpanID:     otelTrace.SpanID{1},
+		TraceFlags: otelTrace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+// exportedSpans starts and ends a span named name in ctx for each name and
+// returns how many were exported.
+func exportedSpans(t *testing.T, sdk *testSDK, ctx context.Context, names ...string) int {
+	t.Helper()
+
+	tracer := sdk.client.Tracer("sampling")
+
+	for _, name := range names {
+		_, span := tracer.Start(ctx, name)
+		span.End()
+	}
+
+	return len(sdk.Spans(t))
+}
+
+func TestEnvironmentSampling(t *testing.T) {
+	ratios := map[string]float64{"test": 0, "staging": 1}
+
+	t.Run("listed environment", func(t *testing.T) {
+		sdk := startSDK(t, newClient(t), silgotel.WithEnvironmentSampling(ratios, 1))
+
+		if n := exportedSpans(t, sdk, t.Context(), "a", "b", "c"); n != 0 {
+			t.Errorf("exported %d spans at ratio 0, want none", n)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		client := newClient(t)
+		client.Environment = "qa"
+
+		sdk := startSDK(t, client, silgotel.WithEnvironmentSampling(ratios, 1))
+
+		if n := exportedSpans(t, sdk, t.Context(), "a", "b", "c"); n != 3 {
+			t.Errorf("exported %d spans at the fallback ratio 1, want 3", n)
+		}
+	})
+
+	t.Run("children follow their parent", func(t *testing.T) {
+		sdk := startSDK(t, newClient(t), silgotel.WithEnvironmentSampling(ratios, 1))
+
+		if n := exportedSpans(t, sdk, sampledParent(t.Context()), "a", "b"); n != 2 {
+			t.Errorf("exported %d children of a sampled parent, want 2", n)
+		}
+	})
+
+	t.Run("WithSampler wins", func(t *testing.T) {
+		sdk := startSDK(t, newClient(t),
+			silgotel.WithSampler(trace.AlwaysSample()),
+			silgotel.WithEnvironmentSampling(ratios, 1),
+		)
+
+		if n := exportedSpans(t, sdk, t.Context(), "a", "b"); n != 2 {
+			t.Errorf("exported %d spans, want WithSampler to sample both", n)
+		}
+	})
+}
+
+func TestRouteSampler(t *testing.T) {
+	base := trace.TraceIDRatioBased(0.5)
+	sampler := silgotel.NewRouteSampler(base, []string{"/health", "/readyz"})
+
+	for _, attr := range []attribute.KeyValue{
+		attribute.String("http.route", "/health"),
+		attribute.String("url.path", "/health/live"),
+		attribute.String("url.path", "/readyz"),
+	} {
+		result := sampler.ShouldSample(trace.SamplingParameters{
+			ParentContext: t.Context(),
+			TraceID:       otelTrace.TraceID{},
+			Name:          "GET",
+			Attributes:    []attribute.KeyValue{attr},
+		})
+		if result.Decision != trace.Drop {
+			t.Errorf("%s=%s decision = %v, want drop", attr.Key, attr.Value.AsString(), result.Decision)
+		}
+	}
+
+	// Other routes follow the base ratio, which decides on the trace ID.
+	for i := range 16 {
+		params := trace.SamplingParameters{
+			ParentContext: t.Context(),
+			TraceID:       otelTrace.TraceID{8: byte(i * 16)},
+			Name:          "GET",
+			Attributes:    []attribute.KeyValue{attribute.String("http.route", "/orders")},
+		}
+
+		if got, want := sampler.ShouldSample(params).Decision, base.ShouldSample(params).Decision; got != want {
+			t.Errorf("decision for trace %v = %v, want the base sampler's %v", params.TraceID, got, want)
+		}
+	}
+}
+
+func TestRouteSamplerThroughSDK(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithSampler(silgotel.NewRouteSampler(trace.AlwaysSample(), []string{"/health"})),
+	)
+
+	tracer := sdk.client.Tracer("sampling")
+
+	for _, route := range []string{"/health", "/orders"} {
+		_, span := tracer.Start(t.Context(), "GET "+route, otelTrace.WithAttributes(attribute.String("http.route", route)))
+		span.End()
+	}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].Name != "GET /orders" {
+		t.Errorf("exported spans = %v, want only GET /orders", spans)
+	}
+}
+
+func TestAlwaysSampleErrors(t *testing.T) {
+	exporter := &namingExporter{}
+
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithSampler(trace.NeverSample()),
+		silgotel.WithAlwaysSampleErrors(),
+		silgotel.WithAdditionalTraceExporter(exporter),
+	)
+
+	tracer := sdk.client.Tracer("sampling")
+
+	_, ok := tracer.Start(t.Context(), "ok")
+	ok.End()
+
+	_, failed := tracer.Start(t.Context(), "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+
+	// The error span joins the batch queue rather than being exported
+	// inline in End.
+	if names := exporter.Names(); len(names) != 0 {
+		t.Errorf("exported %v before the batch ran, want nothing yet", names)
+	}
+
+	if spans := sdk.Spans(t); len(spans) != 1 || spans[0].Name != "failed" {
+		t.Errorf("exported spans = %v, want only the error span", spans)
+	}
+
+	if names := exporter.Names(); !slices.Equal(names, []string{"failed"}) {
+		t.Errorf("additional exporter received %v, want [failed]", names)
+	}
+}
+
+func TestPerOperationSampler(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithSampler(silgotel.NewPerOperationSampler(0.5, map[string]float64{"cache.get": 0.1})),
+	)
+
+	const perName = 2000
+
+	tracer := sdk.client.Tracer("sampling")
+
+	for range perName {
+		for _, name := range []string{"cache.get", "checkout"} {
+			_, span := tracer.Start(t.Context(), name)
+			span.End()
+		}
+	}
+
+	sampled := make(map[string]int)
+	for _, span := range sdk.Spans(t) {
+		sampled[span.Name]++
+	}
+
+	for name, ratio := range map[string]float64{"cache.get": 0.1, "checkout": 0.5} {
+		if got := float64(sampled[name]) / perName; got < ratio-0.05 || got > ratio+0.05 {
+			t.Errorf("%s sampled %.3f of spans, want about %.2f", name, got, ratio)
+		}
+	}
+}
+
+func TestPerOperationSamplerFollowsParent(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithSampler(silgotel.NewPerOperationSampler(0, map[string]float64{"cache.get": 0})),
+	)
+
+	if n := exportedSpans(t, sdk, sampledParent(t.Context()), "cache.get", "checkout"); n != 2 {
+		t.Errorf("exported %d children of a sampled parent, want 2", n)
+	}
+}
+
+func TestPerOperationSamplerAllocations(t *testing.T) {
+	sampler := silgotel.NewPerOperationSampler(0.5, map[string]float64{"cache.get": 0.1})
+	params := trace.SamplingParameters{ParentContext: t.Context(), TraceID: otelTrace.TraceID{1}, Name: "cache.get"}
+
+	if allocs := testing.AllocsPerRun(100, func() { sampler.ShouldSample(params) }); allocs != 0 {
+		t.Errorf("ShouldSample() allocates %v times per call, want 0", allocs)
+	}
+}
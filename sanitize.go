@@ -0,0 +1,19 @@
+package silgotel
+
+import "regexp"
+
+//nolint:gochecknoglobals
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`(^|[^\w$?:.])\d+(?:\.\d+)?\b`)
+)
+
+// SanitizeQuery replaces string and numeric literals in query with '?' so
+// that values inlined into statements, such as a patient ID, do not end up
+// in span attributes. Numbered placeholders such as $1, ?1 and :1 are kept.
+// The database integrations apply it to every recorded db.query.text.
+func SanitizeQuery(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+
+	return numericLiteral.ReplaceAllString(query, "${1}?")
+}
@@ -0,0 +1,127 @@
+package silgotel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Values of the signal attribute on the self-observability metrics.
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// exportMetrics are the instruments the package records about its own
+// exports. They are created on first use because the exporters are built
+// before the meter provider.
+type exportMetrics struct {
+	client *Client
+	once   sync.Once
+
+	spansExported otelMetric.Int64Counter
+	spansDropped  otelMetric.Int64Counter
+	errors        otelMetric.Int64Counter
+	duration      otelMetric.Float64Histogram
+}
+
+func (m *exportMetrics) init() {
+	m.once.Do(func() {
+		meter := m.client.Meter(instrumentationScope)
+
+		m.spansExported = mustInstrument(meter.Int64Counter(
+			"silgotel.spans.exported",
+			otelMetric.WithDescription("Number of spans exported successfully"),
+			otelMetric.WithUnit("{span}"),
+		))
+		m.spansDropped = mustInstrument(meter.Int64Counter(
+			"silgotel.spans.dropped",
+			otelMetric.WithDescription("Number of spans dropped because the export queue was full"),
+			otelMetric.WithUnit("{span}"),
+		))
+		m.errors = mustInstrument(meter.Int64Counter(
+			"silgotel.export.errors",
+			otelMetric.WithDescription("Number of failed exports by signal"),
+			otelMetric.WithUnit("1"),
+		))
+		m.duration = mustInstrument(meter.Float64Histogram(
+			"silgotel.export.duration",
+			otelMetric.WithDescription("Duration of exports by signal"),
+			otelMetric.WithUnit("s"),
+		))
+	})
+}
+
+// recordExport records the outcome of one export of signal.
+func (m *exportMetrics) recordExport(ctx context.Context, signal string, start time.Time, err error) {
+	m.init()
+
+	attrs := otelMetric.WithAttributes(attribute.String("signal", signal))
+
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+
+	if err != nil {
+		m.errors.Add(ctx, 1, attrs)
+	}
+}
+
+// selfObservedSpanExporter records export metrics around a span exporter.
+type selfObservedSpanExporter struct {
+	trace.SpanExporter
+
+	metrics *exportMetrics
+}
+
+func (e *selfObservedSpanExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	start := time.Now()
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+
+	e.metrics.recordExport(ctx, signalTraces, start, err)
+
+	if err == nil {
+		e.metrics.spansExported.Add(ctx, int64(len(spans)))
+	}
+
+	return err
+}
+
+// selfObservedMetricExporter records export metrics around a metric
+// exporter.
+type selfObservedMetricExporter struct {
+	sdkmetric.Exporter
+
+	metrics *exportMetrics
+}
+
+func (e *selfObservedMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, rm)
+
+	e.metrics.recordExport(ctx, signalMetrics, start, err)
+
+	return err
+}
+
+// selfObservedLogExporter records export metrics around a log exporter.
+type selfObservedLogExporter struct {
+	log.Exporter
+
+	metrics *exportMetrics
+}
+
+func (e *selfObservedLogExporter) Export(ctx context.Context, records []log.Record) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, records)
+
+	e.metrics.recordExport(ctx, signalLogs, start, err)
+
+	return err
+}
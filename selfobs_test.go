@@ -0,0 +1,160 @@
+package silgotel_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// int64Sum returns the total of the int64 sum m.
+func int64Sum(m metricdata.Metrics) int64 {
+	var total int64
+
+	for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints { //nolint:forcetypeassert
+		total += point.Value
+	}
+
+	return total
+}
+
+func TestSelfObservabilityExports(t *testing.T) {
+	sdk := startSDK(t, newClient(t))
+
+	for range 3 {
+		_, span := sdk.client.Tracer("selfobs").Start(t.Context(), "op")
+		span.End()
+	}
+
+	sdk.flush(t)
+
+	rm := sdk.Metrics(t)
+
+	exported, ok := findMetric(rm, "silgotel.spans.exported")
+	if !ok {
+		t.Fatal("silgotel.spans.exported was not recorded")
+	}
+
+	// The OTLP exporter and the test's in-memory one each count the spans.
+	if got := int64Sum(exported); got != 6 {
+		t.Errorf("silgotel.spans.exported = %d, want 3 spans from each of 2 exporters", got)
+	}
+
+	duration, ok := findMetric(rm, "silgotel.export.duration")
+	if !ok {
+		t.Fatal("silgotel.export.duration was not recorded")
+	}
+
+	for _, point := range duration.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+		if signal, _ := point.Attributes.Value("signal"); signal.AsString() == "traces" && point.Count == 0 {
+			t.Error("silgotel.export.duration has no trace exports")
+		}
+	}
+
+	if _, ok := findMetric(rm, "silgotel.export.errors"); ok {
+		t.Error("silgotel.export.errors was recorded for successful exports")
+	}
+}
+
+func TestSelfObservabilityExportErrors(t *testing.T) {
+	silenceDefaultLogger(t)
+
+	sdk := startSDK(t, rejectingClient(t))
+
+	_, span := sdk.client.Tracer("selfobs").Start(t.Context(), "op")
+	span.End()
+
+	counter, _ := sdk.client.Meter("selfobs").Int64Counter("selfobs.count")
+	counter.Add(t.Context(), 1)
+
+	slog.New(sdk.client.NewSlogHandler(slog.DiscardHandler)).InfoContext(t.Context(), "rejected")
+
+	_ = sdk.client.ForceFlush(t.Context())
+
+	m, ok := findMetric(sdk.Metrics(t), "silgotel.export.errors")
+	if !ok {
+		t.Fatal("silgotel.export.errors was not recorded")
+	}
+
+	failed := make(map[string]int64)
+
+	for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints { //nolint:forcetypeassert
+		signal, _ := point.Attributes.Value("signal")
+		failed[signal.AsString()] += point.Value
+	}
+
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		if failed[signal] == 0 {
+			t.Errorf("silgotel.export.errors has no %s failures: %v", signal, failed)
+		}
+	}
+}
+
+func TestSelfObservabilityDroppedSpans(t *testing.T) {
+	t.Setenv("OTEL_BSP_MAX_QUEUE_SIZE", "2")
+
+	release := make(chan struct{})
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		if r.URL.Path == "/v1/traces" {
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+
+	sdk := startSDK(t, client, silgotel.WithBatchTimeout(1))
+	t.Cleanup(func() { close(release) })
+
+	for range 50 {
+		_, span := sdk.client.Tracer("selfobs").Start(t.Context(), "burst")
+		span.End()
+	}
+
+	m, ok := findMetric(sdk.Metrics(t), "silgotel.spans.dropped")
+	if !ok {
+		t.Fatal("silgotel.spans.dropped was not recorded")
+	}
+
+	// The stalled OTLP processor lets through only its queue of 2 and a batch
+	// in flight; the in-memory exporter, with a queue of its own, may drop
+	// some of the burst too.
+	if dropped := int64Sum(m); dropped < 50-3 || dropped > 2*50 {
+		t.Errorf("silgotel.spans.dropped = %d, want between %d and %d", dropped, 50-3, 2*50)
+	}
+
+	// A stalled collector does not hold up the other processor's exports.
+	// Flushing would wait on the collector, so poll instead.
+	deadline := time.Now().Add(time.Second)
+	for len(sdk.spans.GetSpans()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("in-memory exporter received no spans while the collector was stalled")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithoutSelfObservability(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithoutSelfObservability())
+
+	emitAll(t, sdk)
+
+	rm := sdk.Metrics(t)
+	for _, name := range []string{"silgotel.spans.exported", "silgotel.export.duration"} {
+		if _, ok := findMetric(rm, name); ok {
+			t.Errorf("%s was recorded under WithoutSelfObservability", name)
+		}
+	}
+}
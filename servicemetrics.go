@@ -0,0 +1,97 @@
+package silgotel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+)
+
+// ServiceMetrics bundles the instruments every service records so that
+// names, units and descriptions stay consistent across services.
+type ServiceMetrics struct {
+	RequestsTotal   otelMetric.Int64Counter
+	RequestDuration otelMetric.Float64Histogram
+	ActiveRequests  otelMetric.Int64UpDownCounter
+	ErrorsTotal     otelMetric.Int64Counter
+}
+
+// NewServiceMetrics creates the standard service instruments on the meter
+// named serviceName. Create it once and share it.
+func NewServiceMetrics(serviceName string) (*ServiceMetrics, error) {
+	meter := Meter(serviceName)
+
+	requestsTotal, err := meter.Int64Counter(
+		"service.requests_total",
+		otelMetric.WithDescription("Total number of requests handled"),
+		otelMetric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		"service.request_duration",
+		otelMetric.WithDescription("Request duration in seconds"),
+		otelMetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRequests, err := meter.Int64UpDownCounter(
+		"service.active_requests",
+		otelMetric.WithDescription("Number of requests currently being handled"),
+		otelMetric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	errorsTotal, err := meter.Int64Counter(
+		"service.errors_total",
+		otelMetric.WithDescription("Total number of requests that failed"),
+		otelMetric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceMetrics{
+		RequestsTotal:   requestsTotal,
+		RequestDuration: requestDuration,
+		ActiveRequests:  activeRequests,
+		ErrorsTotal:     errorsTotal,
+	}, nil
+}
+
+// ObserveRequest records a finished request. A status of "error" or a 5xx
+// status code also counts towards ErrorsTotal.
+func (m *ServiceMetrics) ObserveRequest(ctx context.Context, route, status string, dur time.Duration) {
+	attrs := otelMetric.WithAttributes(
+		attribute.String("route", route),
+		attribute.String("status", status),
+	)
+
+	m.RequestsTotal.Add(ctx, 1, attrs)
+	m.RequestDuration.Record(ctx, dur.Seconds(), attrs)
+
+	if status == "error" || strings.HasPrefix(status, "5") {
+		m.ErrorsTotal.Add(ctx, 1, attrs)
+	}
+}
+
+// TrackActive increments ActiveRequests for route and returns a function
+// that decrements it again.
+//
+//	defer metrics.TrackActive(ctx, "/patients")()
+func (m *ServiceMetrics) TrackActive(ctx context.Context, route string) func() {
+	attrs := otelMetric.WithAttributes(attribute.String("route", route))
+	m.ActiveRequests.Add(ctx, 1, attrs)
+
+	return func() {
+		m.ActiveRequests.Add(ctx, -1, attrs)
+	}
+}
@@ -0,0 +1,77 @@
+package silgotel_test
+
+import (
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestServiceMetrics(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	metrics, err := silgotel.NewServiceMetrics("orders")
+	if err != nil {
+		t.Fatalf("NewServiceMetrics() error = %v", err)
+	}
+
+	ctx := t.Context()
+
+	metrics.ObserveRequest(ctx, "/orders", "200", 100*time.Millisecond)
+	metrics.ObserveRequest(ctx, "/orders", "503", 300*time.Millisecond)
+	metrics.ObserveRequest(ctx, "/orders", "error", time.Second)
+
+	done := metrics.TrackActive(ctx, "/orders")
+	metrics.TrackActive(ctx, "/orders")
+	done()
+
+	rm := sdk.Metrics()
+
+	for name, unit := range map[string]string{
+		"service.requests_total":   "1",
+		"service.request_duration": "s",
+		"service.active_requests":  "1",
+		"service.errors_total":     "1",
+	} {
+		m, ok := findMetric(rm, name)
+		if !ok {
+			t.Errorf("%s was not recorded", name)
+
+			continue
+		}
+
+		if m.Unit != unit || m.Description == "" {
+			t.Errorf("%s unit, description = %q, %q, want %q and a description", name, m.Unit, m.Description, unit)
+		}
+	}
+
+	for name, want := range map[string]int64{
+		"service.requests_total":  3,
+		"service.errors_total":    2,
+		"service.active_requests": 1,
+	} {
+		m, _ := findMetric(rm, name)
+
+		var got int64
+		for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints { //nolint:forcetypeassert
+			got += point.Value
+		}
+
+		if got != want {
+			t.Errorf("%s = %d, want %d", name, got, want)
+		}
+	}
+
+	m, _ := findMetric(rm, "service.request_duration")
+
+	var sum float64
+	for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+		sum += point.Sum
+	}
+
+	if sum < 1.39 || sum > 1.41 {
+		t.Errorf("service.request_duration sum = %v, want 1.4 seconds", sum)
+	}
+}
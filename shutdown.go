@@ -0,0 +1,49 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// providerShutdown is one step of the SDK shutdown.
+type providerShutdown struct {
+	name     string
+	shutdown func(context.Context) error
+}
+
+// shutdownProviders shuts the providers down in the order given, which setup
+// keeps as traces, metrics, logs so that telemetry about the earlier exports
+// can still leave through the later providers. Each provider gets an equal
+// share of the time left, plus whatever the ones before it did not use, so
+// an unreachable collector cannot make the first provider eat the whole
+// deadline. A ctx without a deadline is bounded by the client's
+// ShutdownTimeout.
+func (c *Client) shutdownProviders(ctx context.Context, steps []providerShutdown) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.shutdownTimeout())
+		defer cancel()
+	}
+
+	deadline, _ := ctx.Deadline()
+
+	var err error
+
+	for i, step := range steps {
+		budget := time.Until(deadline) / time.Duration(len(steps)-i)
+
+		stepCtx, cancel := context.WithTimeout(ctx, budget)
+		stepErr := step.shutdown(stepCtx)
+
+		cancel()
+
+		if stepErr != nil {
+			err = errors.Join(err, fmt.Errorf("shutting down %s provider: %w", step.name, stepErr))
+		}
+	}
+
+	return err
+}
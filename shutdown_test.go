@@ -0,0 +1,232 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// blockingShutdown returns a shutdown step that takes the time it is given,
+// as a provider behind an unreachable collector does, and records its name
+// in order.
+func blockingShutdown(name string, order *[]string) providerShutdown {
+	return providerShutdown{name: name, shutdown: func(ctx context.Context) error {
+		*order = append(*order, name)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}}
+}
+
+func TestShutdownProvidersSharesBudget(t *testing.T) {
+	client := &Client{ShutdownTimeout: 300 * time.Millisecond}
+
+	var order []string
+
+	steps := []providerShutdown{
+		blockingShutdown("tracer", &order),
+		blockingShutdown("meter", &order),
+		blockingShutdown("logger", &order),
+	}
+
+	start := time.Now()
+	err := client.shutdownProviders(context.Background(), steps)
+
+	if elapsed := time.Since(start); elapsed > 450*time.Millisecond {
+		t.Errorf("shutdown took %v, want it bounded by the 300ms ShutdownTimeout", elapsed)
+	}
+
+	if want := []string{"tracer", "meter", "logger"}; !slices.Equal(order, want) {
+		t.Errorf("providers shut down in order %v, want %v", order, want)
+	}
+
+	for _, name := range []string{"tracer", "meter", "logger"} {
+		if !strings.Contains(err.Error(), "shutting down "+name+" provider") {
+			t.Errorf("shutdown error = %q, want it to name the %s provider", err, name)
+		}
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("shutdown error = %v, want the providers' deadline errors joined", err)
+	}
+}
+
+func TestShutdownProvidersPassesUnusedBudgetOn(t *testing.T) {
+	client := &Client{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	var remaining time.Duration
+
+	steps := []providerShutdown{
+		{name: "tracer", shutdown: func(context.Context) error { return nil }},
+		{name: "logger", shutdown: func(ctx context.Context) error {
+			deadline, _ := ctx.Deadline()
+			remaining = time.Until(deadline)
+
+			return nil
+		}},
+	}
+
+	if err := client.shutdownProviders(ctx, steps); err != nil {
+		t.Fatalf("shutdown error = %v", err)
+	}
+
+	// An even split would leave the logger 150ms; the tracer's unused share
+	// goes to it.
+	if remaining < 250*time.Millisecond {
+		t.Errorf("last provider got %v, want the budget the first one left", remaining)
+	}
+}
+
+func TestShutdownTimeoutDefault(t *testing.T) {
+	if got := (&Client{}).shutdownTimeout(); got != defaultShutdownTimeout {
+		t.Errorf("shutdownTimeout() = %v, want the %v default", got, defaultShutdownTimeout)
+	}
+}
+
+// slowSpanExporter and slowLogExporter never finish shutting down before
+// their context ends.
+type (
+	slowSpanExporter struct{}
+	slowLogExporter  struct{}
+)
+
+func (slowSpanExporter) ExportSpans(context.Context, []trace.ReadOnlySpan) error { return nil }
+
+func (slowSpanExporter) Shutdown(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (slowLogExporter) Export(context.Context, []log.Record) error { return nil }
+func (slowLogExporter) ForceFlush(context.Context) error           { return nil }
+
+func (slowLogExporter) Shutdown(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestShutdownBoundedWithSlowExporters(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := &Client{
+		OTLPBaseURL:     collector.URL,
+		ServiceName:     "test-service",
+		Environment:     "test",
+		Version:         "1.0.0",
+		ShutdownTimeout: 300 * time.Millisecond,
+	}
+
+	shutdown, err := NewOtelSDK(t.Context(), client,
+		WithoutGlobalRegistration(),
+		WithAdditionalTraceExporter(slowSpanExporter{}),
+		WithAdditionalLogExporter(slowLogExporter{}),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	start := time.Now()
+	err = shutdown(context.Background())
+
+	// Each slow exporter alone would take the whole budget; sequential
+	// shutdowns with a fresh timeout each would take twice as long.
+	if elapsed := time.Since(start); elapsed > 450*time.Millisecond {
+		t.Errorf("shutdown took %v, want it bounded by the 300ms ShutdownTimeout", elapsed)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "tracer provider") || !strings.Contains(err.Error(), "logger provider") {
+		t.Errorf("shutdown error = %v, want it to name the tracer and logger providers", err)
+	}
+}
+
+// cachedEntries returns the keys of every package cache.
+func cachedEntries() map[any]bool {
+	entries := make(map[any]bool)
+
+	for _, cache := range []*sync.Map{
+		&tracerCache, &meterCache, &loggerCache, &meterOwners,
+		&histogramCache, &upDownCache, &gaugeCache, &errorCounterCache,
+	} {
+		cache.Range(func(key, _ any) bool {
+			entries[key] = true
+
+			return true
+		})
+	}
+
+	return entries
+}
+
+func TestShutdownEvictsCachedValues(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	loggerProvider := global.GetLoggerProvider()
+	propagator := otel.GetTextMapPropagator()
+	errorHandler := otel.GetErrorHandler()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+		global.SetLoggerProvider(loggerProvider)
+		otel.SetTextMapPropagator(propagator)
+		otel.SetErrorHandler(errorHandler)
+	})
+
+	before := cachedEntries()
+
+	client := &Client{OTLPBaseURL: collector.URL, ServiceName: "test-service", Environment: "test", Version: "1.0.0"}
+
+	shutdown, err := NewOtelSDK(t.Context(), client)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	ctx, span := Trace(t.Context(), "evict", "op")
+	_ = MeasureDuration(ctx, "evict", "op", func(context.Context) error { return nil })
+	_ = RecordError(ctx, errors.New("payment declined"))
+	AddUpDown(ctx, client.Meter("evict"), "evict.workers", 1)
+	SetGauge(ctx, Meter("evict"), "evict.depth", 3)
+	LogInfo(ctx, "evict", "order placed")
+	span.End()
+
+	if used := cachedEntries(); len(used) <= len(before) {
+		t.Fatalf("the helpers cached %d entries, want some", len(used)-len(before))
+	}
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	for key := range cachedEntries() {
+		if !before[key] {
+			t.Errorf("cache entry %v outlived the client's shutdown", key)
+		}
+	}
+}
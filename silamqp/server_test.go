@@ -0,0 +1,292 @@
+package silamqp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+)
+
+// AMQP 0-9-1 frame types and the class and method IDs the server handles.
+const (
+	frameMethod = 1
+	frameHeader = 2
+	frameBody   = 3
+	frameEnd    = 0xCE
+
+	classConnection = 10
+	classChannel    = 20
+	classQueue      = 50
+	classBasic      = 60
+)
+
+// publishing is a message received by the server.
+type publishing struct {
+	exchange, key string
+	messageID     string
+	headers       map[string]string
+	body          []byte
+	bodySize      uint64
+}
+
+// server is an in-process AMQP 0-9-1 broker speaking just enough of the
+// protocol for amqp091-go to connect, open channels, publish and inspect
+// queues. Every queue reports queueDepth ready messages.
+type server struct {
+	listener   net.Listener
+	queueDepth uint32
+
+	mu          sync.Mutex
+	conns       []net.Conn
+	publishings []publishing
+}
+
+// newServer starts a server that is closed when the test ends.
+func newServer(t *testing.T, queueDepth uint32) *server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	s := &server{listener: listener, queueDepth: queueDepth}
+
+	var wg sync.WaitGroup
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+
+		s.mu.Lock()
+		for _, conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+
+		wg.Wait()
+	})
+
+	wg.Go(func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+
+			wg.Go(func() { s.serve(conn) })
+		}
+	})
+
+	return s
+}
+
+// URL returns the URL clients dial.
+func (s *server) URL() string {
+	return "amqp://guest:guest@" + s.listener.Addr().String() + "/"
+}
+
+// Publishings returns the messages received so far.
+func (s *server) Publishings() []publishing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]publishing(nil), s.publishings...)
+}
+
+// serve runs the connection handshake on conn and answers its frames until
+// it is closed.
+func (s *server) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+
+	if _, err := io.ReadFull(r, make([]byte, 8)); err != nil {
+		return
+	}
+
+	// connection.start: version 0-9, an empty server properties table and
+	// PLAIN authentication.
+	if s.writeMethod(conn, 0, classConnection, 10, []byte{0, 9}, long(0), longstr("PLAIN"), longstr("en_US")) != nil {
+		return
+	}
+
+	var pending *publishing
+
+	for {
+		typ, channel, payload, err := readFrame(r)
+		if err != nil {
+			return
+		}
+
+		switch typ {
+		case frameMethod:
+			pending, err = s.method(conn, channel, payload)
+			if err != nil {
+				return
+			}
+		case frameHeader:
+			parseProperties(pending, payload)
+		case frameBody:
+			if pending != nil {
+				pending.body = append(pending.body, payload...)
+			}
+		}
+
+		if pending != nil && (typ == frameHeader || typ == frameBody) && uint64(len(pending.body)) == pending.bodySize {
+			s.mu.Lock()
+			s.publishings = append(s.publishings, *pending)
+			s.mu.Unlock()
+
+			pending = nil
+		}
+	}
+}
+
+// method answers the method frame payload on channel, returning the
+// publishing whose content follows a basic.publish.
+func (s *server) method(conn net.Conn, channel uint16, payload []byte) (*publishing, error) {
+	class, method := binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:])
+	args := bytes.NewReader(payload[4:])
+
+	switch {
+	case class == classConnection && method == 11: // start-ok
+		// connection.tune: no channel limit, 128KiB frames, no heartbeats.
+		return nil, s.writeMethod(conn, 0, classConnection, 30, short(0), long(128*1024), short(0))
+	case class == classConnection && method == 40: // open
+		return nil, s.writeMethod(conn, 0, classConnection, 41, shortstr(""))
+	case class == classConnection && method == 50: // close
+		return nil, s.writeMethod(conn, 0, classConnection, 51)
+	case class == classChannel && method == 10: // open
+		return nil, s.writeMethod(conn, channel, classChannel, 11, longstr(""))
+	case class == classChannel && method == 40: // close
+		return nil, s.writeMethod(conn, channel, classChannel, 41)
+	case class == classQueue && method == 10: // declare
+		_, _ = args.Seek(2, io.SeekCurrent)
+
+		return nil, s.writeMethod(conn, channel, classQueue, 11,
+			shortstr(readShortstr(args)), long(s.queueDepth), long(0))
+	case class == classBasic && method == 40: // publish
+		_, _ = args.Seek(2, io.SeekCurrent)
+
+		return &publishing{exchange: readShortstr(args), key: readShortstr(args)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// writeMethod writes a method frame with the encoded args to conn.
+func (s *server) writeMethod(conn net.Conn, channel, class, method uint16, args ...[]byte) error {
+	payload := append(short(class), short(method)...)
+	for _, arg := range args {
+		payload = append(payload, arg...)
+	}
+
+	frame := append([]byte{frameMethod}, short(channel)...)
+	frame = append(frame, long(uint32(len(payload)))...) //nolint:gosec
+	frame = append(frame, payload...)
+	frame = append(frame, frameEnd)
+
+	_, err := conn.Write(frame)
+
+	return err
+}
+
+// readFrame reads the next frame from r.
+func readFrame(r io.Reader) (typ byte, channel uint16, payload []byte, err error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, err
+	}
+
+	payload = make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return header[0], binary.BigEndian.Uint16(header[1:]), payload[:len(payload)-1], nil
+}
+
+// parseProperties reads the body size, message ID and string headers of p
+// from a content header frame payload.
+func parseProperties(p *publishing, payload []byte) {
+	if p == nil {
+		return
+	}
+
+	r := bytes.NewReader(payload[4:])
+
+	var flags uint16
+
+	_ = binary.Read(r, binary.BigEndian, &p.bodySize)
+	_ = binary.Read(r, binary.BigEndian, &flags)
+
+	for bit := 15; bit >= 7; bit-- {
+		if flags&(1<<bit) == 0 {
+			continue
+		}
+
+		switch bit {
+		case 13: // headers
+			p.headers = readTable(r)
+		case 12, 11: // delivery mode, priority
+			_, _ = r.ReadByte()
+		case 7: // message ID
+			p.messageID = readShortstr(r)
+		default: // content type, encoding, correlation ID, reply to, expiration
+			readShortstr(r)
+		}
+	}
+}
+
+// readTable reads a field table holding long string values.
+func readTable(r *bytes.Reader) map[string]string {
+	var size uint32
+
+	_ = binary.Read(r, binary.BigEndian, &size)
+
+	data := make([]byte, size)
+	_, _ = io.ReadFull(r, data)
+
+	fields := bytes.NewReader(data)
+	values := make(map[string]string)
+
+	for fields.Len() > 0 {
+		name := readShortstr(fields)
+
+		if typ, _ := fields.ReadByte(); typ != 'S' {
+			break
+		}
+
+		var length uint32
+
+		_ = binary.Read(fields, binary.BigEndian, &length)
+
+		value := make([]byte, length)
+		_, _ = io.ReadFull(fields, value)
+		values[name] = string(value)
+	}
+
+	return values
+}
+
+func readShortstr(r *bytes.Reader) string {
+	length, _ := r.ReadByte()
+
+	value := make([]byte, length)
+	_, _ = io.ReadFull(r, value)
+
+	return string(value)
+}
+
+func short(v uint16) []byte { return binary.BigEndian.AppendUint16(nil, v) }
+func long(v uint32) []byte  { return binary.BigEndian.AppendUint32(nil, v) }
+
+func shortstr(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+func longstr(s string) []byte  { return append(long(uint32(len(s))), s...) } //nolint:gosec
@@ -0,0 +1,268 @@
+// Package silamqp instruments RabbitMQ publishers and consumers built on
+// amqp091-go with the providers configured by silgotel. It lives in its own
+// module so that services not using AMQP do not depend on the
+// instrumentation.
+package silamqp
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// scope names the tracer and meter of the integration.
+	scope = "github.com/savannahghi/sil-gotel/silamqp"
+
+	// OutcomeKey is the attribute recording whether a delivery was acked or
+	// nacked on the messaging.amqp.settlements counter.
+	OutcomeKey = attribute.Key("messaging.amqp.outcome")
+)
+
+// HeaderCarrier adapts the headers of an AMQP message to a
+// propagation.TextMapCarrier. Values that are not strings or byte slices are
+// ignored by Get.
+type HeaderCarrier amqp.Table
+
+var _ propagation.TextMapCarrier = HeaderCarrier{}
+
+// Get returns the value of the header named key.
+func (c HeaderCarrier) Get(key string) string {
+	switch value := c[key].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return ""
+	}
+}
+
+// Set stores value as the header named key.
+func (c HeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys returns the names of all headers.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Publisher wraps an amqp.Channel so that every message is published in a
+// producer span whose context is injected into the message headers.
+type Publisher struct {
+	channel    *amqp.Channel
+	tracer     otelTrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewPublisher returns a Publisher publishing on ch with the client's
+// providers.
+func NewPublisher(client *silgotel.Client, ch *amqp.Channel) *Publisher {
+	return &Publisher{
+		channel:    ch,
+		tracer:     client.Tracer(scope),
+		propagator: client.Propagator(),
+	}
+}
+
+// Publish publishes msg like amqp.Channel.PublishWithContext inside a
+// producer span named "<exchange> send", injecting the span context into
+// the message headers. The default exchange is named "amq.default".
+func (p *Publisher) Publish(
+	ctx context.Context,
+	exchange, key string,
+	mandatory, immediate bool,
+	msg amqp.Publishing,
+) error {
+	destination := exchange
+	if destination == "" {
+		destination = "amq.default"
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeSend,
+		semconv.MessagingOperationName("send"),
+		semconv.MessagingDestinationName(destination),
+		semconv.MessagingRabbitMQDestinationRoutingKey(key),
+		semconv.MessagingMessageBodySize(len(msg.Body)),
+	}
+
+	if msg.MessageId != "" {
+		attrs = append(attrs, semconv.MessagingMessageID(msg.MessageId))
+	}
+
+	ctx, span := p.tracer.Start(ctx, destination+" send",
+		otelTrace.WithSpanKind(otelTrace.SpanKindProducer),
+		otelTrace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	if msg.Headers == nil {
+		msg.Headers = amqp.Table{}
+	}
+
+	p.propagator.Inject(ctx, HeaderCarrier(msg.Headers))
+
+	err := p.channel.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+
+	return err
+}
+
+// Consumer handles deliveries from a queue in consumer spans continuing the
+// trace of their producer, settling each delivery with the outcome of the
+// handler.
+type Consumer struct {
+	queue       string
+	tracer      otelTrace.Tracer
+	propagator  propagation.TextMapPropagator
+	settlements otelMetric.Int64Counter
+}
+
+// NewConsumer returns a Consumer for deliveries from queue using the
+// client's providers. Every ack and nack is counted on
+// messaging.amqp.settlements under OutcomeKey.
+//
+//	consumer := silamqp.NewConsumer(otelClient, "orders")
+//	for d := range deliveries {
+//		_ = consumer.Handle(ctx, d, handle)
+//	}
+func NewConsumer(client *silgotel.Client, queue string) *Consumer {
+	settlements, err := client.Meter(scope).Int64Counter(
+		"messaging.amqp.settlements",
+		otelMetric.WithDescription("Deliveries acked or nacked by consumers"),
+		otelMetric.WithUnit("{message}"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("silamqp: failed to create metric instrument: %v", err))
+	}
+
+	return &Consumer{
+		queue:       queue,
+		tracer:      client.Tracer(scope),
+		propagator:  client.Propagator(),
+		settlements: settlements,
+	}
+}
+
+// Handle passes d to f inside a consumer span named "<queue> process" that
+// continues the trace found in the delivery headers and links to the
+// producer span. A nil error from f acks d; any other error is recorded on
+// the span and nacks d without requeueing, leaving redelivery to the
+// queue's dead letter policy. Deliveries consumed with auto-ack are not
+// settled. The handler error, or else the settlement error, is returned.
+func (c *Consumer) Handle(ctx context.Context, d amqp.Delivery, f func(context.Context, amqp.Delivery) error) error {
+	ctx = c.propagator.Extract(ctx, HeaderCarrier(d.Headers))
+
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeProcess,
+		semconv.MessagingOperationName("process"),
+		semconv.MessagingDestinationName(c.queue),
+		semconv.MessagingRabbitMQDestinationRoutingKey(d.RoutingKey),
+		semconv.MessagingRabbitMQMessageDeliveryTag(int(d.DeliveryTag)), //nolint:gosec
+		semconv.MessagingMessageBodySize(len(d.Body)),
+	}
+
+	if d.MessageId != "" {
+		attrs = append(attrs, semconv.MessagingMessageID(d.MessageId))
+	}
+
+	opts := []otelTrace.SpanStartOption{
+		otelTrace.WithSpanKind(otelTrace.SpanKindConsumer),
+		otelTrace.WithAttributes(attrs...),
+	}
+
+	if producer := otelTrace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: producer}))
+	}
+
+	ctx, span := c.tracer.Start(ctx, c.queue+" process", opts...)
+	defer span.End()
+
+	err := f(ctx, d)
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+
+	if d.Acknowledger == nil {
+		return err
+	}
+
+	outcome := "ack"
+
+	var settleErr error
+	if err == nil {
+		settleErr = d.Ack(false)
+	} else {
+		outcome = "nack"
+		settleErr = d.Nack(false, false)
+	}
+
+	c.settlements.Add(ctx, 1, otelMetric.WithAttributes(
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingDestinationName(c.queue),
+		OutcomeKey.String(outcome),
+	))
+
+	if err != nil {
+		return err
+	}
+
+	return settleErr
+}
+
+// RegisterQueueDepth reports the number of messages ready in queue as the
+// messaging.amqp.queue.depth gauge, inspecting the queue passively on ch at
+// every collection. Use a channel dedicated to the gauge as the broker closes
+// it when the queue does not exist, and unregister the gauge before closing
+// the channel. Failed inspections are reported to the OTel error handler.
+//
+//nolint:ireturn
+func RegisterQueueDepth(client *silgotel.Client, ch *amqp.Channel, queue string) (otelMetric.Registration, error) {
+	meter := client.Meter(scope)
+
+	depth, err := meter.Int64ObservableGauge(
+		"messaging.amqp.queue.depth",
+		otelMetric.WithDescription("Messages ready for delivery in the queue"),
+		otelMetric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating gauge messaging.amqp.queue.depth: %w", err)
+	}
+
+	attrs := otelMetric.WithAttributes(
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingDestinationName(queue),
+	)
+
+	return meter.RegisterCallback(func(_ context.Context, observer otelMetric.Observer) error {
+		state, err := ch.QueueDeclarePassive(queue, false, false, false, false, nil)
+		if err != nil {
+			otel.Handle(fmt.Errorf("silamqp: inspecting queue %s: %w", queue, err))
+
+			return nil
+		}
+
+		observer.ObserveInt64(depth, int64(state.Messages), attrs)
+
+		return nil
+	}, depth)
+}
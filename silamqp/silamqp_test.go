@@ -0,0 +1,330 @@
+package silamqp_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/savannahghi/sil-gotel/silamqp"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// acknowledger records how deliveries were settled.
+type acknowledger struct {
+	acks, nacks []uint64
+	requeued    bool
+}
+
+func (a *acknowledger) Ack(tag uint64, _ bool) error {
+	a.acks = append(a.acks, tag)
+
+	return nil
+}
+
+func (a *acknowledger) Nack(tag uint64, _, requeue bool) error {
+	a.nacks = append(a.nacks, tag)
+	a.requeued = requeue
+
+	return nil
+}
+
+func (a *acknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// openChannel dials s and opens a channel that is closed when the test
+// ends.
+func openChannel(t *testing.T, s *server) *amqp.Channel {
+	t.Helper()
+
+	conn, err := amqp.Dial(s.URL())
+	if err != nil {
+		t.Fatalf("amqp.Dial() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+
+	return ch
+}
+
+// received waits for s to receive its first publishing.
+func received(t *testing.T, s *server) publishing {
+	t.Helper()
+
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if publishings := s.Publishings(); len(publishings) > 0 {
+			return publishings[0]
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("the server received no publishing")
+
+	return publishing{}
+}
+
+// findSpan returns the ended span named name.
+func findSpan(t *testing.T, sdk *siltest.SDK, name string) trace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range sdk.Spans() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("no %q span was recorded", name)
+
+	return nil
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	carrier := silamqp.HeaderCarrier(amqp.Table{
+		"tenant":  "clinic-a",
+		"baggage": []byte("plan=gold"),
+		"retries": int32(3),
+	})
+
+	for key, want := range map[string]string{
+		"tenant":  "clinic-a",
+		"baggage": "plan=gold",
+		"retries": "",
+		"missing": "",
+	} {
+		if got := carrier.Get(key); got != want {
+			t.Errorf("Get(%s) = %q, want %q", key, got, want)
+		}
+	}
+
+	carrier.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	keys := carrier.Keys()
+	slices.Sort(keys)
+
+	if !slices.Equal(keys, []string{"baggage", "retries", "tenant", "traceparent"}) {
+		t.Errorf("Keys() = %v, want every header", keys)
+	}
+}
+
+func TestPublisherAndConsumer(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	s := newServer(t, 0)
+	publisher := silamqp.NewPublisher(client, openChannel(t, s))
+
+	ctx, parent := client.Tracer("orders").Start(t.Context(), "POST /orders")
+
+	err := publisher.Publish(ctx, "", "orders", false, false, amqp.Publishing{
+		MessageId: "order-42",
+		Headers:   amqp.Table{"tenant": "clinic-a"},
+		Body:      []byte(`{"total":10}`),
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	parent.End()
+
+	msg := received(t, s)
+	if msg.headers["traceparent"] == "" || msg.headers["tenant"] != "clinic-a" {
+		t.Errorf("published headers = %v, want traceparent added to the existing headers", msg.headers)
+	}
+
+	send := findSpan(t, sdk, "amq.default send")
+	if send.SpanKind() != otelTrace.SpanKindProducer || send.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("send span kind = %v with parent %s, want a producer child of the request",
+			send.SpanKind(), send.Parent().SpanID())
+	}
+
+	for key, want := range map[string]string{
+		"messaging.system":                           "rabbitmq",
+		"messaging.destination.name":                 "amq.default",
+		"messaging.rabbitmq.destination.routing_key": "orders",
+		"messaging.message.id":                       "order-42",
+		"messaging.message.body.size":                "12",
+	} {
+		if got := attrs(send)[key]; got != want {
+			t.Errorf("send %s = %q, want %q", key, got, want)
+		}
+	}
+
+	headers := amqp.Table{}
+	for key, value := range msg.headers {
+		headers[key] = value
+	}
+
+	ack := &acknowledger{}
+	delivery := amqp.Delivery{
+		Acknowledger: ack,
+		Headers:      headers,
+		DeliveryTag:  7,
+		RoutingKey:   msg.key,
+		MessageId:    msg.messageID,
+		Body:         msg.body,
+	}
+
+	var handled otelTrace.SpanContext
+
+	err = silamqp.NewConsumer(client, "orders").Handle(t.Context(), delivery,
+		func(ctx context.Context, _ amqp.Delivery) error {
+			handled = otelTrace.SpanContextFromContext(ctx)
+
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	process := findSpan(t, sdk, "orders process")
+	if process.SpanContext().SpanID() != handled.SpanID() {
+		t.Errorf("handler span = %s, want the process span", handled.SpanID())
+	}
+
+	if process.Parent().SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span parent = %s, want the send span", process.Parent().SpanID())
+	}
+
+	if links := process.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span links = %v, want the send span", links)
+	}
+
+	if tag := attrs(process)["messaging.rabbitmq.message.delivery_tag"]; tag != "7" {
+		t.Errorf("process delivery tag = %q, want 7", tag)
+	}
+
+	if !slices.Equal(ack.acks, []uint64{7}) || len(ack.nacks) != 0 {
+		t.Errorf("acks, nacks = %v, %v, want the delivery acked", ack.acks, ack.nacks)
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestPublisherRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	ch := openChannel(t, newServer(t, 0))
+	publisher := silamqp.NewPublisher(client, ch)
+
+	if err := ch.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	err := publisher.Publish(t.Context(), "orders", "new", false, false, amqp.Publishing{Body: []byte("{}")})
+	if !errors.Is(err, amqp.ErrClosed) {
+		t.Fatalf("Publish() error = %v, want amqp.ErrClosed", err)
+	}
+
+	span := findSpan(t, sdk, "orders send")
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the publish error recorded", events)
+	}
+}
+
+func TestConsumerSettlements(t *testing.T) {
+	errInvalid := errors.New("invalid order")
+
+	tests := map[string]struct {
+		handleErr error
+		outcome   string
+		status    codes.Code
+	}{
+		"ack":  {outcome: "ack", status: codes.Unset},
+		"nack": {handleErr: errInvalid, outcome: "nack", status: codes.Error},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, sdk := siltest.NewTestClient(t)
+			ack := &acknowledger{}
+
+			err := silamqp.NewConsumer(client, "orders").Handle(t.Context(),
+				amqp.Delivery{Acknowledger: ack, DeliveryTag: 1},
+				func(context.Context, amqp.Delivery) error { return tt.handleErr })
+			if !errors.Is(err, tt.handleErr) {
+				t.Fatalf("Handle() error = %v, want %v", err, tt.handleErr)
+			}
+
+			if span := findSpan(t, sdk, "orders process"); span.Status().Code != tt.status {
+				t.Errorf("span status = %v, want %v", span.Status(), tt.status)
+			}
+
+			if ack.requeued {
+				t.Error("the delivery was requeued, want it left to the dead letter policy")
+			}
+
+			m, ok := sdk.Metric("messaging.amqp.settlements")
+			if !ok {
+				t.Fatal("messaging.amqp.settlements was not recorded")
+			}
+
+			points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+			if len(points) != 1 {
+				t.Fatalf("messaging.amqp.settlements has %d points, want 1", len(points))
+			}
+
+			if outcome, _ := points[0].Attributes.Value(silamqp.OutcomeKey); outcome.AsString() != tt.outcome {
+				t.Errorf("%s = %q, want %q", silamqp.OutcomeKey, outcome.AsString(), tt.outcome)
+			}
+		})
+	}
+}
+
+func TestConsumerAutoAck(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	err := silamqp.NewConsumer(client, "orders").Handle(t.Context(), amqp.Delivery{},
+		func(context.Context, amqp.Delivery) error { return nil })
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if _, ok := sdk.Metric("messaging.amqp.settlements"); ok {
+		t.Error("messaging.amqp.settlements was recorded for an auto-acked delivery")
+	}
+}
+
+func TestRegisterQueueDepth(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	registration, err := silamqp.RegisterQueueDepth(client, openChannel(t, newServer(t, 5)), "orders")
+	if err != nil {
+		t.Fatalf("RegisterQueueDepth() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = registration.Unregister() })
+
+	m, ok := sdk.Metric("messaging.amqp.queue.depth")
+	if !ok {
+		t.Fatal("messaging.amqp.queue.depth was not recorded")
+	}
+
+	points := m.Data.(metricdata.Gauge[int64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 || points[0].Value != 5 {
+		t.Errorf("messaging.amqp.queue.depth points = %v, want the 5 ready messages", points)
+	}
+}
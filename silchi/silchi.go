@@ -0,0 +1,41 @@
+// Package silchi instruments chi routers with the providers configured by
+// silgotel. It lives in its own module so that services not using chi do not
+// depend on the instrumentation.
+package silchi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Middleware traces every request as a server span named after the chi
+// route pattern, such as "GET /patients/{id}", so path parameters never
+// reach span names, and records the per-route RED metrics of
+// silgotel.Client.MetricsMiddleware. It is meant for the root router:
+//
+//	router.Use(silchi.Middleware(otelClient))
+//
+// opts are passed on to silgotel.Client.HTTPMiddleware.
+func Middleware(client *silgotel.Client, opts ...otelhttp.Option) func(http.Handler) http.Handler {
+	opts = append([]otelhttp.Option{otelhttp.WithSpanNameFormatter(spanName)}, opts...)
+
+	return func(next http.Handler) http.Handler {
+		return client.HTTPMiddleware(client.MetricsMiddleware(next), opts...)
+	}
+}
+
+// spanName names a span after the method and the chi route pattern, which
+// is only known once the router has matched the request; otelhttp renames
+// the span when it is.
+func spanName(_ string, r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return r.Method + " " + pattern
+		}
+	}
+
+	return r.Method
+}
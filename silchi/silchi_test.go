@@ -0,0 +1,122 @@
+package silchi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silchi"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newRouter returns a chi router instrumented by Middleware with a patient
+// route, a failing route and a health check.
+func newRouter(client *silgotel.Client) http.Handler {
+	router := chi.NewRouter()
+	router.Use(silchi.Middleware(client))
+
+	router.Get("/patients/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Get("/visits/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "visits database unavailable", http.StatusInternalServerError)
+	})
+	router.Get("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return router
+}
+
+// serve sends a GET request for path through router.
+func serve(router http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestMiddleware(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	serve(newRouter(client), "/patients/42")
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	if spans[0].Name() != "GET /patients/{id}" {
+		t.Errorf("span name = %q, want the route pattern", spans[0].Name())
+	}
+
+	if status := attrs(spans[0])["http.response.status_code"]; status != "200" {
+		t.Errorf("http.response.status_code = %q, want 200", status)
+	}
+
+	m, ok := sdk.Metric("http.server.request.count")
+	if !ok {
+		t.Fatal("http.server.request.count was not recorded")
+	}
+
+	points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 {
+		t.Fatalf("http.server.request.count has %d points, want 1", len(points))
+	}
+
+	if route, _ := points[0].Attributes.Value("http.route"); route.AsString() != "/patients/{id}" {
+		t.Errorf("http.route = %q, want the route pattern", route.AsString())
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestMiddlewareRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	if rec := serve(newRouter(client), "/visits/7"); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("GET /visits/7 = %d, want 500", rec.Code)
+	}
+
+	span := sdk.Spans()[0]
+
+	if span.Name() != "GET /visits/{id}" || attrs(span)["http.response.status_code"] != "500" {
+		t.Errorf("span %q status code = %q, want GET /visits/{id} with 500",
+			span.Name(), attrs(span)["http.response.status_code"])
+	}
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error for a 500", span.Status())
+	}
+}
+
+func TestMiddlewareIgnoredPaths(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t, silgotel.WithIgnoredPaths("/healthz"))
+
+	if rec := serve(newRouter(client), "/healthz"); rec.Code != http.StatusOK {
+		t.Fatalf("GET /healthz = %d, want the handler's 200", rec.Code)
+	}
+
+	if spans := sdk.Spans(); len(spans) != 0 {
+		t.Errorf("recorded %d spans for an ignored path, want none", len(spans))
+	}
+
+	if _, ok := sdk.Metric("http.server.request.count"); ok {
+		t.Error("http.server.request.count was recorded for an ignored path")
+	}
+}
@@ -0,0 +1,95 @@
+// Package silecho instruments Echo servers with the providers configured by
+// silgotel. It lives in its own module so that services not using Echo do not
+// depend on the instrumentation.
+package silecho
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	silgotel "github.com/savannahghi/sil-gotel"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// scope names the tracer and meter of the middleware.
+const scope = "github.com/savannahghi/sil-gotel/silecho"
+
+// Middleware traces every request as a server span named after its route,
+// such as "GET /users/:id", continuing the trace context of the incoming
+// headers. An error returned by the handler is recorded on the span and
+// passed to Echo's error handler before being returned. The request
+// duration is recorded in seconds on http.server.request.duration, the
+// histogram bucketed by silgotel.WithHTTPViews. Requests ignored through
+// silgotel.WithIgnoredPaths pass straight through.
+//
+//	e.Use(silecho.Middleware(otelClient))
+func Middleware(client *silgotel.Client) echo.MiddlewareFunc {
+	tracer := client.Tracer(scope)
+	propagator := client.Propagator()
+
+	duration, err := client.Meter(scope).Float64Histogram(
+		"http.server.request.duration",
+		otelMetric.WithDescription("Duration of HTTP server requests"),
+		otelMetric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("silecho: failed to create metric instrument: %v", err))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if client.IgnoresRequest(req) {
+				return next(c)
+			}
+
+			route := c.Path()
+
+			name := req.Method
+			if route != "" {
+				name += " " + route
+			}
+
+			ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, name,
+				otelTrace.WithSpanKind(otelTrace.SpanKindServer),
+				otelTrace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				silgotel.CaptureTraceStatusAndError(span, err)
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+			if err == nil {
+				silgotel.SetSpanStatusFromHTTPStatus(span, otelTrace.SpanKindServer, status)
+			}
+
+			duration.Record(ctx, time.Since(start).Seconds(), otelMetric.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(route),
+				semconv.HTTPResponseStatusCode(status),
+			))
+
+			return err
+		}
+	}
+}
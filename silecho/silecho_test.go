@@ -0,0 +1,146 @@
+package silecho_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silecho"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newServer returns an Echo server instrumented by Middleware with a user
+// route, failing routes and a health check.
+func newServer(client *silgotel.Client) *echo.Echo {
+	e := echo.New()
+	e.Use(silecho.Middleware(client))
+
+	e.GET("/users/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/orders/:id", func(echo.Context) error {
+		return errors.New("orders database unavailable")
+	})
+	e.GET("/invoices/:id", func(echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "no such invoice")
+	})
+	e.GET("/healthz", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	return e
+}
+
+// serve sends a GET request for path through e.
+func serve(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestMiddleware(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	serve(newServer(client), "/users/42")
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /users/:id" {
+		t.Errorf("span name = %q, want the route template", span.Name())
+	}
+
+	if got := attrs(span); got["http.response.status_code"] != "200" || got["http.route"] != "/users/:id" {
+		t.Errorf("status code, route = %q, %q, want 200 and /users/:id", got["http.response.status_code"], got["http.route"])
+	}
+
+	if span.Status().Code != codes.Unset {
+		t.Errorf("span status = %v, want unset for a 200", span.Status())
+	}
+
+	m, ok := sdk.Metric("http.server.request.duration")
+	if !ok {
+		t.Fatal("http.server.request.duration was not recorded")
+	}
+
+	points := m.Data.(metricdata.Histogram[float64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 || points[0].Count != 1 {
+		t.Fatalf("http.server.request.duration points = %v, want one request", points)
+	}
+
+	if route, _ := points[0].Attributes.Value("http.route"); route.AsString() != "/users/:id" {
+		t.Errorf("duration http.route = %q, want /users/:id", route.AsString())
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestMiddlewareRecordsErrors(t *testing.T) {
+	tests := map[string]struct {
+		path   string
+		route  string
+		status int
+	}{
+		"handler error": {path: "/orders/7", route: "GET /orders/:id", status: http.StatusInternalServerError},
+		"http error":    {path: "/invoices/9", route: "GET /invoices/:id", status: http.StatusNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, sdk := siltest.NewTestClient(t)
+
+			// The middleware hands the error to Echo's error handler, so the
+			// status it reads back is the one the client receives.
+			if rec := serve(newServer(client), tt.path); rec.Code != tt.status {
+				t.Fatalf("GET %s = %d, want %d", tt.path, rec.Code, tt.status)
+			}
+
+			span := sdk.Spans()[0]
+
+			if status := attrs(span)["http.response.status_code"]; span.Name() != tt.route || status != strconv.Itoa(tt.status) {
+				t.Errorf("span %q status code = %q, want %s with %d", span.Name(), status, tt.route, tt.status)
+			}
+
+			if span.Status().Code != codes.Error {
+				t.Errorf("span status = %v, want error", span.Status())
+			}
+
+			if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+				t.Errorf("span events = %v, want the handler's error recorded", events)
+			}
+		})
+	}
+}
+
+func TestMiddlewareIgnoredPaths(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t, silgotel.WithIgnoredPaths("/healthz"))
+
+	if rec := serve(newServer(client), "/healthz"); rec.Code != http.StatusOK {
+		t.Fatalf("GET /healthz = %d, want the handler's 200", rec.Code)
+	}
+
+	if spans := sdk.Spans(); len(spans) != 0 {
+		t.Errorf("recorded %d spans for an ignored path, want none", len(spans))
+	}
+}
@@ -0,0 +1,152 @@
+// Package silfiber instruments Fiber apps with the providers configured by
+// silgotel. Fiber runs on fasthttp, which the net/http instrumentation cannot
+// wrap, so the middleware builds its spans and metrics itself. It lives in
+// its own module so that services not using Fiber do not depend on the
+// instrumentation.
+package silfiber
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// scope names the tracer and meter of the middleware.
+const scope = "github.com/savannahghi/sil-gotel/silfiber"
+
+type metrics struct {
+	duration     otelMetric.Float64Histogram
+	requestSize  otelMetric.Int64Histogram
+	responseSize otelMetric.Int64Histogram
+}
+
+func newMetrics(meter otelMetric.Meter) *metrics {
+	return &metrics{
+		duration: mustInstrument(meter.Float64Histogram(
+			"http.server.request.duration",
+			otelMetric.WithDescription("Duration of HTTP server requests"),
+			otelMetric.WithUnit("s"),
+		)),
+		requestSize: mustInstrument(meter.Int64Histogram(
+			"http.server.request.body.size",
+			otelMetric.WithDescription("Size of HTTP server request bodies"),
+			otelMetric.WithUnit("By"),
+		)),
+		responseSize: mustInstrument(meter.Int64Histogram(
+			"http.server.response.body.size",
+			otelMetric.WithDescription("Size of HTTP server response bodies"),
+			otelMetric.WithUnit("By"),
+		)),
+	}
+}
+
+func mustInstrument[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Sprintf("silfiber: failed to create metric instrument: %v", err))
+	}
+
+	return v
+}
+
+// Middleware traces every request as a server span named after its route,
+// such as "GET /users/:id", continuing the trace context of the incoming
+// headers, and records the request duration and the request and response
+// body sizes under the same names as the net/http middleware. The span's
+// context is available to handlers through c.UserContext(). An error
+// returned by a handler is recorded on the span and passed to the app's
+// error handler, so the recorded status matches the response. Requests
+// ignored through silgotel.WithIgnoredPaths pass straight through.
+//
+//	app.Use(silfiber.Middleware(otelClient))
+func Middleware(client *silgotel.Client) fiber.Handler {
+	tracer := client.Tracer(scope)
+	propagator := client.Propagator()
+	m := newMetrics(client.Meter(scope))
+
+	return func(c *fiber.Ctx) error {
+		var req http.Request
+
+		err := fasthttpadaptor.ConvertRequest(c.Context(), &req, true)
+		if err == nil && client.IgnoresRequest(&req) {
+			return c.Next()
+		}
+
+		method := c.Method()
+		start := time.Now()
+
+		ctx := propagator.Extract(c.UserContext(), headerCarrier{header: &c.Request().Header})
+		ctx, span := tracer.Start(ctx, method,
+			otelTrace.WithSpanKind(otelTrace.SpanKindServer),
+			otelTrace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(c.Path()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		err = c.Next()
+		if err != nil {
+			silgotel.CaptureTraceStatusAndError(span, err)
+
+			_ = c.App().Config().ErrorHandler(c, err)
+		}
+
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+
+		span.SetName(method + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+
+		if err == nil {
+			silgotel.SetSpanStatusFromHTTPStatus(span, otelTrace.SpanKindServer, status)
+		}
+
+		attrs := otelMetric.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+
+		m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+		m.requestSize.Record(ctx, int64(len(c.Request().Body())), attrs)
+		m.responseSize.Record(ctx, int64(len(c.Response().Body())), attrs)
+
+		return err
+	}
+}
+
+// headerCarrier adapts fasthttp request headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (h headerCarrier) Get(key string) string {
+	return string(h.header.Peek(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+
+	h.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
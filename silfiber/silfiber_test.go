@@ -0,0 +1,164 @@
+package silfiber_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silfiber"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// traceparent is an incoming W3C trace context header.
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newApp returns a Fiber app instrumented by Middleware with a user route,
+// failing routes and a health check.
+func newApp(client *silgotel.Client) *fiber.App {
+	app := fiber.New()
+	app.Use(silfiber.Middleware(client))
+
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		sc := otelTrace.SpanContextFromContext(c.UserContext())
+
+		return c.SendString(sc.TraceID().String())
+	})
+	app.Get("/orders/:id", func(*fiber.Ctx) error {
+		return errors.New("orders database unavailable")
+	})
+	app.Get("/invoices/:id", func(*fiber.Ctx) error {
+		return fiber.NewError(http.StatusNotFound, "no such invoice")
+	})
+	app.Get("/healthz", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	return app
+}
+
+// serve sends a GET request for path through app and returns the status.
+func serve(t *testing.T, app *fiber.App, path string, header http.Header) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", path, err)
+	}
+
+	_ = resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestMiddleware(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	serve(t, newApp(client), "/users/42", http.Header{"Traceparent": {traceparent}})
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /users/:id" {
+		t.Errorf("span name = %q, want the route template", span.Name())
+	}
+
+	if got := attrs(span); got["http.response.status_code"] != "200" || got["http.route"] != "/users/:id" {
+		t.Errorf("status code, route = %q, %q, want 200 and /users/:id", got["http.response.status_code"], got["http.route"])
+	}
+
+	if traceID := span.SpanContext().TraceID().String(); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, want the incoming traceparent continued", traceID)
+	}
+
+	for _, name := range []string{
+		"http.server.request.duration", "http.server.request.body.size", "http.server.response.body.size",
+	} {
+		if _, ok := sdk.Metric(name); !ok {
+			t.Errorf("%s was not recorded", name)
+		}
+	}
+
+	m, _ := sdk.Metric("http.server.response.body.size")
+
+	points := m.Data.(metricdata.Histogram[int64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 || points[0].Sum != 32 {
+		t.Errorf("http.server.response.body.size points = %v, want the 32 byte trace ID", points)
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestMiddlewareRecordsErrors(t *testing.T) {
+	tests := map[string]struct {
+		path   string
+		route  string
+		status int
+	}{
+		"handler error": {path: "/orders/7", route: "GET /orders/:id", status: http.StatusInternalServerError},
+		"fiber error":   {path: "/invoices/9", route: "GET /invoices/:id", status: http.StatusNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, sdk := siltest.NewTestClient(t)
+
+			if code := serve(t, newApp(client), tt.path, nil); code != tt.status {
+				t.Fatalf("GET %s = %d, want %d", tt.path, code, tt.status)
+			}
+
+			span := sdk.Spans()[0]
+
+			// The error handler runs before the status is read back, so the
+			// span records the status the client received.
+			if status := attrs(span)["http.response.status_code"]; span.Name() != tt.route || status != strconv.Itoa(tt.status) {
+				t.Errorf("span %q status code = %q, want %s with %d", span.Name(), status, tt.route, tt.status)
+			}
+
+			if span.Status().Code != codes.Error {
+				t.Errorf("span status = %v, want error", span.Status())
+			}
+
+			if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+				t.Errorf("span events = %v, want the handler's error recorded", events)
+			}
+		})
+	}
+}
+
+func TestMiddlewareIgnoredPaths(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t, silgotel.WithIgnoredPaths("/healthz"))
+
+	if code := serve(t, newApp(client), "/healthz", nil); code != http.StatusOK {
+		t.Fatalf("GET /healthz = %d, want the handler's 200", code)
+	}
+
+	if spans := sdk.Spans(); len(spans) != 0 {
+		t.Errorf("recorded %d spans for an ignored path, want none", len(spans))
+	}
+}
@@ -0,0 +1,66 @@
+// Package silgin instruments Gin engines with the providers configured by
+// silgotel. It lives in its own module so that services not using Gin do not
+// depend on the instrumentation.
+package silgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// Middleware returns the handlers that trace every request as a server span
+// named after its route template, such as "GET /users/:id", record the
+// http.server.request.duration histogram, and store the trace ID in the Gin
+// context for TraceID. Requests ignored through silgotel.WithIgnoredPaths
+// pass straight through. opts are applied after the package's defaults.
+//
+//	router.Use(silgin.Middleware(otelClient)...)
+func Middleware(client *silgotel.Client, opts ...otelgin.Option) gin.HandlersChain {
+	defaults := []otelgin.Option{
+		otelgin.WithTracerProvider(client.TracerProvider()),
+		otelgin.WithMeterProvider(client.MeterProvider()),
+		otelgin.WithPropagators(client.Propagator()),
+		otelgin.WithSpanNameFormatter(spanName),
+		otelgin.WithFilter(func(r *http.Request) bool {
+			return !client.IgnoresRequest(r)
+		}),
+	}
+
+	return gin.HandlersChain{
+		otelgin.Middleware(client.ServiceName, append(defaults, opts...)...),
+		traceIDs,
+	}
+}
+
+// TraceID returns the trace ID Middleware stored for the request, or an
+// empty string when the request is not traced.
+func TraceID(c *gin.Context) string {
+	return c.GetString(silgotel.TraceIDKey)
+}
+
+// traceIDs stores the request's trace ID in the Gin context and returns it
+// in the X-Trace-Id response header.
+func traceIDs(c *gin.Context) {
+	sc := otelTrace.SpanContextFromContext(c.Request.Context())
+	if sc.IsValid() {
+		c.Set(silgotel.TraceIDKey, sc.TraceID().String())
+		c.Header(silgotel.TraceIDHeader, sc.TraceID().String())
+	}
+
+	c.Next()
+}
+
+// spanName names a span after the method and route template, keeping raw
+// paths out of span names.
+func spanName(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		return c.Request.Method
+	}
+
+	return c.Request.Method + " " + route
+}
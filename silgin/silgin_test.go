@@ -0,0 +1,135 @@
+package silgin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silgin"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newRouter returns a Gin engine instrumented by Middleware with a user
+// route, a failing route and a health check.
+func newRouter(client *silgotel.Client) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(silgin.Middleware(client)...)
+
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, silgin.TraceID(c))
+	})
+	router.GET("/orders/:id", func(c *gin.Context) {
+		_ = c.Error(errors.New("orders database unavailable"))
+		c.Status(http.StatusInternalServerError)
+	})
+	router.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+// serve sends a GET request for path through router.
+func serve(router http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestMiddleware(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	router := newRouter(client)
+
+	rec := serve(router, "/users/42")
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /users/:id" {
+		t.Errorf("span name = %q, want the route template", span.Name())
+	}
+
+	if status := attrs(span)["http.response.status_code"]; status != "200" {
+		t.Errorf("http.response.status_code = %q, want 200", status)
+	}
+
+	traceID := span.SpanContext().TraceID().String()
+	if rec.Body.String() != traceID || rec.Header().Get(silgotel.TraceIDHeader) != traceID {
+		t.Errorf("TraceID() = %q and %s = %q, want the span's %s",
+			rec.Body.String(), silgotel.TraceIDHeader, rec.Header().Get(silgotel.TraceIDHeader), traceID)
+	}
+
+	m, ok := sdk.Metric("http.server.request.duration")
+	if !ok {
+		t.Fatal("http.server.request.duration was not recorded")
+	}
+
+	points := m.Data.(metricdata.Histogram[float64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 || points[0].Count != 1 {
+		t.Errorf("http.server.request.duration points = %v, want one request", points)
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestMiddlewareRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	if rec := serve(newRouter(client), "/orders/7"); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("GET /orders/7 = %d, want 500", rec.Code)
+	}
+
+	span := sdk.Spans()[0]
+
+	if span.Name() != "GET /orders/:id" || attrs(span)["http.response.status_code"] != "500" {
+		t.Errorf("span %q status code = %q, want GET /orders/:id with 500",
+			span.Name(), attrs(span)["http.response.status_code"])
+	}
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the handler's error recorded", events)
+	}
+}
+
+func TestMiddlewareIgnoredPaths(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t, silgotel.WithIgnoredPaths("/healthz"))
+
+	rec := serve(newRouter(client), "/healthz")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /healthz = %d, want the handler's 200", rec.Code)
+	}
+
+	if spans := sdk.Spans(); len(spans) != 0 {
+		t.Errorf("recorded %d spans for an ignored path, want none", len(spans))
+	}
+
+	if rec.Header().Get(silgotel.TraceIDHeader) != "" {
+		t.Errorf("%s = %q on an ignored path, want none", silgotel.TraceIDHeader, rec.Header().Get(silgotel.TraceIDHeader))
+	}
+}
@@ -0,0 +1,151 @@
+// Package silgorm instruments GORM with the providers configured by silgotel.
+// It lives in its own module so that services not using GORM do not depend on
+// the instrumentation.
+package silgorm
+
+import (
+	"errors"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// scope names the tracer of the plugin.
+	scope = "github.com/savannahghi/sil-gotel/silgorm"
+
+	// spanKey is the statement instance key the in-flight span is kept under
+	// between the before and after callbacks.
+	spanKey = "silgorm:span"
+
+	// dbSystemKey is the db.system span attribute, matching silsql.
+	dbSystemKey = attribute.Key("db.system")
+
+	// RowsAffectedKey is the span attribute holding the number of rows a
+	// statement returned or changed.
+	RowsAffectedKey = attribute.Key("db.rows_affected")
+)
+
+// dialectorSystems maps GORM dialector names to their db.system value.
+//
+//nolint:gochecknoglobals
+var dialectorSystems = map[string]string{
+	"postgres":  "postgresql",
+	"sqlserver": "mssql",
+}
+
+type plugin struct {
+	tracer otelTrace.Tracer
+}
+
+// Plugin returns a GORM plugin that traces every Create, Query, Update,
+// Delete, Row and Raw operation as a client span named after the operation
+// and table, such as "gorm.Query users". Spans carry db.system, the table,
+// the statement sanitized by silgotel.SanitizeQuery and the number of rows
+// affected, and become children of the
+// span in the context passed through db.WithContext. gorm.ErrRecordNotFound
+// is not treated as a failure.
+//
+//	if err := db.Use(silgorm.Plugin(otelClient)); err != nil {
+//		return err
+//	}
+func Plugin(client *silgotel.Client) gorm.Plugin {
+	return &plugin{tracer: client.Tracer(scope)}
+}
+
+// Name implements gorm.Plugin.
+func (p *plugin) Name() string {
+	return "silgotel"
+}
+
+// Initialize implements gorm.Plugin by registering the tracing callbacks
+// around every operation processor.
+func (p *plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	for _, step := range []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"Create", callbacks.Create().Before("*").Register, callbacks.Create().After("*").Register},
+		{"Query", callbacks.Query().Before("*").Register, callbacks.Query().After("*").Register},
+		{"Update", callbacks.Update().Before("*").Register, callbacks.Update().After("*").Register},
+		{"Delete", callbacks.Delete().Before("*").Register, callbacks.Delete().After("*").Register},
+		{"Row", callbacks.Row().Before("*").Register, callbacks.Row().After("*").Register},
+		{"Raw", callbacks.Raw().Before("*").Register, callbacks.Raw().After("*").Register},
+	} {
+		err := step.before("silgotel:before_"+step.operation, p.before(step.operation))
+		if err != nil {
+			return err
+		}
+
+		err = step.after("silgotel:after_"+step.operation, p.after(step.operation))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *plugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		system := db.Name()
+		if mapped, ok := dialectorSystems[system]; ok {
+			system = mapped
+		}
+
+		ctx, span := p.tracer.Start(db.Statement.Context, spanName(operation, db), //nolint:spancheck
+			otelTrace.WithSpanKind(otelTrace.SpanKindClient),
+			otelTrace.WithAttributes(
+				dbSystemKey.String(system),
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(db.Statement.Table),
+			),
+		)
+
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func (p *plugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+
+		span, ok := value.(otelTrace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		// The table is only resolved once the statement is parsed, which
+		// happens after the before callbacks have run for model based calls.
+		span.SetName(spanName(operation, db))
+		span.SetAttributes(
+			semconv.DBCollectionName(db.Statement.Table),
+			semconv.DBQueryText(silgotel.SanitizeQuery(db.Statement.SQL.String())),
+			RowsAffectedKey.Int64(db.RowsAffected),
+		)
+
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			silgotel.CaptureTraceStatusAndError(span, db.Error)
+		}
+	}
+}
+
+func spanName(operation string, db *gorm.DB) string {
+	name := "gorm." + operation
+	if db.Statement.Table != "" {
+		name += " " + db.Statement.Table
+	}
+
+	return name
+}
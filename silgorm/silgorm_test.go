@@ -0,0 +1,205 @@
+package silgorm_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silgorm"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils/tests"
+)
+
+// errUnavailable is returned by fakeConn for statements on the broken table.
+var errUnavailable = errors.New("database unavailable")
+
+// fakeDriver is a database/sql driver answering queries on the users table
+// with one row, queries on archived_users with none, failing every
+// statement on broken and reporting one affected row for the rest.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.Contains(query, "broken") {
+		return nil, errUnavailable
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	switch {
+	case strings.Contains(query, "broken"):
+		return nil, errUnavailable
+	case strings.Contains(query, "archived_users"):
+		return &fakeRows{done: true}, nil
+	default:
+		return &fakeRows{}, nil
+	}
+}
+
+type fakeRows struct{ done bool }
+
+func (*fakeRows) Columns() []string { return []string{"id", "email"} }
+func (*fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = int64(1)
+	dest[1] = "jane@example.com"
+
+	return nil
+}
+
+func init() {
+	sql.Register("silgormfake", fakeDriver{})
+}
+
+// postgresDialector is GORM's dummy dialector named like the postgres one.
+type postgresDialector struct{ tests.DummyDialector }
+
+func (postgresDialector) Name() string { return "postgres" }
+
+type User struct {
+	ID    uint
+	Email string
+}
+
+// openDB opens a GORM database on the fake driver with the plugin
+// installed.
+func openDB(t *testing.T, client *silgotel.Client) *gorm.DB {
+	t.Helper()
+
+	conn, err := sql.Open("silgormfake", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	db, err := gorm.Open(postgresDialector{}, &gorm.Config{
+		ConnPool:               conn,
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	if err := db.Use(silgorm.Plugin(client)); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+
+	return db
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestPlugin(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	db := openDB(t, client)
+
+	ctx, parent := client.Tracer("users").Start(t.Context(), "GET /users")
+
+	var users []User
+	if err := db.WithContext(ctx).Where("email = ?", "jane@example.com").Find(&users).Error; err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	parent.End()
+
+	if len(users) != 1 {
+		t.Fatalf("found %d users, want 1", len(users))
+	}
+
+	span := sdk.Spans()[0]
+	if span.Name() != "gorm.Query users" {
+		t.Errorf("span name = %q, want the operation and table", span.Name())
+	}
+
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span parent = %s, want the request span", span.Parent().SpanID())
+	}
+
+	got := attrs(span)
+	for key, want := range map[string]string{
+		"db.system":          "postgresql",
+		"db.operation.name":  "Query",
+		"db.collection.name": "users",
+		"db.rows_affected":   "1",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %q, want %q", key, got[key], want)
+		}
+	}
+
+	if query := got["db.query.text"]; !strings.HasPrefix(query, "SELECT") || strings.Contains(query, "jane") {
+		t.Errorf("db.query.text = %q, want the sanitized statement", query)
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestPluginRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	db := openDB(t, client)
+
+	err := db.WithContext(t.Context()).Table("broken").Where("id = ?", 1).Update("email", "x").Error
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("Update() error = %v, want the driver's", err)
+	}
+
+	span := sdk.Spans()[0]
+	if span.Name() != "gorm.Update broken" || span.Status().Code != codes.Error {
+		t.Errorf("span %q status = %v, want gorm.Update broken failed", span.Name(), span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the error recorded", events)
+	}
+}
+
+func TestPluginRecordNotFound(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	db := openDB(t, client)
+
+	var user User
+
+	err := db.WithContext(t.Context()).Table("archived_users").First(&user).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("First() error = %v, want gorm.ErrRecordNotFound", err)
+	}
+
+	span := sdk.Spans()[0]
+	if span.Status().Code != codes.Unset || len(span.Events()) != 0 {
+		t.Errorf("span status = %v with events %v, want a missing record left unrecorded", span.Status(), span.Events())
+	}
+}
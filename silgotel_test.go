@@ -0,0 +1,273 @@
+package silgotel_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// newCollector starts a fake OTLP/HTTP collector accepting every export.
+func newCollector(t testing.TB) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// recordingCollector is a fake OTLP/HTTP collector remembering the requests
+// it received.
+type recordingCollector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newRecordingCollector(t testing.TB) *recordingCollector {
+	t.Helper()
+
+	c := &recordingCollector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		c.mu.Lock()
+		c.requests = append(c.requests, r.Clone(context.Background()))
+		c.bodies = append(c.bodies, body)
+		c.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+// Requests returns the requests received so far.
+func (c *recordingCollector) Requests() []*http.Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.requests)
+}
+
+// Paths returns the distinct paths requested so far, sorted.
+func (c *recordingCollector) Paths() []string {
+	var paths []string
+
+	for _, r := range c.Requests() {
+		paths = append(paths, r.URL.Path)
+	}
+
+	slices.Sort(paths)
+
+	return slices.Compact(paths)
+}
+
+// SpanNames decodes the trace exports received so far and returns the names
+// of their spans.
+func (c *recordingCollector) SpanNames(t testing.TB) []string {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var names []string
+
+	for i, r := range c.requests {
+		if !strings.HasSuffix(r.URL.Path, "/v1/traces") || len(c.bodies[i]) == 0 {
+			continue
+		}
+
+		var req coltracepb.ExportTraceServiceRequest
+
+		err := proto.Unmarshal(c.bodies[i], &req)
+		if err != nil {
+			t.Fatalf("decoding trace export: %v", err)
+		}
+
+		for _, rs := range req.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					names = append(names, span.GetName())
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// Metrics decodes the metric exports received so far and returns their
+// metrics, the latest export of a metric last.
+func (c *recordingCollector) Metrics(t testing.TB) []*metricspb.Metric {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var metrics []*metricspb.Metric
+
+	for i, r := range c.requests {
+		if !strings.HasSuffix(r.URL.Path, "/v1/metrics") || len(c.bodies[i]) == 0 {
+			continue
+		}
+
+		var req colmetricpb.ExportMetricsServiceRequest
+
+		err := proto.Unmarshal(c.bodies[i], &req)
+		if err != nil {
+			t.Fatalf("decoding metric export: %v", err)
+		}
+
+		for _, rm := range req.GetResourceMetrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				metrics = append(metrics, sm.GetMetrics()...)
+			}
+		}
+	}
+
+	return metrics
+}
+
+// newClient returns a client exporting to a fake collector.
+func newClient(t testing.TB) *silgotel.Client {
+	t.Helper()
+
+	return &silgotel.Client{
+		OTLPBaseURL: newCollector(t).URL,
+		ServiceName: "test-service",
+		Environment: "test",
+		Version:     "1.0.0",
+	}
+}
+
+// testSDK is an SDK started with NewOtelSDK whose spans, metrics and logs are
+// also kept in memory.
+type testSDK struct {
+	client *silgotel.Client
+	spans  *tracetest.InMemoryExporter
+	reader *sdkmetric.ManualReader
+	logs   *siltest.LogExporter
+}
+
+// startSDK starts the SDK for client without registering globals and shuts
+// it down when the test finishes.
+func startSDK(t testing.TB, client *silgotel.Client, opts ...silgotel.Option) *testSDK {
+	t.Helper()
+
+	return newTestSDK(t, client, append([]silgotel.Option{silgotel.WithoutGlobalRegistration()}, opts...))
+}
+
+// startGlobalSDK is startSDK for a client registering the OTel globals,
+// which are put back when the test ends.
+func startGlobalSDK(t *testing.T, client *silgotel.Client, opts ...silgotel.Option) *testSDK {
+	t.Helper()
+
+	restoreGlobals(t)
+
+	return newTestSDK(t, client, opts)
+}
+
+func newTestSDK(t testing.TB, client *silgotel.Client, opts []silgotel.Option) *testSDK {
+	t.Helper()
+
+	sdk := &testSDK{
+		client: client,
+		spans:  tracetest.NewInMemoryExporter(),
+		reader: sdkmetric.NewManualReader(),
+		logs:   &siltest.LogExporter{},
+	}
+
+	opts = append([]silgotel.Option{
+		silgotel.WithAdditionalTraceExporter(sdk.spans),
+		silgotel.WithAdditionalMetricReader(sdk.reader),
+		silgotel.WithAdditionalLogExporter(sdk.logs),
+	}, opts...)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, opts...)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+	})
+
+	return sdk
+}
+
+// Spans flushes the SDK and returns the spans exported so far.
+func (s *testSDK) Spans(t testing.TB) tracetest.SpanStubs {
+	t.Helper()
+
+	s.flush(t)
+
+	return s.spans.GetSpans()
+}
+
+// Logs flushes the SDK and returns the records exported so far.
+func (s *testSDK) Logs(t testing.TB) []log.Record {
+	t.Helper()
+
+	s.flush(t)
+
+	return s.logs.Records()
+}
+
+// Metrics collects the current metric data.
+func (s *testSDK) Metrics(t testing.TB) metricdata.ResourceMetrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+
+	err := s.reader.Collect(t.Context(), &rm)
+	if err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	return rm
+}
+
+func (s *testSDK) flush(t testing.TB) {
+	t.Helper()
+
+	err := s.client.ForceFlush(t.Context())
+	if err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+}
+
+// findMetric returns the metric called name in rm.
+func findMetric(rm metricdata.ResourceMetrics, name string) (metricdata.Metrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+
+	return metricdata.Metrics{}, false
+}
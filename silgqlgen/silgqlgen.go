@@ -0,0 +1,148 @@
+// Package silgqlgen traces gqlgen GraphQL servers with the providers
+// configured by silgotel. It lives in its own module so that services not
+// using gqlgen do not depend on the instrumentation.
+package silgqlgen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// scope names the tracer of the extension.
+	scope = "github.com/savannahghi/sil-gotel/silgqlgen"
+
+	// FieldPathKey is the span attribute holding the response path of a
+	// resolved field, such as "user.orders[0].total".
+	FieldPathKey = attribute.Key("graphql.field.path")
+)
+
+// errGraphQL describes the status of operation spans whose response carries
+// errors.
+var errGraphQL = errors.New("graphql response has errors")
+
+type extension struct {
+	tracer otelTrace.Tracer
+}
+
+var (
+	_ graphql.HandlerExtension    = extension{}
+	_ graphql.ResponseInterceptor = extension{}
+	_ graphql.FieldInterceptor    = extension{}
+)
+
+// Extension returns a gqlgen handler extension tracing every operation as a
+// span named after its type and name, such as "query GetUser", and every
+// field with a user defined resolver as a child span named "Type.field".
+// The enclosing server span, named "POST /graphql" by the HTTP middleware,
+// is renamed after the operation too. Errors returned by resolvers are
+// recorded on their field span and every GraphQL error of the response is
+// recorded on the operation span.
+//
+//	srv := handler.New(generated.NewExecutableSchema(cfg))
+//	srv.Use(silgqlgen.Extension(otelClient))
+//
+//nolint:ireturn
+func Extension(client *silgotel.Client) graphql.HandlerExtension {
+	return extension{tracer: client.Tracer(scope)}
+}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (extension) ExtensionName() string {
+	return "SilgotelTracer"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse implements graphql.ResponseInterceptor by wrapping each
+// response of an operation in an operation span.
+func (e extension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	name, attrs := operation(graphql.GetOperationContext(ctx))
+
+	if server, ok := otelTrace.SpanFromContext(ctx).(interface {
+		SpanKind() otelTrace.SpanKind
+	}); ok && server.SpanKind() == otelTrace.SpanKindServer {
+		otelTrace.SpanFromContext(ctx).SetName(name)
+	}
+
+	ctx, span := e.tracer.Start(ctx, name, otelTrace.WithAttributes(attrs...))
+	defer span.End()
+
+	resp := next(ctx)
+
+	gqlErrors := graphql.GetErrors(ctx)
+	if resp != nil {
+		gqlErrors = resp.Errors
+	}
+
+	for _, gqlErr := range gqlErrors {
+		span.RecordError(gqlErr)
+	}
+
+	if len(gqlErrors) > 0 {
+		span.SetStatus(codes.Error, errGraphQL.Error())
+	}
+
+	return resp
+}
+
+// InterceptField implements graphql.FieldInterceptor by tracing fields that
+// have a user defined resolver. Trivial fields read off their parent object
+// are not traced.
+func (e extension) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := e.tracer.Start(ctx, fc.Object+"."+fc.Field.Name,
+		otelTrace.WithAttributes(
+			attribute.String("graphql.field.name", fc.Field.Name),
+			attribute.String("graphql.field.type", fc.Object),
+			FieldPathKey.String(fc.Path().String()),
+		),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+
+	return res, err
+}
+
+// operation returns the span name and attributes of the operation in opCtx.
+func operation(opCtx *graphql.OperationContext) (string, []attribute.KeyValue) {
+	name := opCtx.OperationName
+	kind := "query"
+
+	if opCtx.Operation != nil {
+		kind = string(opCtx.Operation.Operation)
+
+		if name == "" {
+			name = opCtx.Operation.Name
+		}
+	}
+
+	attrs := []attribute.KeyValue{semconv.GraphQLOperationTypeKey.String(kind)}
+	if name == "" {
+		return kind, attrs
+	}
+
+	return kind + " " + name, append(attrs, semconv.GraphQLOperationName(name))
+}
@@ -0,0 +1,195 @@
+package silgqlgen_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silgqlgen"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// errNotFound is returned by the patient resolver for the missing ID.
+var errNotFound = errors.New("patient not found")
+
+// newServer returns a gqlgen server over a hand written schema whose
+// patient field has a resolver, instrumented by Extension and served
+// behind the client's HTTP middleware. gqlgen's generated code is
+// simulated the way its own testserver package does.
+func newServer(client *silgotel.Client) http.Handler {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query {
+			patient(id: ID!): String!
+		}
+	`})
+
+	srv := handler.New(&graphql.ExecutableSchemaMock{
+		ExecFunc: func(context.Context) graphql.ResponseHandler {
+			ran := false
+
+			return func(ctx context.Context) *graphql.Response {
+				if ran {
+					return nil
+				}
+
+				ran = true
+
+				return execute(ctx, schema)
+			}
+		},
+		SchemaFunc: func() *ast.Schema { return schema },
+		ComplexityFunc: func(context.Context, string, string, int, map[string]any) (int, bool) {
+			return 0, false
+		},
+	})
+	srv.AddTransport(transport.POST{})
+	srv.Use(silgqlgen.Extension(client))
+
+	return client.HTTPMiddleware(srv)
+}
+
+// execute resolves the patient field of the operation in ctx.
+func execute(ctx context.Context, schema *ast.Schema) *graphql.Response {
+	opCtx := graphql.GetOperationContext(ctx)
+	id, _ := opCtx.Variables["id"].(string)
+
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Query",
+		Field: graphql.CollectedField{Field: &ast.Field{
+			Name:       "patient",
+			Alias:      "patient",
+			Definition: schema.Types["Query"].Fields.ForName("patient"),
+		}},
+		IsResolver: true,
+	})
+
+	res, err := opCtx.ResolverMiddleware(ctx, func(context.Context) (any, error) {
+		if id == "missing" {
+			return nil, errNotFound
+		}
+
+		return "Jane", nil
+	})
+	if err != nil {
+		graphql.AddError(ctx, err)
+
+		return &graphql.Response{Data: json.RawMessage(`null`)}
+	}
+
+	data, _ := json.Marshal(map[string]any{"patient": res})
+
+	return &graphql.Response{Data: data}
+}
+
+// query posts the GetPatient query for id to srv and returns the status.
+func query(srv http.Handler, id string) int {
+	body := `{"query":"query GetPatient($id: ID!) { patient(id: $id) }","variables":{"id":"` + id + `"}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+// findSpan returns the ended span named name.
+func findSpan(t *testing.T, sdk *siltest.SDK, name string) trace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range sdk.Spans() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("no %q span was recorded", name)
+
+	return nil
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestExtension(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	if code := query(newServer(client), "42"); code != http.StatusOK {
+		t.Fatalf("POST /graphql = %d, want 200", code)
+	}
+
+	spans := sdk.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want the server, operation and field spans", len(spans))
+	}
+
+	field := findSpan(t, sdk, "Query.patient")
+	op := findSpan(t, sdk, "query GetPatient")
+
+	if field.Parent().SpanID() != op.SpanContext().SpanID() {
+		t.Errorf("field span parent = %s, want the operation span", field.Parent().SpanID())
+	}
+
+	if path := attrs(field)[string(silgqlgen.FieldPathKey)]; path != "patient" {
+		t.Errorf("%s = %q, want patient", silgqlgen.FieldPathKey, path)
+	}
+
+	if got := attrs(op); got["graphql.operation.type"] != "query" || got["graphql.operation.name"] != "GetPatient" {
+		t.Errorf("operation type, name = %q, %q, want query GetPatient",
+			got["graphql.operation.type"], got["graphql.operation.name"])
+	}
+
+	// The HTTP server span is renamed after the operation too.
+	server := spans[len(spans)-1]
+	if server.Name() != "query GetPatient" || op.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("server span = %q, want the operation span's parent renamed after it", server.Name())
+	}
+
+	for _, span := range spans {
+		if span.Status().Code != codes.Unset {
+			t.Errorf("span %q status = %v, want unset", span.Name(), span.Status())
+		}
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestExtensionRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	// GraphQL reports resolver errors in the response body.
+	if code := query(newServer(client), "missing"); code != http.StatusOK {
+		t.Fatalf("POST /graphql = %d, want 200", code)
+	}
+
+	for _, name := range []string{"Query.patient", "query GetPatient"} {
+		span := findSpan(t, sdk, name)
+		if span.Status().Code != codes.Error {
+			t.Errorf("%s status = %v, want error", name, span.Status())
+		}
+
+		if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+			t.Errorf("%s events = %v, want the resolver error recorded", name, events)
+		}
+	}
+}
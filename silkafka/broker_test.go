@@ -0,0 +1,294 @@
+package silkafka_test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/fetch"
+	"github.com/segmentio/kafka-go/protocol/listoffsets"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+)
+
+const (
+	// rejectedTopic is a topic whose writes the broker refuses.
+	rejectedTopic = "rejected"
+
+	// errMessageTooLarge is the MESSAGE_TOO_LARGE error code, which writers
+	// do not retry.
+	errMessageTooLarge = 10
+)
+
+// broker is an in-process Kafka broker with a single partition per topic,
+// speaking just enough of the protocol for kafka-go writers and readers.
+type broker struct {
+	listener net.Listener
+	host     string
+	port     int32
+
+	mu      sync.Mutex
+	conns   []net.Conn
+	records map[string][]storedRecord
+}
+
+// storedRecord is a record the broker keeps after reading it from a produce
+// request.
+type storedRecord struct {
+	time       time.Time
+	key, value []byte
+	headers    []protocol.Header
+}
+
+// newBroker starts a broker that is closed when the test ends.
+func newBroker(t *testing.T) *broker {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	addr := listener.Addr().(*net.TCPAddr) //nolint:forcetypeassert
+	b := &broker{
+		listener: listener,
+		host:     addr.IP.String(),
+		port:     int32(addr.Port), //nolint:gosec
+		records:  make(map[string][]storedRecord),
+	}
+
+	var wg sync.WaitGroup
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+
+		b.mu.Lock()
+		for _, conn := range b.conns {
+			_ = conn.Close()
+		}
+		b.mu.Unlock()
+
+		wg.Wait()
+	})
+
+	wg.Go(func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			b.mu.Lock()
+			b.conns = append(b.conns, conn)
+			b.mu.Unlock()
+
+			wg.Go(func() { b.serve(conn) })
+		}
+	})
+
+	return b
+}
+
+// Addr returns the address clients bootstrap from.
+func (b *broker) Addr() string {
+	return net.JoinHostPort(b.host, strconv.Itoa(int(b.port)))
+}
+
+// serve answers the requests sent on conn until it is closed.
+func (b *broker) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+
+	for {
+		version, correlationID, _, msg, err := protocol.ReadRequest(r)
+		if err != nil {
+			return
+		}
+
+		res := b.handle(msg)
+		if res == nil {
+			continue
+		}
+
+		if err := protocol.WriteResponse(conn, version, correlationID, res); err != nil {
+			return
+		}
+	}
+}
+
+// handle returns the response to msg, or nil when none is expected.
+//
+//nolint:ireturn
+func (b *broker) handle(msg protocol.Message) protocol.Message {
+	switch req := msg.(type) {
+	case *apiversions.Request:
+		res := &apiversions.Response{}
+		for _, key := range []protocol.ApiKey{
+			protocol.Produce, protocol.Fetch, protocol.ListOffsets, protocol.Metadata, protocol.ApiVersions,
+		} {
+			res.ApiKeys = append(res.ApiKeys, apiversions.ApiKeyResponse{
+				ApiKey: int16(key), MinVersion: key.MinVersion(), MaxVersion: key.MaxVersion(),
+			})
+		}
+
+		return res
+	case *metadata.Request:
+		return b.metadata()
+	case *produce.Request:
+		return b.produce(req)
+	case *listoffsets.Request:
+		return b.listOffsets(req)
+	case *fetch.Request:
+		return b.fetch(req)
+	default:
+		return nil
+	}
+}
+
+func (b *broker) metadata() *metadata.Response {
+	res := &metadata.Response{
+		Brokers:      []metadata.ResponseBroker{{NodeID: 1, Host: b.host, Port: b.port}},
+		ControllerID: 1,
+	}
+
+	for _, topic := range []string{"orders", rejectedTopic} {
+		res.Topics = append(res.Topics, metadata.ResponseTopic{
+			Name: topic,
+			Partitions: []metadata.ResponsePartition{
+				{PartitionIndex: 0, LeaderID: 1, ReplicaNodes: []int32{1}, IsrNodes: []int32{1}},
+			},
+		})
+	}
+
+	return res
+}
+
+func (b *broker) produce(req *produce.Request) protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &produce.Response{}
+
+	for _, topic := range req.Topics {
+		partitions := make([]produce.ResponsePartition, 0, len(topic.Partitions))
+
+		for _, partition := range topic.Partitions {
+			result := produce.ResponsePartition{
+				Partition:  partition.Partition,
+				BaseOffset: int64(len(b.records[topic.Topic])),
+			}
+
+			if topic.Topic == rejectedTopic {
+				result.ErrorCode = errMessageTooLarge
+			} else {
+				b.store(topic.Topic, partition.RecordSet.Records)
+			}
+
+			partitions = append(partitions, result)
+		}
+
+		res.Topics = append(res.Topics, produce.ResponseTopic{Topic: topic.Topic, Partitions: partitions})
+	}
+
+	if req.Acks == 0 {
+		return nil
+	}
+
+	return res
+}
+
+// store appends the records read from records to topic.
+func (b *broker) store(topic string, records protocol.RecordReader) {
+	for {
+		record, err := records.ReadRecord()
+		if err != nil {
+			return
+		}
+
+		key, _ := protocol.ReadAll(record.Key)
+		value, _ := protocol.ReadAll(record.Value)
+
+		b.records[topic] = append(b.records[topic], storedRecord{
+			time:    time.Now(),
+			key:     key,
+			value:   value,
+			headers: append([]protocol.Header(nil), record.Headers...),
+		})
+	}
+}
+
+func (b *broker) listOffsets(req *listoffsets.Request) *listoffsets.Response {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &listoffsets.Response{}
+
+	for _, topic := range req.Topics {
+		partitions := make([]listoffsets.ResponsePartition, 0, len(topic.Partitions))
+
+		for _, partition := range topic.Partitions {
+			offset := int64(0)
+			if partition.Timestamp == -1 {
+				offset = int64(len(b.records[topic.Topic]))
+			}
+
+			partitions = append(partitions, listoffsets.ResponsePartition{
+				Partition: partition.Partition,
+				Timestamp: partition.Timestamp,
+				Offset:    offset,
+			})
+		}
+
+		res.Topics = append(res.Topics, listoffsets.ResponseTopic{Topic: topic.Topic, Partitions: partitions})
+	}
+
+	return res
+}
+
+func (b *broker) fetch(req *fetch.Request) *fetch.Response {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &fetch.Response{}
+
+	for _, topic := range req.Topics {
+		partitions := make([]fetch.ResponsePartition, 0, len(topic.Partitions))
+
+		for _, partition := range topic.Partitions {
+			stored := b.records[topic.Topic]
+
+			var records []protocol.Record
+			for offset := partition.FetchOffset; offset < int64(len(stored)); offset++ {
+				records = append(records, stored[offset].record(offset))
+			}
+
+			partitions = append(partitions, fetch.ResponsePartition{
+				Partition:        partition.Partition,
+				HighWatermark:    int64(len(stored)),
+				LastStableOffset: int64(len(stored)),
+				RecordSet:        protocol.RecordSet{Version: 2, Records: protocol.NewRecordReader(records...)},
+			})
+		}
+
+		res.Topics = append(res.Topics, fetch.ResponseTopic{Topic: topic.Topic, Partitions: partitions})
+	}
+
+	return res
+}
+
+// record returns stored as a record to fetch at offset.
+func (s storedRecord) record(offset int64) protocol.Record {
+	return protocol.Record{
+		Offset:  offset,
+		Time:    s.time,
+		Key:     protocol.NewBytes(s.key),
+		Value:   protocol.NewBytes(s.value),
+		Headers: s.headers,
+	}
+}
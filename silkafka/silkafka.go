@@ -0,0 +1,294 @@
+// Package silkafka instruments Kafka producers and consumers built on
+// segmentio/kafka-go with the providers configured by silgotel. It lives in
+// its own module so that services not using Kafka do not depend on the
+// instrumentation.
+package silkafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// scope names the tracer and meter of the integration.
+const scope = "github.com/savannahghi/sil-gotel/silkafka"
+
+// HeaderCarrier adapts the headers of a Kafka message to a
+// propagation.TextMapCarrier.
+type HeaderCarrier struct {
+	msg *kafka.Message
+}
+
+var _ propagation.TextMapCarrier = HeaderCarrier{}
+
+// NewHeaderCarrier returns a carrier reading and writing the headers of msg.
+func NewHeaderCarrier(msg *kafka.Message) HeaderCarrier {
+	return HeaderCarrier{msg: msg}
+}
+
+// Get returns the value of the first header named key.
+func (c HeaderCarrier) Get(key string) string {
+	for _, header := range c.msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set replaces the header named key, adding it when missing.
+func (c HeaderCarrier) Set(key, value string) {
+	for i, header := range c.msg.Headers {
+		if header.Key == key {
+			c.msg.Headers[i].Value = []byte(value)
+
+			return
+		}
+	}
+
+	c.msg.Headers = append(c.msg.Headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys returns the names of all headers.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, header := range c.msg.Headers {
+		keys = append(keys, header.Key)
+	}
+
+	return keys
+}
+
+// Writer wraps a kafka.Writer so that every message is published in a
+// producer span whose context is injected into the message headers.
+type Writer struct {
+	writer     *kafka.Writer
+	tracer     otelTrace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   otelMetric.Float64Histogram
+}
+
+// NewWriter returns a Writer publishing through w with the client's
+// providers. The publish latency is recorded in seconds on
+// messaging.client.operation.duration.
+//
+//	writer := silkafka.NewWriter(otelClient, &kafka.Writer{Addr: kafka.TCP(broker), Topic: "orders"})
+func NewWriter(client *silgotel.Client, w *kafka.Writer) *Writer {
+	return &Writer{
+		writer:     w,
+		tracer:     client.Tracer(scope),
+		propagator: client.Propagator(),
+		duration:   operationDuration(client),
+	}
+}
+
+// WriteMessages publishes msgs, starting a producer span named
+// "<topic> send" for each and injecting its context into the message
+// headers. A failed write is recorded on every span of the call.
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	spans := make([]otelTrace.Span, len(msgs))
+
+	for i := range msgs {
+		topic := w.topic(&msgs[i])
+
+		attrs := []attribute.KeyValue{
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingOperationName("send"),
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingMessageBodySize(len(msgs[i].Value)),
+		}
+
+		if len(msgs[i].Key) > 0 {
+			attrs = append(attrs, semconv.MessagingKafkaMessageKey(string(msgs[i].Key)))
+		}
+
+		spanCtx, span := w.tracer.Start(ctx, topic+" send", //nolint:spancheck
+			otelTrace.WithSpanKind(otelTrace.SpanKindProducer),
+			otelTrace.WithAttributes(attrs...),
+		)
+
+		w.propagator.Inject(spanCtx, NewHeaderCarrier(&msgs[i]))
+		spans[i] = span
+	}
+
+	start := time.Now()
+	err := w.writer.WriteMessages(ctx, msgs...)
+	elapsed := time.Since(start).Seconds()
+
+	for i, span := range spans {
+		if err != nil {
+			silgotel.CaptureTraceStatusAndError(span, err)
+		}
+
+		span.End()
+
+		w.duration.Record(ctx, elapsed, otelMetric.WithAttributes(metricAttributes("send", w.topic(&msgs[i]), err)...))
+	}
+
+	return err
+}
+
+// topic returns the topic msg is published to, falling back to the topic of
+// the wrapped writer.
+func (w *Writer) topic(msg *kafka.Message) string {
+	if msg.Topic != "" {
+		return msg.Topic
+	}
+
+	return w.writer.Topic
+}
+
+// Close closes the wrapped kafka.Writer.
+func (w *Writer) Close() error {
+	return w.writer.Close()
+}
+
+// Reader wraps a kafka.Reader so that every message is handled in a
+// consumer span continuing the trace of its producer.
+type Reader struct {
+	reader     *kafka.Reader
+	tracer     otelTrace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   otelMetric.Float64Histogram
+}
+
+// NewReader returns a Reader consuming through r with the client's
+// providers. The processing latency is recorded in seconds on
+// messaging.client.operation.duration. For a reader of a single partition
+// the lag of the reader is also reported on the messaging.kafka.consumer.lag
+// gauge; readers with a GroupID report no lag, since kafka-go does not track
+// it for consumer groups.
+//
+//	reader, err := silkafka.NewReader(otelClient, kafka.NewReader(kafka.ReaderConfig{...}))
+func NewReader(client *silgotel.Client, r *kafka.Reader) (*Reader, error) {
+	config := r.Config()
+
+	if config.GroupID == "" {
+		_, err := client.RegisterGauge(client.Meter(scope),
+			"messaging.kafka.consumer.lag", "{message}", "Messages the reader is behind the end of its partition",
+			func(context.Context) (float64, []attribute.KeyValue) {
+				return float64(r.Lag()), []attribute.KeyValue{
+					semconv.MessagingSystemKafka,
+					semconv.MessagingDestinationName(config.Topic),
+					semconv.MessagingDestinationPartitionID(strconv.Itoa(config.Partition)),
+				}
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Reader{
+		reader:     r,
+		tracer:     client.Tracer(scope),
+		propagator: client.Propagator(),
+		duration:   operationDuration(client),
+	}, nil
+}
+
+// HandleMessage fetches the next message and passes it to handle inside a
+// consumer span named "<topic> process". The span continues the producer's
+// trace and links to the producer span. When handle succeeds and the reader
+// belongs to a consumer group the message is committed. Errors from the
+// fetch, handle and commit are returned.
+func (r *Reader) HandleMessage(ctx context.Context, handle func(context.Context, kafka.Message) error) error {
+	msg, err := r.reader.FetchMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	msgCtx := r.propagator.Extract(ctx, NewHeaderCarrier(&msg))
+
+	opts := []otelTrace.SpanStartOption{
+		otelTrace.WithSpanKind(otelTrace.SpanKindConsumer),
+		otelTrace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingOperationName("process"),
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(msg.Partition)),
+			semconv.MessagingKafkaOffset(int(msg.Offset)),
+			semconv.MessagingMessageBodySize(len(msg.Value)),
+		),
+	}
+
+	if len(msg.Key) > 0 {
+		opts = append(opts, otelTrace.WithAttributes(semconv.MessagingKafkaMessageKey(string(msg.Key))))
+	}
+
+	if group := r.reader.Config().GroupID; group != "" {
+		opts = append(opts, otelTrace.WithAttributes(semconv.MessagingConsumerGroupName(group)))
+	}
+
+	if producer := otelTrace.SpanContextFromContext(msgCtx); producer.IsValid() {
+		opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: producer}))
+	}
+
+	msgCtx, span := r.tracer.Start(msgCtx, msg.Topic+" process", opts...)
+	defer span.End()
+
+	start := time.Now()
+
+	err = handle(msgCtx, msg)
+	if err == nil && r.reader.Config().GroupID != "" {
+		err = r.reader.CommitMessages(msgCtx, msg)
+	}
+
+	r.duration.Record(msgCtx, time.Since(start).Seconds(),
+		otelMetric.WithAttributes(metricAttributes("process", msg.Topic, err)...))
+
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+
+	return err
+}
+
+// Close closes the wrapped kafka.Reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}
+
+// operationDuration creates the histogram shared by writers and readers.
+//
+//nolint:ireturn
+func operationDuration(client *silgotel.Client) otelMetric.Float64Histogram {
+	duration, err := client.Meter(scope).Float64Histogram(
+		"messaging.client.operation.duration",
+		otelMetric.WithDescription("Duration of messaging operations"),
+		otelMetric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("silkafka: failed to create metric instrument: %v", err))
+	}
+
+	return duration
+}
+
+// metricAttributes returns the attributes a messaging operation on topic is
+// recorded under.
+func metricAttributes(operation, topic string, err error) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKafka,
+		semconv.MessagingOperationName(operation),
+		semconv.MessagingDestinationName(topic),
+	}
+
+	if err != nil {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+
+	return attrs
+}
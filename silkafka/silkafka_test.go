@@ -0,0 +1,269 @@
+package silkafka_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silkafka"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// newWriter returns a Writer publishing to topic on b.
+func newWriter(t *testing.T, client *silgotel.Client, b *broker, topic string) *silkafka.Writer {
+	t.Helper()
+
+	writer := silkafka.NewWriter(client, &kafka.Writer{
+		Addr:         kafka.TCP(b.Addr()),
+		Topic:        topic,
+		RequiredAcks: kafka.RequireOne,
+		MaxAttempts:  1,
+		BatchTimeout: time.Millisecond,
+	})
+
+	t.Cleanup(func() { _ = writer.Close() })
+
+	return writer
+}
+
+// newReader returns a Reader consuming the orders topic on b.
+func newReader(t *testing.T, client *silgotel.Client, b *broker) *silkafka.Reader {
+	t.Helper()
+
+	reader, err := silkafka.NewReader(client, kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{b.Addr()},
+		Topic:   "orders",
+		MaxWait: 10 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = reader.Close() })
+
+	return reader
+}
+
+// handle passes the next message of reader to handleFunc, failing the test
+// when none arrives.
+func handle(t *testing.T, reader *silkafka.Reader, handleFunc func(context.Context, kafka.Message) error) error {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	err := reader.HandleMessage(ctx, handleFunc)
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("no message was fetched")
+	}
+
+	return err
+}
+
+// findSpan returns the ended span named name.
+func findSpan(t *testing.T, sdk *siltest.SDK, name string) trace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range sdk.Spans() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("no %q span was recorded", name)
+
+	return nil
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	msg := &kafka.Message{Headers: []kafka.Header{
+		{Key: "tenant", Value: []byte("clinic-a")},
+		{Key: "tenant", Value: []byte("clinic-b")},
+	}}
+	carrier := silkafka.NewHeaderCarrier(msg)
+
+	if got := carrier.Get("tenant"); got != "clinic-a" {
+		t.Errorf("Get(tenant) = %q, want the first header", got)
+	}
+
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("Get(missing) = %q, want empty", got)
+	}
+
+	carrier.Set("tenant", "clinic-c")
+	carrier.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if got := carrier.Get("tenant"); got != "clinic-c" {
+		t.Errorf("Get(tenant) after Set = %q, want the replaced value", got)
+	}
+
+	if keys := carrier.Keys(); !slices.Equal(keys, []string{"tenant", "tenant", "traceparent"}) {
+		t.Errorf("Keys() = %v, want the existing headers and traceparent appended", keys)
+	}
+
+	ctx := propagation.TraceContext{}.Extract(t.Context(), carrier)
+	if traceID := otelTrace.SpanContextFromContext(ctx).TraceID().String(); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("extracted trace ID = %s, want the traceparent header's", traceID)
+	}
+}
+
+func TestWriterAndReader(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	b := newBroker(t)
+	writer := newWriter(t, client, b, "orders")
+	reader := newReader(t, client, b)
+
+	ctx, parent := client.Tracer("orders").Start(t.Context(), "POST /orders")
+
+	err := writer.WriteMessages(ctx, kafka.Message{Key: []byte("order-42"), Value: []byte(`{"total":10}`)})
+	if err != nil {
+		t.Fatalf("WriteMessages() error = %v", err)
+	}
+
+	parent.End()
+
+	var handled otelTrace.SpanContext
+
+	err = handle(t, reader, func(ctx context.Context, msg kafka.Message) error {
+		handled = otelTrace.SpanContextFromContext(ctx)
+
+		if string(msg.Value) != `{"total":10}` {
+			t.Errorf("handled message = %q, want the published one", msg.Value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("HandleMessage() error = %v", err)
+	}
+
+	send := findSpan(t, sdk, "orders send")
+	if send.SpanKind() != otelTrace.SpanKindProducer || send.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("send span kind = %v with parent %s, want a producer child of the request",
+			send.SpanKind(), send.Parent().SpanID())
+	}
+
+	for key, want := range map[string]string{
+		"messaging.system":            "kafka",
+		"messaging.operation.type":    "send",
+		"messaging.destination.name":  "orders",
+		"messaging.kafka.message.key": "order-42",
+		"messaging.message.body.size": "12",
+	} {
+		if got := attrs(send)[key]; got != want {
+			t.Errorf("send %s = %q, want %q", key, got, want)
+		}
+	}
+
+	process := findSpan(t, sdk, "orders process")
+	if process.SpanContext().SpanID() != handled.SpanID() {
+		t.Errorf("handler span = %s, want the process span", handled.SpanID())
+	}
+
+	if process.Parent().SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span parent = %s, want the send span", process.Parent().SpanID())
+	}
+
+	if links := process.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span links = %v, want the send span", links)
+	}
+
+	for key, want := range map[string]string{
+		"messaging.operation.type":           "process",
+		"messaging.destination.partition.id": "0",
+		"messaging.kafka.offset":             "0",
+		"messaging.kafka.message.key":        "order-42",
+	} {
+		if got := attrs(process)[key]; got != want {
+			t.Errorf("process %s = %q, want %q", key, got, want)
+		}
+	}
+
+	m, ok := sdk.Metric("messaging.client.operation.duration")
+	if !ok {
+		t.Fatal("messaging.client.operation.duration was not recorded")
+	}
+
+	points := m.Data.(metricdata.Histogram[float64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 2 {
+		t.Errorf("messaging.client.operation.duration has %d points, want send and process", len(points))
+	}
+
+	if _, ok := sdk.Metric("messaging.kafka.consumer.lag"); !ok {
+		t.Error("messaging.kafka.consumer.lag was not recorded for a partition reader")
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestWriterRecordsErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	writer := newWriter(t, client, newBroker(t), rejectedTopic)
+
+	if err := writer.WriteMessages(t.Context(), kafka.Message{Value: []byte("too large")}); err == nil {
+		t.Fatal("WriteMessages() error = nil, want the broker's rejection")
+	}
+
+	span := findSpan(t, sdk, rejectedTopic+" send")
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the write error recorded", events)
+	}
+
+	m, _ := sdk.Metric("messaging.client.operation.duration")
+
+	points := m.Data.(metricdata.Histogram[float64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 || !points[0].Attributes.HasValue("error.type") {
+		t.Errorf("messaging.client.operation.duration points = %v, want one with error.type", points)
+	}
+}
+
+func TestReaderRecordsHandlerErrors(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	b := newBroker(t)
+	reader := newReader(t, client, b)
+
+	writer := newWriter(t, client, b, "orders")
+
+	if err := writer.WriteMessages(t.Context(), kafka.Message{Value: []byte("{}")}); err != nil {
+		t.Fatalf("WriteMessages() error = %v", err)
+	}
+
+	errInvalid := errors.New("invalid order")
+
+	err := handle(t, reader, func(context.Context, kafka.Message) error { return errInvalid })
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("HandleMessage() error = %v, want the handler's", err)
+	}
+
+	span := findSpan(t, sdk, "orders process")
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the handler's error recorded", events)
+	}
+}
@@ -0,0 +1,124 @@
+// Package sillogrus ships logrus entries through the logger provider
+// configured by silgotel, for legacy services that have not moved to slog. It
+// lives in its own module so that services not using logrus do not depend on
+// it.
+package sillogrus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/sirupsen/logrus"
+	otelLog "go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// severities maps logrus levels to OTel severities.
+//
+//nolint:gochecknoglobals
+var severities = map[logrus.Level]otelLog.Severity{
+	logrus.TraceLevel: otelLog.SeverityTrace,
+	logrus.DebugLevel: otelLog.SeverityDebug,
+	logrus.InfoLevel:  otelLog.SeverityInfo,
+	logrus.WarnLevel:  otelLog.SeverityWarn,
+	logrus.ErrorLevel: otelLog.SeverityError,
+	logrus.FatalLevel: otelLog.SeverityFatal,
+	logrus.PanicLevel: otelLog.SeverityFatal4,
+}
+
+// Hook is a logrus.Hook emitting every entry as an OTel log record.
+type Hook struct {
+	logger otelLog.Logger
+	levels []logrus.Level
+}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// NewHook returns a hook that emits entries at levels, or at every level
+// when none are given, as OTel log records on the client's logger provider,
+// scoped to the client's service name. Entry fields become log attributes
+// with their native types where OTel has one, errors become their message
+// and other values are formatted with fmt. The trace context is taken from
+// the entry's context, set with WithContext.
+//
+//	logrus.AddHook(sillogrus.NewHook(otelClient))
+//	logrus.WithContext(ctx).WithField("order", id).Info("order placed")
+func NewHook(client *silgotel.Client, levels ...logrus.Level) *Hook {
+	if len(levels) == 0 {
+		levels = logrus.AllLevels
+	}
+
+	return &Hook{
+		logger: client.LoggerProvider().Logger(client.ServiceName,
+			otelLog.WithInstrumentationVersion(client.Version),
+			otelLog.WithSchemaURL(semconv.SchemaURL),
+		),
+		levels: levels,
+	}
+}
+
+// Levels implements logrus.Hook.
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire implements logrus.Hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var record otelLog.Record
+
+	record.SetTimestamp(entry.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(severities[entry.Level])
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otelLog.StringValue(entry.Message))
+
+	attrs := make([]otelLog.KeyValue, 0, len(entry.Data))
+	for key, value := range entry.Data {
+		attrs = append(attrs, otelLog.KeyValue{Key: key, Value: logValue(value)})
+	}
+
+	record.AddAttributes(attrs...)
+
+	h.logger.Emit(ctx, record)
+
+	return nil
+}
+
+// logValue converts a logrus field value to a log value.
+func logValue(value any) otelLog.Value {
+	switch v := value.(type) {
+	case string:
+		return otelLog.StringValue(v)
+	case bool:
+		return otelLog.BoolValue(v)
+	case int:
+		return otelLog.IntValue(v)
+	case int32:
+		return otelLog.Int64Value(int64(v))
+	case int64:
+		return otelLog.Int64Value(v)
+	case uint32:
+		return otelLog.Int64Value(int64(v))
+	case float32:
+		return otelLog.Float64Value(float64(v))
+	case float64:
+		return otelLog.Float64Value(v)
+	case []byte:
+		return otelLog.BytesValue(v)
+	case time.Duration:
+		return otelLog.Int64Value(v.Nanoseconds())
+	case error:
+		return otelLog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otelLog.StringValue(v.String())
+	default:
+		return otelLog.StringValue(fmt.Sprint(v))
+	}
+}
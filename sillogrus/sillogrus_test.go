@@ -0,0 +1,130 @@
+package sillogrus_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/sil-gotel/sillogrus"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"github.com/sirupsen/logrus"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// newLogger returns a logrus logger writing only through hook.
+func newLogger(hook logrus.Hook) *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(hook)
+
+	return logger
+}
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record log.Record) map[string]otelLog.Value {
+	values := make(map[string]otelLog.Value)
+	record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		values[kv.Key] = kv.Value
+
+		return true
+	})
+
+	return values
+}
+
+func TestHook(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	logger := newLogger(sillogrus.NewHook(client))
+
+	ctx, span := client.Tracer("orders").Start(t.Context(), "POST /orders")
+
+	logger.WithContext(ctx).WithFields(logrus.Fields{
+		"order":   "order-42",
+		"attempt": 3,
+		"retried": true,
+		"ratio":   0.5,
+		"backoff": 2 * time.Second,
+		"error":   errors.New("payment declined"),
+		"items":   []string{"a", "b"},
+	}).Warn("order retried")
+	span.End()
+
+	records := sdk.Logs()
+	if len(records) != 1 {
+		t.Fatalf("emitted %d records, want 1", len(records))
+	}
+
+	record := records[0]
+	if record.Body().AsString() != "order retried" || record.Severity() != otelLog.SeverityWarn ||
+		record.SeverityText() != "warning" {
+		t.Errorf("record = %q at %v (%s), want the warning", record.Body().AsString(),
+			record.Severity(), record.SeverityText())
+	}
+
+	if record.TraceID() != span.SpanContext().TraceID() || record.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("record trace, span = %s, %s, want the entry context's", record.TraceID(), record.SpanID())
+	}
+
+	if scope := record.InstrumentationScope(); scope.Name != client.ServiceName || scope.Version != client.Version {
+		t.Errorf("record scope = %s@%s, want the client's service and version", scope.Name, scope.Version)
+	}
+
+	got := attrs(record)
+	for key, want := range map[string]otelLog.Value{
+		"order":   otelLog.StringValue("order-42"),
+		"attempt": otelLog.IntValue(3),
+		"retried": otelLog.BoolValue(true),
+		"ratio":   otelLog.Float64Value(0.5),
+		"backoff": otelLog.Int64Value((2 * time.Second).Nanoseconds()),
+		"error":   otelLog.StringValue("payment declined"),
+		"items":   otelLog.StringValue("[a b]"),
+	} {
+		if !got[key].Equal(want) {
+			t.Errorf("%s = %v, want %v", key, got[key], want)
+		}
+	}
+}
+
+func TestHookLevels(t *testing.T) {
+	tests := map[string]struct {
+		levels []logrus.Level
+		want   int
+	}{
+		"every level": {want: 3},
+		"errors only": {levels: []logrus.Level{logrus.ErrorLevel}, want: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, sdk := siltest.NewTestClient(t)
+			logger := newLogger(sillogrus.NewHook(client, tt.levels...))
+
+			logger.Debug("cache warmed")
+			logger.Info("order placed")
+			logger.Error("order failed")
+
+			if records := sdk.Logs(); len(records) != tt.want {
+				t.Errorf("emitted %d records, want %d", len(records), tt.want)
+			}
+		})
+	}
+}
+
+func TestHookWithoutContext(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	logger := newLogger(sillogrus.NewHook(client))
+
+	logger.Info("order placed")
+
+	records := sdk.Logs()
+	if len(records) != 1 {
+		t.Fatalf("emitted %d records, want 1", len(records))
+	}
+
+	if records[0].TraceID().IsValid() {
+		t.Errorf("record trace = %s, want none without an entry context", records[0].TraceID())
+	}
+}
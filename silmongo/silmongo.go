@@ -0,0 +1,122 @@
+// Package silmongo instruments the MongoDB Go driver with the providers
+// configured by silgotel. It lives in its own module so that services not
+// using MongoDB do not depend on the instrumentation.
+//
+// The monitor is written here rather than wrapping contrib's otelmongo, which
+// has no release for the v2 driver that builds against the OpenTelemetry
+// version silgotel pins. Its spans carry the same database attributes, but
+// are named "find users" where otelmongo names them "users.find".
+package silmongo
+
+import (
+	"context"
+	"sync"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// scope names the tracer of the monitor.
+	scope = "github.com/savannahghi/sil-gotel/silmongo"
+
+	// dbSystemKey is the db.system span attribute, matching silsql.
+	dbSystemKey = attribute.Key("db.system")
+)
+
+// spanKey identifies an in-flight command. Request IDs are only unique per
+// connection.
+type spanKey struct {
+	connectionID string
+	requestID    int64
+}
+
+type monitor struct {
+	tracer otelTrace.Tracer
+	spans  sync.Map
+}
+
+// CommandMonitor returns a command monitor that traces every command sent by
+// the driver as a client span named after the command and collection, such as
+// "find users", on the client's tracer provider. Spans carry db.system, the
+// database, collection and command name, and become children of the span in
+// the context passed to the driver call. Command documents are never recorded
+// as they hold the queried values.
+//
+//	opts := options.Client().ApplyURI(uri).SetMonitor(silmongo.CommandMonitor(otelClient))
+func CommandMonitor(client *silgotel.Client) *event.CommandMonitor {
+	m := &monitor{tracer: client.Tracer(scope)}
+
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *monitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	attrs := []attribute.KeyValue{
+		dbSystemKey.String("mongodb"),
+		semconv.DBNamespace(evt.DatabaseName),
+		semconv.DBOperationName(evt.CommandName),
+	}
+
+	name := evt.CommandName
+
+	collection := collectionName(evt)
+	if collection != "" {
+		name += " " + collection
+		attrs = append(attrs, semconv.DBCollectionName(collection))
+	}
+
+	_, span := m.tracer.Start(ctx, name, //nolint:spancheck
+		otelTrace.WithSpanKind(otelTrace.SpanKindClient),
+		otelTrace.WithAttributes(attrs...),
+	)
+
+	m.spans.Store(spanKey{evt.ConnectionID, evt.RequestID}, span)
+}
+
+func (m *monitor) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	m.finished(&evt.CommandFinishedEvent, nil)
+}
+
+func (m *monitor) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	m.finished(&evt.CommandFinishedEvent, evt.Failure)
+}
+
+func (m *monitor) finished(evt *event.CommandFinishedEvent, err error) {
+	value, ok := m.spans.LoadAndDelete(spanKey{evt.ConnectionID, evt.RequestID})
+	if !ok {
+		return
+	}
+
+	span, ok := value.(otelTrace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+}
+
+// collectionName returns the collection a command targets, which the driver
+// sends as the string value of the command's first element.
+func collectionName(evt *event.CommandStartedEvent) string {
+	elements, err := evt.Command.Elements()
+	if err != nil || len(elements) == 0 {
+		return ""
+	}
+
+	collection, ok := elements[0].Value().StringValueOK()
+	if !ok {
+		return ""
+	}
+
+	return collection
+}
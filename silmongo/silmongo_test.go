@@ -0,0 +1,165 @@
+package silmongo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/savannahghi/sil-gotel/silmongo"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The monitor is driven with the events the driver publishes, so no server
+// is needed.
+
+// started returns the started event of a command on connection.
+func started(t *testing.T, connection string, requestID int64, command bson.D) *event.CommandStartedEvent {
+	t.Helper()
+
+	raw, err := bson.Marshal(command)
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	return &event.CommandStartedEvent{
+		Command:      raw,
+		DatabaseName: "clinic",
+		CommandName:  command[0].Key,
+		RequestID:    requestID,
+		ConnectionID: connection,
+	}
+}
+
+// finished returns the finished event matching evt.
+func finished(evt *event.CommandStartedEvent) event.CommandFinishedEvent {
+	return event.CommandFinishedEvent{
+		CommandName:  evt.CommandName,
+		DatabaseName: evt.DatabaseName,
+		RequestID:    evt.RequestID,
+		ConnectionID: evt.ConnectionID,
+	}
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestCommandMonitor(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	monitor := silmongo.CommandMonitor(client)
+
+	ctx, parent := client.Tracer("patients").Start(t.Context(), "GET /patients")
+
+	evt := started(t, "db:27017[-1]", 1, bson.D{
+		{Key: "find", Value: "patients"},
+		{Key: "filter", Value: bson.D{{Key: "name", Value: "Jane"}}},
+	})
+	monitor.Started(ctx, evt)
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(evt)})
+	parent.End()
+
+	span := sdk.Spans()[0]
+	if span.Name() != "find patients" {
+		t.Errorf("span name = %q, want the command and collection", span.Name())
+	}
+
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span parent = %s, want the request span", span.Parent().SpanID())
+	}
+
+	got := attrs(span)
+	for key, want := range map[string]string{
+		"db.system":          "mongodb",
+		"db.namespace":       "clinic",
+		"db.operation.name":  "find",
+		"db.collection.name": "patients",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %q, want %q", key, got[key], want)
+		}
+	}
+
+	for key, value := range got {
+		if value == "Jane" {
+			t.Errorf("%s records the queried value", key)
+		}
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestCommandMonitorRecordsFailures(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	monitor := silmongo.CommandMonitor(client)
+
+	evt := started(t, "db:27017[-1]", 1, bson.D{{Key: "insert", Value: "visits"}})
+	monitor.Started(t.Context(), evt)
+	monitor.Failed(t.Context(), &event.CommandFailedEvent{
+		CommandFinishedEvent: finished(evt),
+		Failure:              errors.New("E11000 duplicate key error"),
+	})
+
+	span := sdk.Spans()[0]
+	if span.Name() != "insert visits" || span.Status().Code != codes.Error {
+		t.Errorf("span %q status = %v, want a failed insert visits", span.Name(), span.Status())
+	}
+
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("span events = %v, want the failure recorded", events)
+	}
+}
+
+func TestCommandMonitorWithoutCollection(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	monitor := silmongo.CommandMonitor(client)
+
+	evt := started(t, "db:27017[-1]", 1, bson.D{{Key: "ping", Value: 1}})
+	monitor.Started(t.Context(), evt)
+	monitor.Succeeded(t.Context(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(evt)})
+
+	span := sdk.Spans()[0]
+	if span.Name() != "ping" {
+		t.Errorf("span name = %q, want the bare command", span.Name())
+	}
+
+	if collection, ok := attrs(span)["db.collection.name"]; ok {
+		t.Errorf("db.collection.name = %q, want none for an admin command", collection)
+	}
+}
+
+func TestCommandMonitorMatchesConnections(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	monitor := silmongo.CommandMonitor(client)
+	ctx := t.Context()
+
+	// Request IDs are only unique per connection, so both commands stay
+	// in flight until their own connection reports them finished.
+	first := started(t, "db:27017[-1]", 7, bson.D{{Key: "find", Value: "patients"}})
+	second := started(t, "db:27017[-2]", 7, bson.D{{Key: "find", Value: "visits"}})
+
+	monitor.Started(ctx, first)
+	monitor.Started(ctx, second)
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(second)})
+
+	spans := sdk.Spans()
+	if len(spans) != 1 || spans[0].Name() != "find visits" {
+		t.Fatalf("ended spans = %d, want only find visits", len(spans))
+	}
+
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(first)})
+
+	if spans := sdk.Spans(); len(spans) != 2 || spans[1].Name() != "find patients" {
+		t.Errorf("ended spans = %d, want find patients ended second", len(spans))
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
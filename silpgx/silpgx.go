@@ -0,0 +1,224 @@
+// Package silpgx instruments pgx connections and pgxpool pools with the
+// providers configured by silgotel. It lives in its own module so that
+// services not using pgx do not depend on the instrumentation.
+package silpgx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// scope names the tracer and meter of the integration.
+	scope = "github.com/savannahghi/sil-gotel/silpgx"
+
+	// dbSystemKey is the db.system span attribute, matching silsql.
+	dbSystemKey = attribute.Key("db.system")
+
+	// RowsAffectedKey is the span attribute holding the number of rows a
+	// statement returned or changed.
+	RowsAffectedKey = attribute.Key("db.rows_affected")
+)
+
+// Tracer traces pgx queries, batches, connects and pool acquisitions as
+// client spans on the client's tracer provider. It implements
+// pgx.QueryTracer, pgx.BatchTracer, pgx.ConnectTracer and
+// pgxpool.AcquireTracer and is installed as the ConnConfig.Tracer.
+// Statements are recorded as db.query.text after silgotel.SanitizeQuery
+// strips their inlined literals; their arguments never are recorded.
+type Tracer struct {
+	tracer otelTrace.Tracer
+}
+
+var (
+	_ pgx.QueryTracer       = (*Tracer)(nil)
+	_ pgx.BatchTracer       = (*Tracer)(nil)
+	_ pgx.ConnectTracer     = (*Tracer)(nil)
+	_ pgxpool.AcquireTracer = (*Tracer)(nil)
+)
+
+// NewTracer returns a Tracer using the client's tracer provider.
+//
+//	config.ConnConfig.Tracer = silpgx.NewTracer(otelClient)
+func NewTracer(client *silgotel.Client) *Tracer {
+	return &Tracer{tracer: client.Tracer(scope)}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *Tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.start(ctx, "pgx.query", conn, semconv.DBQueryText(silgotel.SanitizeQuery(data.SQL))) //nolint:spancheck
+
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := otelTrace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(RowsAffectedKey.Int64(data.CommandTag.RowsAffected()))
+
+	if data.Err != nil {
+		silgotel.CaptureTraceStatusAndError(span, data.Err)
+	}
+}
+
+// TraceBatchStart implements pgx.BatchTracer.
+func (t *Tracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = t.start(ctx, "pgx.batch", conn, semconv.DBOperationBatchSize(data.Batch.Len())) //nolint:spancheck
+
+	return ctx
+}
+
+// TraceBatchQuery implements pgx.BatchTracer by adding an event for each
+// query of the batch to the batch span.
+func (t *Tracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attrs := []attribute.KeyValue{
+		semconv.DBQueryText(silgotel.SanitizeQuery(data.SQL)),
+		RowsAffectedKey.Int64(data.CommandTag.RowsAffected()),
+	}
+
+	if data.Err != nil {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", data.Err)))
+	}
+
+	otelTrace.SpanFromContext(ctx).AddEvent("query", otelTrace.WithAttributes(attrs...))
+}
+
+// TraceBatchEnd implements pgx.BatchTracer.
+func (t *Tracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	end(ctx, data.Err)
+}
+
+// TraceConnectStart implements pgx.ConnectTracer.
+func (t *Tracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "pgx.connect", //nolint:spancheck
+		otelTrace.WithSpanKind(otelTrace.SpanKindClient),
+		otelTrace.WithAttributes(
+			dbSystemKey.String("postgresql"),
+			semconv.DBNamespace(data.ConnConfig.Database),
+			semconv.ServerAddress(data.ConnConfig.Host),
+			semconv.ServerPort(int(data.ConnConfig.Port)),
+		),
+	)
+
+	return ctx
+}
+
+// TraceConnectEnd implements pgx.ConnectTracer.
+func (t *Tracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	end(ctx, data.Err)
+}
+
+// TraceAcquireStart implements pgxpool.AcquireTracer.
+func (t *Tracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "pgx.acquire", //nolint:spancheck
+		otelTrace.WithSpanKind(otelTrace.SpanKindClient),
+		otelTrace.WithAttributes(dbSystemKey.String("postgresql")),
+	)
+
+	return ctx
+}
+
+// TraceAcquireEnd implements pgxpool.AcquireTracer.
+func (t *Tracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	end(ctx, data.Err)
+}
+
+// start starts a client span for an operation on conn.
+//
+//nolint:ireturn
+func (t *Tracer) start(
+	ctx context.Context,
+	name string,
+	conn *pgx.Conn,
+	attrs ...attribute.KeyValue,
+) (context.Context, otelTrace.Span) {
+	attrs = append(attrs, dbSystemKey.String("postgresql"))
+
+	if conn != nil {
+		config := conn.Config()
+		attrs = append(attrs,
+			semconv.DBNamespace(config.Database),
+			semconv.ServerAddress(config.Host),
+			semconv.ServerPort(int(config.Port)),
+		)
+	}
+
+	return t.tracer.Start(ctx, name, //nolint:spancheck
+		otelTrace.WithSpanKind(otelTrace.SpanKindClient),
+		otelTrace.WithAttributes(attrs...),
+	)
+}
+
+// end records err on the span in ctx and ends it.
+func end(ctx context.Context, err error) {
+	span := otelTrace.SpanFromContext(ctx)
+	defer span.End()
+
+	if err != nil {
+		silgotel.CaptureTraceStatusAndError(span, err)
+	}
+}
+
+// NewPool creates a pgxpool pool for connString whose queries, batches,
+// connects and acquisitions are traced by a Tracer and whose stats are
+// reported through RegisterPoolStats.
+//
+//	pool, err := silpgx.NewPool(ctx, otelClient, os.Getenv("DATABASE_URL"))
+func NewPool(ctx context.Context, client *silgotel.Client, connString string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+
+	config.ConnConfig.Tracer = NewTracer(client)
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RegisterPoolStats(client, pool)
+	if err != nil {
+		pool.Close()
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// RegisterPoolStats reports the acquired, idle and maximum connections of
+// pool as observable gauges on the client's meter provider. The gauges are
+// removed when the client shuts down.
+func RegisterPoolStats(client *silgotel.Client, pool *pgxpool.Pool) error {
+	meter := client.Meter(scope)
+
+	for _, gauge := range []struct {
+		name, desc string
+		value      func(*pgxpool.Stat) int32
+	}{
+		{"db.client.connections.acquired", "Connections currently acquired from the pool", (*pgxpool.Stat).AcquiredConns},
+		{"db.client.connections.idle", "Idle connections in the pool", (*pgxpool.Stat).IdleConns},
+		{"db.client.connections.max", "Maximum connections allowed in the pool", (*pgxpool.Stat).MaxConns},
+	} {
+		_, err := client.RegisterGauge(meter, gauge.name, "{connection}", gauge.desc,
+			func(context.Context) (float64, []attribute.KeyValue) {
+				return float64(gauge.value(pool.Stat())), []attribute.KeyValue{dbSystemKey.String("postgresql")}
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
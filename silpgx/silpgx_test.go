@@ -0,0 +1,179 @@
+package silpgx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/savannahghi/sil-gotel/silpgx"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+// The hooks are driven the way pgx calls them, so no server is needed.
+
+func TestTracerQuery(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status codes.Code
+	}{
+		"success": {status: codes.Unset},
+		"failure": {err: errors.New("relation does not exist"), status: codes.Error},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, sdk := siltest.NewTestClient(t)
+			tracer := silpgx.NewTracer(client)
+
+			ctx := tracer.TraceQueryStart(t.Context(), nil, pgx.TraceQueryStartData{
+				SQL:  "SELECT * FROM patients WHERE name = 'Jane' AND age > 30",
+				Args: []any{"secret"},
+			})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+				CommandTag: pgconn.NewCommandTag("SELECT 2"),
+				Err:        tt.err,
+			})
+
+			span := sdk.Spans()[0]
+			if span.Name() != "pgx.query" || span.Status().Code != tt.status {
+				t.Errorf("span %q status = %v, want pgx.query with %v", span.Name(), span.Status(), tt.status)
+			}
+
+			got := attrs(span)
+			for key, want := range map[string]string{
+				"db.system":        "postgresql",
+				"db.query.text":    "SELECT * FROM patients WHERE name = ? AND age > ?",
+				"db.rows_affected": "2",
+			} {
+				if got[key] != want {
+					t.Errorf("%s = %q, want %q", key, got[key], want)
+				}
+			}
+
+			sdk.CheckSpanLeaks(t)
+		})
+	}
+}
+
+func TestTracerBatch(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	tracer := silpgx.NewTracer(client)
+
+	batch := &pgx.Batch{}
+	batch.Queue("UPDATE visits SET closed = true WHERE id = 7")
+	batch.Queue("DELETE FROM visits WHERE id = 8")
+
+	ctx := tracer.TraceBatchStart(t.Context(), nil, pgx.TraceBatchStartData{Batch: batch})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{
+		SQL:        "UPDATE visits SET closed = true WHERE id = 7",
+		CommandTag: pgconn.NewCommandTag("UPDATE 1"),
+	})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{
+		SQL: "DELETE FROM visits WHERE id = 8",
+		Err: &pgconn.PgError{Code: "23503"},
+	})
+	tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{})
+
+	span := sdk.Spans()[0]
+	if span.Name() != "pgx.batch" || attrs(span)["db.operation.batch.size"] != "2" {
+		t.Errorf("span %q batch size = %q, want pgx.batch of 2", span.Name(), attrs(span)["db.operation.batch.size"])
+	}
+
+	events := span.Events()
+	if len(events) != 2 {
+		t.Fatalf("span has %d events, want one per query", len(events))
+	}
+
+	var errorType string
+
+	for _, kv := range events[1].Attributes {
+		if kv.Key == "error.type" {
+			errorType = kv.Value.AsString()
+		}
+	}
+
+	if errorType != "*pgconn.PgError" {
+		t.Errorf("failed query error.type = %q, want *pgconn.PgError", errorType)
+	}
+
+	if span.Status().Code != codes.Unset {
+		t.Errorf("span status = %v, want unset when the batch succeeds", span.Status())
+	}
+}
+
+func TestTracerConnect(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	tracer := silpgx.NewTracer(client)
+
+	config, err := pgx.ParseConfig("postgres://app@db.internal:5433/clinic")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	ctx := tracer.TraceConnectStart(t.Context(), pgx.TraceConnectStartData{ConnConfig: config})
+	tracer.TraceConnectEnd(ctx, pgx.TraceConnectEndData{Err: errors.New("connection refused")})
+
+	span := sdk.Spans()[0]
+	if span.Name() != "pgx.connect" || span.Status().Code != codes.Error {
+		t.Errorf("span %q status = %v, want a failed pgx.connect", span.Name(), span.Status())
+	}
+
+	got := attrs(span)
+	for key, want := range map[string]string{
+		"db.namespace":   "clinic",
+		"server.address": "db.internal",
+		"server.port":    "5433",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %q, want %q", key, got[key], want)
+		}
+	}
+}
+
+func TestNewPool(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	// The pool connects lazily, so no server is needed to read its stats.
+	pool, err := silpgx.NewPool(t.Context(), client, "postgres://app@127.0.0.1:1/clinic?pool_max_conns=3")
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+
+	t.Cleanup(pool.Close)
+
+	if _, ok := pool.Config().ConnConfig.Tracer.(*silpgx.Tracer); !ok {
+		t.Errorf("ConnConfig.Tracer = %T, want *silpgx.Tracer", pool.Config().ConnConfig.Tracer)
+	}
+
+	for name, want := range map[string]float64{
+		"db.client.connections.acquired": 0,
+		"db.client.connections.idle":     0,
+		"db.client.connections.max":      3,
+	} {
+		m, ok := sdk.Metric(name)
+		if !ok {
+			t.Errorf("%s was not recorded", name)
+
+			continue
+		}
+
+		points := m.Data.(metricdata.Gauge[float64]).DataPoints //nolint:forcetypeassert
+		if len(points) != 1 || points[0].Value != want {
+			t.Errorf("%s points = %v, want %v", name, points, want)
+		}
+	}
+}
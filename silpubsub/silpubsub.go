@@ -0,0 +1,116 @@
+// Package silpubsub propagates trace context across Google Cloud Pub/Sub with
+// the providers configured by silgotel. It lives in its own module so that
+// services not using Pub/Sub do not depend on the client library.
+package silpubsub
+
+import (
+	"context"
+
+	"cloud.google.com/go/pubsub/v2"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// scope names the tracer of the integration.
+const scope = "github.com/savannahghi/sil-gotel/silpubsub"
+
+// Publisher wraps a pubsub.Publisher so that every message is published in a
+// producer span whose context is injected into the message attributes.
+type Publisher struct {
+	publisher  *pubsub.Publisher
+	tracer     otelTrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewPublisher returns a Publisher publishing through p with the client's
+// providers.
+//
+//	publisher := silpubsub.NewPublisher(otelClient, pubsubClient.Publisher("orders"))
+func NewPublisher(client *silgotel.Client, p *pubsub.Publisher) *Publisher {
+	return &Publisher{
+		publisher:  p,
+		tracer:     client.Tracer(scope),
+		propagator: client.Propagator(),
+	}
+}
+
+// Publish starts a producer span named "<topic> send", injects its context
+// into the attributes of msg and hands msg to the wrapped publisher. As
+// publishing is batched in the background the span ends once msg is queued;
+// the returned result reports the outcome of the publish.
+func (p *Publisher) Publish(ctx context.Context, msg *pubsub.Message) *pubsub.PublishResult {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemGCPPubSub,
+		semconv.MessagingOperationTypeSend,
+		semconv.MessagingOperationName("send"),
+		semconv.MessagingDestinationName(p.publisher.ID()),
+		semconv.MessagingMessageBodySize(len(msg.Data)),
+	}
+
+	if msg.OrderingKey != "" {
+		attrs = append(attrs, semconv.MessagingGCPPubSubMessageOrderingKey(msg.OrderingKey))
+	}
+
+	ctx, span := p.tracer.Start(ctx, p.publisher.ID()+" send",
+		otelTrace.WithSpanKind(otelTrace.SpanKindProducer),
+		otelTrace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	if msg.Attributes == nil {
+		msg.Attributes = map[string]string{}
+	}
+
+	p.propagator.Inject(ctx, propagation.MapCarrier(msg.Attributes))
+
+	return p.publisher.Publish(ctx, msg)
+}
+
+// ReceiveHandler wraps f for pubsub.Subscriber.Receive so that every message
+// is handled in a consumer span named "<subscription> process". The span
+// continues the trace found in the message attributes and links to the
+// producer span. Acking and nacking stay with f.
+//
+//	err := sub.Receive(ctx, silpubsub.ReceiveHandler(otelClient, sub.ID(), handle))
+func ReceiveHandler(
+	client *silgotel.Client,
+	subscription string,
+	f func(context.Context, *pubsub.Message),
+) func(context.Context, *pubsub.Message) {
+	tracer := client.Tracer(scope)
+	propagator := client.Propagator()
+
+	return func(ctx context.Context, msg *pubsub.Message) {
+		ctx = propagator.Extract(ctx, propagation.MapCarrier(msg.Attributes))
+
+		attrs := []attribute.KeyValue{
+			semconv.MessagingSystemGCPPubSub,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingOperationName("process"),
+			semconv.MessagingDestinationSubscriptionName(subscription),
+			semconv.MessagingMessageID(msg.ID),
+			semconv.MessagingMessageBodySize(len(msg.Data)),
+		}
+
+		if msg.DeliveryAttempt != nil {
+			attrs = append(attrs, semconv.MessagingGCPPubSubMessageDeliveryAttempt(*msg.DeliveryAttempt))
+		}
+
+		opts := []otelTrace.SpanStartOption{
+			otelTrace.WithSpanKind(otelTrace.SpanKindConsumer),
+			otelTrace.WithAttributes(attrs...),
+		}
+
+		if producer := otelTrace.SpanContextFromContext(ctx); producer.IsValid() {
+			opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: producer}))
+		}
+
+		ctx, span := tracer.Start(ctx, subscription+" process", opts...)
+		defer span.End()
+
+		f(ctx, msg)
+	}
+}
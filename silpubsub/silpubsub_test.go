@@ -0,0 +1,204 @@
+package silpubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/silpubsub"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const project = "clinic"
+
+// newPubSub returns a Pub/Sub client of an in-process fake server holding
+// the orders topic and its orders-worker subscription.
+func newPubSub(t *testing.T) (*pubsub.Client, *pstest.Server) {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client, err := pubsub.NewClient(t.Context(), project, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("pubsub.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	topic, err := client.TopicAdminClient.CreateTopic(t.Context(), &pubsubpb.Topic{
+		Name: "projects/" + project + "/topics/orders",
+	})
+	if err != nil {
+		t.Fatalf("CreateTopic() error = %v", err)
+	}
+
+	_, err = client.SubscriptionAdminClient.CreateSubscription(t.Context(), &pubsubpb.Subscription{
+		Name:  "projects/" + project + "/subscriptions/orders-worker",
+		Topic: topic.GetName(),
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+
+	return client, srv
+}
+
+// receive handles the next message of the orders-worker subscription with
+// f wrapped by ReceiveHandler.
+func receive(t *testing.T, client *silgotel.Client, ps *pubsub.Client, f func(context.Context, *pubsub.Message)) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	sub := ps.Subscriber("orders-worker")
+
+	err := sub.Receive(ctx, silpubsub.ReceiveHandler(client, sub.ID(), func(ctx context.Context, msg *pubsub.Message) {
+		f(ctx, msg)
+		msg.Ack()
+		cancel()
+	}))
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+}
+
+// findSpan returns the ended span named name.
+func findSpan(t *testing.T, sdk *siltest.SDK, name string) trace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range sdk.Spans() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("no %q span was recorded", name)
+
+	return nil
+}
+
+// attrs returns the attributes of span as strings keyed by name.
+func attrs(span trace.ReadOnlySpan) map[string]string {
+	values := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		values[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return values
+}
+
+func TestPublisherAndReceiveHandler(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	ps, srv := newPubSub(t)
+
+	publisher := ps.Publisher("orders")
+	publisher.EnableMessageOrdering = true
+
+	t.Cleanup(publisher.Stop)
+
+	ctx, parent := client.Tracer("orders").Start(t.Context(), "POST /orders")
+
+	result := silpubsub.NewPublisher(client, publisher).Publish(ctx, &pubsub.Message{
+		Data:        []byte(`{"total":10}`),
+		OrderingKey: "patient-42",
+	})
+	if _, err := result.Get(t.Context()); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	parent.End()
+
+	send := findSpan(t, sdk, "orders send")
+	if send.SpanKind() != otelTrace.SpanKindProducer || send.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("send span kind = %v with parent %s, want a producer child of the request",
+			send.SpanKind(), send.Parent().SpanID())
+	}
+
+	for key, want := range map[string]string{
+		"messaging.system":                          "gcp_pubsub",
+		"messaging.destination.name":                "orders",
+		"messaging.gcp_pubsub.message.ordering_key": "patient-42",
+		"messaging.message.body.size":               "12",
+	} {
+		if got := attrs(send)[key]; got != want {
+			t.Errorf("send %s = %q, want %q", key, got, want)
+		}
+	}
+
+	msgs := srv.Messages()
+	if len(msgs) != 1 || msgs[0].Attributes["traceparent"] == "" {
+		t.Fatalf("published messages = %v, want one carrying traceparent", msgs)
+	}
+
+	var handled otelTrace.SpanContext
+
+	receive(t, client, ps, func(ctx context.Context, _ *pubsub.Message) {
+		handled = otelTrace.SpanContextFromContext(ctx)
+	})
+
+	process := findSpan(t, sdk, "orders-worker process")
+	if process.SpanContext().SpanID() != handled.SpanID() {
+		t.Errorf("handler span = %s, want the process span", handled.SpanID())
+	}
+
+	if process.Parent().SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span parent = %s, want the send span", process.Parent().SpanID())
+	}
+
+	if links := process.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != send.SpanContext().SpanID() {
+		t.Errorf("process span links = %v, want the send span", links)
+	}
+
+	for key, want := range map[string]string{
+		"messaging.operation.type":                "process",
+		"messaging.destination.subscription.name": "orders-worker",
+		"messaging.message.id":                    msgs[0].ID,
+	} {
+		if got := attrs(process)[key]; got != want {
+			t.Errorf("process %s = %q, want %q", key, got, want)
+		}
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
+
+func TestReceiveHandlerWithoutTraceContext(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+	ps, srv := newPubSub(t)
+
+	srv.Publish("projects/"+project+"/topics/orders", []byte("{}"), map[string]string{"tenant": "clinic-a"})
+
+	var attributes map[string]string
+
+	receive(t, client, ps, func(_ context.Context, msg *pubsub.Message) {
+		attributes = msg.Attributes
+	})
+
+	process := findSpan(t, sdk, "orders-worker process")
+	if process.Parent().IsValid() || len(process.Links()) != 0 {
+		t.Errorf("process span parent = %v with %d links, want a new root trace",
+			process.Parent(), len(process.Links()))
+	}
+
+	if attributes["tenant"] != "clinic-a" {
+		t.Errorf("handled attributes = %v, want the published ones", attributes)
+	}
+}
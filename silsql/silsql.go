@@ -0,0 +1,102 @@
+// Package silsql instruments database/sql connections with the providers
+// configured by silgotel. It lives in its own module so that services not
+// using database/sql do not depend on the instrumentation.
+package silsql
+
+import (
+	"database/sql"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// dbSystemKey is the db.system span and metric attribute.
+const dbSystemKey = attribute.Key("db.system")
+
+// driverSystems maps well known driver names to their db.system value.
+//
+//nolint:gochecknoglobals
+var driverSystems = map[string]string{
+	"postgres": "postgresql",
+	"pgx":      "postgresql",
+	"sqlite3":  "sqlite",
+	"sqlite":   "sqlite",
+	"mysql":    "mysql",
+}
+
+type dbConfig struct {
+	system    string
+	name      string
+	sanitizer func(string) string
+}
+
+// DBOption configures OpenDB.
+type DBOption func(*dbConfig)
+
+// WithDBSystem overrides the db.system attribute derived from the driver name.
+func WithDBSystem(system string) DBOption {
+	return func(c *dbConfig) {
+		c.system = system
+	}
+}
+
+// WithDBName sets the db.name attribute.
+func WithDBName(name string) DBOption {
+	return func(c *dbConfig) {
+		c.name = name
+	}
+}
+
+// WithQuerySanitizer replaces SanitizeQuery as the function applied to
+// statements before they are recorded as db.statement.
+func WithQuerySanitizer(sanitizer func(string) string) DBOption {
+	return func(c *dbConfig) {
+		c.sanitizer = sanitizer
+	}
+}
+
+// OpenDB opens a database whose queries produce spans and latency metrics on
+// the client's providers and whose connection pool stats are reported as
+// observable gauges. Statements are sanitized before being recorded and the
+// DSN, along with any credentials it holds, is never recorded.
+func OpenDB(client *silgotel.Client, driverName, dsn string, opts ...DBOption) (*sql.DB, error) {
+	cfg := &dbConfig{
+		system:    driverSystems[driverName],
+		sanitizer: SanitizeQuery,
+	}
+
+	if cfg.system == "" {
+		cfg.system = driverName
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	attrs := []attribute.KeyValue{dbSystemKey.String(cfg.system)}
+	if cfg.name != "" {
+		attrs = append(attrs, attribute.String("db.name", cfg.name))
+	}
+
+	otelOpts := []otelsql.Option{
+		otelsql.WithTracerProvider(client.TracerProvider()),
+		otelsql.WithMeterProvider(client.MeterProvider()),
+		otelsql.WithAttributes(attrs...),
+		otelsql.WithQueryFormatter(cfg.sanitizer),
+	}
+
+	db, err := otelsql.Open(driverName, dsn, otelOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	otelsql.ReportDBStatsMetrics(db, otelOpts...)
+
+	return db, nil
+}
+
+// SanitizeQuery is silgotel.SanitizeQuery, the default sanitizer of OpenDB.
+func SanitizeQuery(query string) string {
+	return silgotel.SanitizeQuery(query)
+}
@@ -0,0 +1,242 @@
+// Package siltest provides an in-memory OpenTelemetry harness for testing
+// code instrumented with silgotel. It needs no network and no OTLPBaseURL.
+package siltest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// SDK records every span, metric and log emitted through the global
+// providers, or through the Client of NewTestClient, while a test runs.
+type SDK struct {
+	t      testing.TB
+	client *silgotel.Client
+
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+	logs   *LogExporter
+	leaks  *silgotel.LeakDetector
+
+	TracerProvider *trace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+	LoggerProvider *log.LoggerProvider
+}
+
+// NewTestSDK installs in-memory trace, metric and log providers as the
+// globals and restores the previous globals when the test finishes.
+//
+//	sdk := siltest.NewTestSDK(t)
+//	ctx, span := silgotel.Trace(ctx, "pkg", "op")
+//	span.End()
+//	spans := sdk.Spans()
+func NewTestSDK(t testing.TB) *SDK {
+	t.Helper()
+
+	sdk := &SDK{
+		t:      t,
+		spans:  tracetest.NewSpanRecorder(),
+		reader: sdkmetric.NewManualReader(),
+		logs:   &LogExporter{},
+		leaks:  silgotel.NewLeakDetector(),
+	}
+
+	sdk.TracerProvider = trace.NewTracerProvider(
+		trace.WithSpanProcessor(silgotel.NewCtxAttributesProcessor()),
+		trace.WithSpanProcessor(sdk.spans),
+		trace.WithSpanProcessor(sdk.leaks),
+	)
+	sdk.MeterProvider = sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdk.reader),
+		silgotel.WithHTTPViews(),
+	)
+	sdk.LoggerProvider = log.NewLoggerProvider(log.WithProcessor(log.NewSimpleProcessor(sdk.logs)))
+
+	prevTracerProvider := otel.GetTracerProvider()
+	prevMeterProvider := otel.GetMeterProvider()
+	prevLoggerProvider := global.GetLoggerProvider()
+
+	otel.SetTracerProvider(sdk.TracerProvider)
+	otel.SetMeterProvider(sdk.MeterProvider)
+	global.SetLoggerProvider(sdk.LoggerProvider)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTracerProvider)
+		otel.SetMeterProvider(prevMeterProvider)
+		global.SetLoggerProvider(prevLoggerProvider)
+
+		ctx := context.Background()
+
+		_ = sdk.TracerProvider.Shutdown(ctx)
+		_ = sdk.MeterProvider.Shutdown(ctx)
+		_ = sdk.LoggerProvider.Shutdown(ctx)
+	})
+
+	return sdk
+}
+
+// NewTestClient starts the SDK for a Client with silgotel.NewOtelSDK and
+// records its spans, metrics and logs like NewTestSDK, for instrumentation
+// that takes a *silgotel.Client rather than using the globals. The Client
+// exports to an in-process collector, registers no globals and is shut down
+// when the test finishes. opts are passed to NewOtelSDK.
+//
+//	client, sdk := siltest.NewTestClient(t, silgotel.WithIgnoredPaths("/healthz"))
+//	router.Use(silgin.Middleware(client)...)
+func NewTestClient(t testing.TB, opts ...silgotel.Option) (*silgotel.Client, *SDK) {
+	t.Helper()
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := &silgotel.Client{
+		OTLPBaseURL: collector.URL,
+		ServiceName: "test-service",
+		Environment: "test",
+		Version:     "1.0.0",
+		Sampler:     "always_on",
+	}
+
+	sdk := &SDK{
+		t:      t,
+		client: client,
+		spans:  tracetest.NewSpanRecorder(),
+		reader: sdkmetric.NewManualReader(),
+		logs:   &LogExporter{},
+		leaks:  silgotel.NewLeakDetector(),
+	}
+
+	opts = append([]silgotel.Option{
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithSpanProcessor(sdk.spans),
+		silgotel.WithSpanProcessor(sdk.leaks),
+		silgotel.WithAdditionalMetricReader(sdk.reader),
+		silgotel.WithAdditionalLogExporter(sdk.logs),
+	}, opts...)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client, opts...)
+	if err != nil {
+		t.Fatalf("siltest: NewOtelSDK() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+
+	sdk.TracerProvider, _ = client.TracerProvider().(*trace.TracerProvider)
+	sdk.MeterProvider, _ = client.MeterProvider().(*sdkmetric.MeterProvider)
+	sdk.LoggerProvider, _ = client.LoggerProvider().(*log.LoggerProvider)
+
+	return client, sdk
+}
+
+// Spans returns the spans that have ended so far.
+func (s *SDK) Spans() []trace.ReadOnlySpan {
+	return s.spans.Ended()
+}
+
+// CheckSpanLeaks fails t for every span started but not yet ended, naming
+// the file:line that started it. Call it once the code under test returned.
+func (s *SDK) CheckSpanLeaks(t testing.TB) {
+	t.Helper()
+
+	for _, leak := range s.leaks.Leaks() {
+		t.Errorf("siltest: %s", leak)
+	}
+}
+
+// Metrics collects and returns the current metric data.
+func (s *SDK) Metrics() metricdata.ResourceMetrics {
+	s.t.Helper()
+
+	var rm metricdata.ResourceMetrics
+
+	err := s.reader.Collect(context.Background(), &rm)
+	if err != nil {
+		s.t.Fatalf("siltest: collecting metrics: %v", err)
+	}
+
+	return rm
+}
+
+// Metric collects the current metric data and returns the metric named
+// name, reporting whether it was recorded.
+func (s *SDK) Metric(name string) (metricdata.Metrics, bool) {
+	s.t.Helper()
+
+	for _, sm := range s.Metrics().ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+
+	return metricdata.Metrics{}, false
+}
+
+// Logs returns the log records emitted so far. The batched log export of a
+// Client from NewTestClient is flushed first.
+func (s *SDK) Logs() []log.Record {
+	if s.client != nil {
+		err := s.client.ForceFlush(context.Background())
+		if err != nil {
+			s.t.Fatalf("siltest: flushing logs: %v", err)
+		}
+	}
+
+	return s.logs.Records()
+}
+
+// LogExporter is a log.Exporter that keeps exported records in memory.
+type LogExporter struct {
+	mu      sync.Mutex
+	records []log.Record
+}
+
+// Export stores a copy of each record.
+func (e *LogExporter) Export(_ context.Context, records []log.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+
+	return nil
+}
+
+// Shutdown is a no-op.
+func (e *LogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush is a no-op.
+func (e *LogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Records returns a copy of the stored records.
+func (e *LogExporter) Records() []log.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]log.Record, len(e.records))
+	copy(out, e.records)
+
+	return out
+}
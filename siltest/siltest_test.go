@@ -0,0 +1,176 @@
+package siltest_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSpanName(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	_, span := silgotel.Trace(t.Context(), "checkout", "charge card")
+	span.End()
+
+	spans := sdk.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	if name := spans[0].Name(); name != "charge card" {
+		t.Errorf("span name = %q, want %q", name, "charge card")
+	}
+}
+
+func TestCounterValue(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	counter, err := silgotel.Meter("checkout").Int64Counter("orders.placed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(t.Context(), 2)
+	counter.Add(t.Context(), 3)
+
+	var total int64
+
+	for _, sm := range sdk.Metrics().ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "orders.placed" {
+				continue
+			}
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("orders.placed data = %T, want a sum", m.Data)
+			}
+
+			for _, point := range sum.DataPoints {
+				total += point.Value
+			}
+		}
+	}
+
+	if total != 5 {
+		t.Errorf("orders.placed = %d, want 5", total)
+	}
+}
+
+func TestLogSeverity(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	silgotel.LogWarn(t.Context(), "checkout", "card declined", "order.id", "42")
+
+	logs := sdk.Logs()
+	if len(logs) != 1 {
+		t.Fatalf("recorded %d logs, want 1", len(logs))
+	}
+
+	if severity := logs[0].Severity(); severity != otelLog.SeverityWarn {
+		t.Errorf("severity = %v, want %v", severity, otelLog.SeverityWarn)
+	}
+
+	if body := logs[0].Body().AsString(); body != "card declined" {
+		t.Errorf("body = %q, want %q", body, "card declined")
+	}
+}
+
+// recordingTB records the errors reported to it instead of failing the test.
+type recordingTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckSpanLeaks(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	_, ended := silgotel.Trace(context.Background(), "checkout", "ended")
+	ended.End()
+
+	_, file, line, _ := runtime.Caller(0)
+	_, span := silgotel.Trace(context.Background(), "checkout", "leaked")
+
+	probe := &recordingTB{TB: t}
+	sdk.CheckSpanLeaks(probe)
+
+	site := fmt.Sprintf("%s:%d", file, line+1)
+
+	if len(probe.errors) != 1 || !strings.Contains(probe.errors[0], `"leaked"`) || !strings.Contains(probe.errors[0], site) {
+		t.Errorf("CheckSpanLeaks() reported %q, want only the leaked span started at %s", probe.errors, site)
+	}
+
+	span.End()
+
+	probe = &recordingTB{TB: t}
+	sdk.CheckSpanLeaks(probe)
+
+	if len(probe.errors) != 0 {
+		t.Errorf("CheckSpanLeaks() reported %q after the span ended, want nothing", probe.errors)
+	}
+}
+
+func TestNewTestSDKRestoresGlobals(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	t.Run("harness", func(t *testing.T) {
+		sdk := siltest.NewTestSDK(t)
+
+		if otel.GetTracerProvider() != sdk.TracerProvider {
+			t.Error("NewTestSDK() did not install its tracer provider")
+		}
+	})
+
+	if otel.GetTracerProvider() != previous {
+		t.Error("the previous tracer provider was not restored")
+	}
+}
+
+func TestNewTestClient(t *testing.T) {
+	client, sdk := siltest.NewTestClient(t)
+
+	if otel.GetTracerProvider() == sdk.TracerProvider {
+		t.Error("NewTestClient registered its tracer provider globally")
+	}
+
+	_, span := client.Tracer("checkout").Start(t.Context(), "charge card")
+	span.End()
+
+	counter, err := client.Meter("checkout").Int64Counter("charges")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+
+	counter.Add(t.Context(), 1)
+
+	var record otelLog.Record
+	record.SetBody(otelLog.StringValue("card charged"))
+	client.Logger("checkout").Emit(t.Context(), record)
+
+	if spans := sdk.Spans(); len(spans) != 1 || spans[0].Name() != "charge card" {
+		t.Errorf("spans = %v, want the client's charge card span", spans)
+	}
+
+	if _, ok := sdk.Metric("charges"); !ok {
+		t.Error("charges was not recorded")
+	}
+
+	if logs := sdk.Logs(); len(logs) != 1 || logs[0].Body().AsString() != "card charged" {
+		t.Errorf("logs = %v, want the client's record", logs)
+	}
+
+	sdk.CheckSpanLeaks(t)
+}
@@ -0,0 +1,63 @@
+// Package silzap bridges zap loggers to the logger provider configured by
+// silgotel, for services that have not moved to slog. It lives in its own
+// module so that services not using zap do not depend on it.
+package silzap
+
+import (
+	"context"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	otelTrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCore returns a zapcore.Core that emits every entry as an OTel log
+// record on the client's logger provider, scoped to the client's service
+// name. Zap fields become log attributes with their native types: strings,
+// integers and booleans as is, durations in nanoseconds and errors as their
+// message. The trace context is taken from a context.Context field, if any.
+//
+//nolint:ireturn
+func NewCore(client *silgotel.Client) zapcore.Core {
+	return otelzap.NewCore(client.ServiceName,
+		otelzap.WithLoggerProvider(client.LoggerProvider()),
+		otelzap.WithVersion(client.Version),
+		otelzap.WithSchemaURL(semconv.SchemaURL),
+	)
+}
+
+// WrapLogger returns a copy of logger that writes to its existing core and
+// to the core from NewCore, so console output is kept while the records
+// also reach the OTLP pipeline.
+func WrapLogger(client *silgotel.Client, logger *zap.Logger) *zap.Logger {
+	otelCore := NewCore(client)
+
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, otelCore)
+	}))
+}
+
+// TraceFields returns the fields correlating an entry with the span in ctx:
+// the context itself, which the core from NewCore uses for the record's
+// trace context and other encoders skip, and the trace_id, span_id and
+// trace_flags fields that NewCorrelationHandler adds to slog records. Only
+// the context field is returned when ctx carries no valid span.
+//
+//	logger.Info("order placed", silzap.TraceFields(ctx)...)
+func TraceFields(ctx context.Context) []zap.Field {
+	fields := []zap.Field{{Key: "context", Type: zapcore.SkipType, Interface: ctx}}
+
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		fields = append(fields,
+			zap.String(silgotel.TraceIDKey, sc.TraceID().String()),
+			zap.String(silgotel.SpanIDKey, sc.SpanID().String()),
+			zap.String(silgotel.TraceFlagsKey, sc.TraceFlags().String()),
+		)
+	}
+
+	return fields
+}
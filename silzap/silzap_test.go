@@ -0,0 +1,152 @@
+package silzap_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"github.com/savannahghi/sil-gotel/silzap"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// startSDK starts the SDK for a client exporting to a fake collector and
+// returns the client along with an in-memory log exporter.
+func startSDK(t *testing.T) (*silgotel.Client, *siltest.LogExporter) {
+	t.Helper()
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	client := &silgotel.Client{
+		OTLPBaseURL: collector.URL,
+		ServiceName: "test-service",
+		Environment: "test",
+		Version:     "1.0.0",
+	}
+
+	logs := &siltest.LogExporter{}
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithAdditionalLogExporter(logs),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+
+	return client, logs
+}
+
+// exported flushes client and returns the records exported so far.
+func exported(t *testing.T, client *silgotel.Client, logs *siltest.LogExporter) []log.Record {
+	t.Helper()
+
+	if err := client.ForceFlush(t.Context()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	return logs.Records()
+}
+
+// attrs returns the attributes of record by key.
+func attrs(record log.Record) map[string]otelLog.Value {
+	values := make(map[string]otelLog.Value)
+
+	record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		values[kv.Key] = kv.Value
+
+		return true
+	})
+
+	return values
+}
+
+func TestNewCore(t *testing.T) {
+	client, logs := startSDK(t)
+
+	ctx, span := client.Tracer("silzap").Start(t.Context(), "charge")
+	defer span.End()
+
+	logger := zap.New(silzap.NewCore(client))
+	logger.Error("charge failed", append(silzap.TraceFields(ctx),
+		zap.String("order.id", "o-1"),
+		zap.Int("attempt", 2),
+		zap.Bool("retryable", true),
+		zap.Duration("elapsed", 1500*time.Millisecond),
+		zap.Error(errors.New("card declined")),
+	)...)
+
+	records := exported(t, client, logs)
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+
+	record := records[0]
+
+	if record.Severity() != otelLog.SeverityError || record.Body().AsString() != "charge failed" {
+		t.Errorf("record = %v %q, want the error entry", record.Severity(), record.Body().AsString())
+	}
+
+	if record.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("record span ID = %v, want the span from the context field", record.SpanID())
+	}
+
+	got := attrs(record)
+
+	if got["order.id"].AsString() != "o-1" || got["attempt"].AsInt64() != 2 || !got["retryable"].AsBool() {
+		t.Errorf("attributes = %v, want order.id, attempt and retryable with their types", got)
+	}
+
+	if got["elapsed"].AsInt64() != int64(1500*time.Millisecond) {
+		t.Errorf("elapsed = %v, want the duration in nanoseconds", got["elapsed"])
+	}
+
+	if got["error"].AsString() != "card declined" {
+		t.Errorf("error = %v, want the error message", got["error"])
+	}
+
+	if got[silgotel.TraceIDKey].AsString() != span.SpanContext().TraceID().String() {
+		t.Errorf("%s = %v, want the span's trace ID", silgotel.TraceIDKey, got[silgotel.TraceIDKey])
+	}
+
+	if _, ok := got["context"]; ok {
+		t.Error("the context field was exported as an attribute")
+	}
+}
+
+func TestWrapLogger(t *testing.T) {
+	client, logs := startSDK(t)
+
+	console, entries := observer.New(zapcore.InfoLevel)
+	logger := silzap.WrapLogger(client, zap.New(console))
+
+	logger.Warn("stock low", zap.String("sku", "A-1"))
+
+	if entries.Len() != 1 || entries.All()[0].Message != "stock low" {
+		t.Errorf("console entries = %v, want the warning kept", entries.All())
+	}
+
+	records := exported(t, client, logs)
+	if len(records) != 1 || records[0].Severity() != otelLog.SeverityWarn {
+		t.Fatalf("exported records = %v, want one warning", records)
+	}
+
+	if sku := attrs(records[0])["sku"]; sku.AsString() != "A-1" {
+		t.Errorf("sku = %v, want A-1", sku)
+	}
+}
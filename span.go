@@ -0,0 +1,189 @@
+package silgotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// AddSpanEvent records a timestamped milestone such as "cache miss" on the
+// span held in ctx. It is a no-op when the span is not recording.
+func AddSpanEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	span := otelTrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent(name,
+		otelTrace.WithTimestamp(time.Now()),
+		otelTrace.WithAttributes(attrs...),
+	)
+}
+
+// AddSpanAttributes sets attributes on the span held in ctx. It is a no-op
+// when the span is not recording.
+func AddSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	span := otelTrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(attrs...)
+}
+
+// GetTraceID returns the hex trace ID of the span context held in ctx, or an
+// empty string when there is no valid span context.
+func GetTraceID(ctx context.Context) string {
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+
+	return sc.TraceID().String()
+}
+
+// GetSpanID returns the hex span ID of the span context held in ctx, or an
+// empty string when there is no valid span context.
+func GetSpanID(ctx context.Context) string {
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if !sc.HasSpanID() {
+		return ""
+	}
+
+	return sc.SpanID().String()
+}
+
+// LinkFromContext returns a link to the span context held in ctx, typically
+// a context returned by ExtractMap or ExtractHTTPHeaders.
+func LinkFromContext(ctx context.Context, attrs ...attribute.KeyValue) otelTrace.Link {
+	return otelTrace.Link{
+		SpanContext: otelTrace.SpanContextFromContext(ctx),
+		Attributes:  attrs,
+	}
+}
+
+// TraceWithLinks starts a new span carrying links, for batch and fan-in work
+// that processes items from many producers. Links with an invalid span
+// context are skipped.
+//
+//nolint:ireturn
+func TraceWithLinks(
+	ctx context.Context,
+	packageName, spanName string,
+	links []otelTrace.Link,
+) (context.Context, otelTrace.Span) {
+	valid := make([]otelTrace.Link, 0, len(links))
+
+	for _, link := range links {
+		if link.SpanContext.IsValid() {
+			valid = append(valid, link)
+		}
+	}
+
+	return cachedTracer(packageName).Start( //nolint:spancheck
+		ctx, spanName, otelTrace.WithLinks(valid...), ctxAttributesOption(ctx),
+	)
+}
+
+// WithSpan runs fn inside a span named spanName on the tracer for
+// packageName and returns its error. The span is ended when fn returns, with
+// a non-nil error recorded on it through CaptureTraceStatusAndError. A panic
+// in fn is recorded on the span like RecoverAndRepanic does and propagated
+// once the span is ended.
+//
+//	err := silgotel.WithSpan(ctx, "github.com/acme/orders", "reserveStock",
+//		func(ctx context.Context) error {
+//			return inventory.Reserve(ctx, order)
+//		})
+//
+//nolint:nonamedreturns
+func WithSpan(ctx context.Context, packageName, spanName string, fn func(context.Context) error) (err error) {
+	ctx, end := StartSpan(ctx, packageName, spanName)
+
+	defer func() {
+		if v := recover(); v != nil {
+			recordPanic(ctx, v)
+			end(nil)
+			panic(v)
+		}
+
+		end(err)
+	}()
+
+	return fn(ctx)
+}
+
+// StartSpan starts a span like Trace and returns, instead of the span, a
+// function recording err on it, when not nil, and ending it. Call it exactly
+// once, typically deferred with the named error result of the caller.
+//
+//	ctx, end := silgotel.StartSpan(ctx, "github.com/acme/orders", "reserveStock")
+//	defer func() { end(err) }()
+//
+//nolint:nonamedreturns
+func StartSpan(ctx context.Context, packageName, spanName string) (_ context.Context, end func(error)) {
+	ctx, span := Trace(ctx, packageName, spanName) //nolint:spancheck
+
+	return ctx, func(err error) {
+		CaptureTraceStatusAndError(span, err)
+		span.End()
+	}
+}
+
+// WatchContext records on span why ctx ends. The remaining time before the
+// deadline, if any, is set as context.deadline.remaining in seconds, and when
+// ctx is done a context.done event is added with context.cancel.reason
+// ("deadline_exceeded" or "canceled") and context.cancel.cause from
+// context.Cause. Call the returned stop function when the span ends; no
+// goroutine runs until ctx is done, so nothing leaks if it never is.
+//
+//nolint:nonamedreturns
+func WatchContext(ctx context.Context, span otelTrace.Span) (stop func() bool) {
+	if deadline, ok := ctx.Deadline(); ok {
+		span.SetAttributes(attribute.Float64("context.deadline.remaining", time.Until(deadline).Seconds()))
+	}
+
+	return context.AfterFunc(ctx, func() {
+		reason := "canceled"
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			reason = "deadline_exceeded"
+		}
+
+		attrs := []attribute.KeyValue{attribute.String("context.cancel.reason", reason)}
+		if cause := context.Cause(ctx); cause != nil {
+			attrs = append(attrs, attribute.String("context.cancel.cause", cause.Error()))
+		}
+
+		span.AddEvent("context.done", otelTrace.WithAttributes(attrs...))
+	})
+}
+
+// SetSpanStatusFromHTTPStatus sets http.response.status_code on span and its
+// status following the HTTP semantic conventions: for server spans only 5xx
+// responses are errors, while for client spans 4xx responses are errors too.
+// Other responses leave the status unset. Codes outside 100-599 are always
+// errors.
+func SetSpanStatusFromHTTPStatus(span otelTrace.Span, kind otelTrace.SpanKind, statusCode int) {
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+
+	if statusCode < 100 || statusCode > 599 {
+		span.SetStatus(codes.Error, fmt.Sprintf("invalid HTTP status code %d", statusCode))
+
+		return
+	}
+
+	threshold := http.StatusInternalServerError
+	if kind == otelTrace.SpanKindClient {
+		threshold = http.StatusBadRequest
+	}
+
+	if statusCode >= threshold {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
@@ -0,0 +1,285 @@
+package silgotel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"github.com/savannahghi/sil-gotel/siltest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestAddSpanEvent(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	before := time.Now()
+
+	ctx, span := silgotel.Trace(t.Context(), "cache", "lookup")
+	silgotel.AddSpanEvent(ctx, "cache miss", attribute.String("cache.key", "user:1"))
+	span.End()
+
+	events := sdk.Spans()[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("span has %d events, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.Name != "cache miss" {
+		t.Errorf("event name = %q, want %q", event.Name, "cache miss")
+	}
+
+	if len(event.Attributes) != 1 || event.Attributes[0] != attribute.String("cache.key", "user:1") {
+		t.Errorf("event attributes = %v, want cache.key=user:1", event.Attributes)
+	}
+
+	if event.Time.Before(before) || event.Time.After(time.Now()) {
+		t.Errorf("event time = %v, want the time AddSpanEvent was called", event.Time)
+	}
+}
+
+func TestAddSpanEventWithoutSpan(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	silgotel.AddSpanEvent(t.Context(), "cache miss")
+
+	if spans := sdk.Spans(); len(spans) != 0 {
+		t.Errorf("recorded %d spans, want none", len(spans))
+	}
+}
+
+func TestAddSpanAttributes(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "cache", "lookup")
+	silgotel.AddSpanAttributes(ctx, attribute.Bool("cache.hit", true))
+	span.End()
+
+	attrs := attribute.NewSet(sdk.Spans()[0].Attributes()...)
+	if value, ok := attrs.Value("cache.hit"); !ok || !value.AsBool() {
+		t.Errorf("span attributes = %v, want cache.hit=true", attrs.ToSlice())
+	}
+}
+
+func TestGetTraceIDAndSpanID(t *testing.T) {
+	siltest.NewTestSDK(t)
+
+	ctx, span := silgotel.Trace(t.Context(), "api", "handle")
+	defer span.End()
+
+	if got, want := silgotel.GetTraceID(ctx), span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("GetTraceID() = %q, want %q", got, want)
+	}
+
+	if got, want := silgotel.GetSpanID(ctx), span.SpanContext().SpanID().String(); got != want {
+		t.Errorf("GetSpanID() = %q, want %q", got, want)
+	}
+
+	if len(silgotel.GetTraceID(ctx)) != 32 || len(silgotel.GetSpanID(ctx)) != 16 {
+		t.Errorf("IDs = %q, %q, want 32 and 16 hex digits", silgotel.GetTraceID(ctx), silgotel.GetSpanID(ctx))
+	}
+}
+
+func TestGetTraceIDWithoutSpan(t *testing.T) {
+	if got := silgotel.GetTraceID(t.Context()); got != "" {
+		t.Errorf("GetTraceID() = %q, want an empty string", got)
+	}
+
+	if got := silgotel.GetSpanID(t.Context()); got != "" {
+		t.Errorf("GetSpanID() = %q, want an empty string", got)
+	}
+}
+
+// remoteContext returns a context carrying a sampled remote span context
+// with the given trace and span ID bytes.
+func remoteContext(t *testing.T, id byte) context.Context {
+	t.Helper()
+
+	return otelTrace.ContextWithRemoteSpanContext(t.Context(), otelTrace.NewSpanContext(otelTrace.SpanContextConfig{
+		TraceID:    otelTrace.TraceID{id},
+		SpanID:     otelTrace.SpanID{id},
+		TraceFlags: otelTrace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+func TestTraceWithLinks(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	links := []otelTrace.Link{
+		silgotel.LinkFromContext(remoteContext(t, 1), attribute.String("messaging.message.id", "m1")),
+		silgotel.LinkFromContext(t.Context()),
+		silgotel.LinkFromContext(remoteContext(t, 2), attribute.String("messaging.message.id", "m2")),
+	}
+
+	_, span := silgotel.TraceWithLinks(t.Context(), "batch", "process", links)
+	span.End()
+
+	got := sdk.Spans()[0].Links()
+	if len(got) != 2 {
+		t.Fatalf("span has %d links, want the invalid one skipped and 2 left", len(got))
+	}
+
+	for i, want := range []struct {
+		id        byte
+		messageID string
+	}{{1, "m1"}, {2, "m2"}} {
+		if got[i].SpanContext.TraceID() != (otelTrace.TraceID{want.id}) {
+			t.Errorf("link %d trace ID = %v, want %v", i, got[i].SpanContext.TraceID(), otelTrace.TraceID{want.id})
+		}
+
+		if attrs := attribute.NewSet(got[i].Attributes...); attrs.Len() != 1 {
+			t.Errorf("link %d attributes = %v, want only messaging.message.id", i, got[i].Attributes)
+		} else if value, _ := attrs.Value("messaging.message.id"); value.AsString() != want.messageID {
+			t.Errorf("link %d messaging.message.id = %q, want %q", i, value.AsString(), want.messageID)
+		}
+	}
+
+	if sdk.Spans()[0].Parent().IsValid() {
+		t.Error("linked span has a parent, want a root span")
+	}
+}
+
+// watchedEvents cancels ctx through cancel while span is watched by
+// WatchContext and returns the attributes of the context.done event once it
+// has been added.
+func watchedEvents(t *testing.T, sdk *siltest.SDK, ctx context.Context, cancel func()) attribute.Set {
+	t.Helper()
+
+	_, span := silgotel.Trace(ctx, "watch", "handle")
+	silgotel.WatchContext(ctx, span)
+
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if events := span.(trace.ReadOnlySpan).Events(); len(events) > 0 { //nolint:forcetypeassert
+			span.End()
+
+			if events[0].Name != "context.done" {
+				t.Errorf("event name = %q, want context.done", events[0].Name)
+			}
+
+			return attribute.NewSet(events[0].Attributes...)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	span.End()
+	t.Fatalf("no context.done event after ctx ended; spans: %v", sdk.Spans())
+
+	return attribute.Set{}
+}
+
+func TestWatchContextCanceled(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+
+	attrs := watchedEvents(t, sdk, ctx, func() { cancel(errors.New("client disconnected")) })
+
+	if reason, _ := attrs.Value("context.cancel.reason"); reason.AsString() != "canceled" {
+		t.Errorf("context.cancel.reason = %q, want canceled", reason.AsString())
+	}
+
+	if cause, _ := attrs.Value("context.cancel.cause"); cause.AsString() != "client disconnected" {
+		t.Errorf("context.cancel.cause = %q, want the cancel cause", cause.AsString())
+	}
+}
+
+func TestWatchContextDeadline(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	attrs := watchedEvents(t, sdk, ctx, func() { <-ctx.Done() })
+
+	if reason, _ := attrs.Value("context.cancel.reason"); reason.AsString() != "deadline_exceeded" {
+		t.Errorf("context.cancel.reason = %q, want deadline_exceeded", reason.AsString())
+	}
+
+	spanAttrs := attribute.NewSet(sdk.Spans()[0].Attributes()...)
+
+	remaining, ok := spanAttrs.Value("context.deadline.remaining")
+	if !ok || remaining.AsFloat64() <= 0 || remaining.AsFloat64() > 0.02 {
+		t.Errorf("context.deadline.remaining = %v, want the time left at start", remaining)
+	}
+}
+
+func TestWatchContextStoppedBeforeDone(t *testing.T) {
+	sdk := siltest.NewTestSDK(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	_, span := silgotel.Trace(ctx, "watch", "handle")
+	stop := silgotel.WatchContext(ctx, span)
+	span.End()
+
+	if !stop() {
+		t.Error("stop() = false, want the watch removed before ctx ended")
+	}
+
+	cancel()
+
+	got := sdk.Spans()[0]
+	if len(got.Events()) != 0 {
+		t.Errorf("span events = %v, want none after a normal completion", got.Events())
+	}
+
+	if attrs := attribute.NewSet(got.Attributes()...); attrs.HasValue("context.deadline.remaining") {
+		t.Error("context.deadline.remaining set for a context without deadline")
+	}
+}
+
+func TestSetSpanStatusFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		kind        otelTrace.SpanKind
+		statusCode  int
+		code        codes.Code
+		description string
+	}{
+		{kind: otelTrace.SpanKindServer, statusCode: 200, code: codes.Unset},
+		{kind: otelTrace.SpanKindServer, statusCode: 404, code: codes.Unset},
+		{kind: otelTrace.SpanKindServer, statusCode: 500, code: codes.Error, description: "Internal Server Error"},
+		{kind: otelTrace.SpanKindClient, statusCode: 200, code: codes.Unset},
+		{kind: otelTrace.SpanKindClient, statusCode: 404, code: codes.Error, description: "Not Found"},
+		{kind: otelTrace.SpanKindClient, statusCode: 500, code: codes.Error, description: "Internal Server Error"},
+		{kind: otelTrace.SpanKindServer, statusCode: 999, code: codes.Error, description: "invalid HTTP status code 999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.kind, tt.statusCode), func(t *testing.T) {
+			sdk := siltest.NewTestSDK(t)
+
+			_, span := otel.Tracer("status").Start(t.Context(), "request", otelTrace.WithSpanKind(tt.kind))
+			silgotel.SetSpanStatusFromHTTPStatus(span, tt.kind, tt.statusCode)
+			span.End()
+
+			recorded := sdk.Spans()[0]
+
+			if status := recorded.Status(); status.Code != tt.code || status.Description != tt.description {
+				t.Errorf("status = %v %q, want %v %q", status.Code, status.Description, tt.code, tt.description)
+			}
+
+			var found bool
+
+			for _, kv := range recorded.Attributes() {
+				if kv.Key == "http.response.status_code" {
+					found = kv.Value.AsInt64() == int64(tt.statusCode)
+				}
+			}
+
+			if !found {
+				t.Errorf("http.response.status_code is not %d on %v", tt.statusCode, recorded.Attributes())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package silgotel
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// denyListExporter strips denied attributes from spans and their events
+// before handing them to the wrapped exporter. Spans are wrapped rather than
+// modified so that nothing still referencing them observes the change.
+type denyListExporter struct {
+	trace.SpanExporter
+
+	denied map[string]struct{}
+}
+
+func newDenyListExporter(next trace.SpanExporter, keys []string) *denyListExporter {
+	denied := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		denied[strings.ToLower(key)] = struct{}{}
+	}
+
+	return &denyListExporter{SpanExporter: next, denied: denied}
+}
+
+func (e *denyListExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	filtered := make([]trace.ReadOnlySpan, len(spans))
+	for i, span := range spans {
+		filtered[i] = &filteredSpan{ReadOnlySpan: span, exporter: e}
+	}
+
+	return e.SpanExporter.ExportSpans(ctx, filtered)
+}
+
+func (e *denyListExporter) filter(attrs []attribute.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+
+	for _, kv := range attrs {
+		if _, denied := e.denied[strings.ToLower(string(kv.Key))]; !denied {
+			out = append(out, kv)
+		}
+	}
+
+	return out
+}
+
+type filteredSpan struct {
+	trace.ReadOnlySpan
+
+	exporter *denyListExporter
+}
+
+func (s *filteredSpan) Attributes() []attribute.KeyValue {
+	return s.exporter.filter(s.ReadOnlySpan.Attributes())
+}
+
+func (s *filteredSpan) Events() []trace.Event {
+	events := s.ReadOnlySpan.Events()
+	out := make([]trace.Event, len(events))
+
+	for i, event := range events {
+		event.Attributes = s.exporter.filter(event.Attributes)
+		out[i] = event
+	}
+
+	return out
+}
@@ -0,0 +1,43 @@
+package silgotel_test
+
+import (
+	"slices"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanAttributeDenyList(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithSpanAttributeDenyList("user.email", "HTTP.Request.Header.Authorization"))
+
+	_, span := sdk.client.Tracer("filter").Start(t.Context(), "login", otelTrace.WithAttributes(
+		attribute.String("user.email", "jane@example.com"),
+		attribute.String("http.request.header.authorization", "Bearer secret"),
+		attribute.String("user.id", "42"),
+	))
+	span.AddEvent("retry", otelTrace.WithAttributes(
+		attribute.String("user.email", "jane@example.com"),
+		attribute.Int("attempt", 2),
+	))
+
+	live := span.(interface{ Attributes() []attribute.KeyValue }) //nolint:forcetypeassert
+
+	span.End()
+
+	exported := sdk.Spans(t)[0]
+
+	if keys := attributeKeys(attribute.NewSet(exported.Attributes...)); !slices.Contains(keys, "user.id") ||
+		slices.Contains(keys, "user.email") || slices.Contains(keys, "http.request.header.authorization") {
+		t.Errorf("span attribute keys = %v, want only the denied keys removed", keys)
+	}
+
+	if keys := attributeKeys(attribute.NewSet(exported.Events[0].Attributes...)); !slices.Equal(keys, []string{"attempt"}) {
+		t.Errorf("event attribute keys = %v, want [attempt]", keys)
+	}
+
+	if keys := attributeKeys(attribute.NewSet(live.Attributes()...)); !slices.Contains(keys, "user.email") {
+		t.Errorf("live span attribute keys = %v, want the span itself left untouched", keys)
+	}
+}
@@ -0,0 +1,120 @@
+package silgotel_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingProcessor records the calls it receives and stamps a
+// processed attribute on every span it sees start.
+type recordingProcessor struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (p *recordingProcessor) record(call string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, call)
+}
+
+func (p *recordingProcessor) OnStart(_ context.Context, s trace.ReadWriteSpan) {
+	s.SetAttributes(attribute.Bool("processed", true))
+	p.record("start " + s.Name())
+}
+
+func (p *recordingProcessor) OnEnd(s trace.ReadOnlySpan) { p.record("end " + s.Name()) }
+
+func (p *recordingProcessor) Shutdown(context.Context) error {
+	p.record("shutdown")
+
+	return nil
+}
+
+func (p *recordingProcessor) ForceFlush(context.Context) error {
+	p.record("flush")
+
+	return nil
+}
+
+func (p *recordingProcessor) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.calls)
+}
+
+// restoreGlobals puts the OTel globals back as they were when the test
+// finishes, for tests that let NewOtelSDK register its providers.
+func restoreGlobals(t *testing.T) {
+	t.Helper()
+
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	loggerProvider := global.GetLoggerProvider()
+	propagator := otel.GetTextMapPropagator()
+	errorHandler := otel.GetErrorHandler()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+		global.SetLoggerProvider(loggerProvider)
+		otel.SetTextMapPropagator(propagator)
+		otel.SetErrorHandler(errorHandler)
+	})
+}
+
+func TestWithSpanProcessor(t *testing.T) {
+	restoreGlobals(t)
+
+	first, second := &recordingProcessor{}, &recordingProcessor{}
+	spans := tracetest.NewInMemoryExporter()
+
+	client := newClient(t)
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithSpanProcessor(first),
+		silgotel.WithSpanProcessor(second),
+		silgotel.WithAdditionalTraceExporter(spans),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_, span := silgotel.Trace(t.Context(), "processors", "handle")
+	span.End()
+
+	if err := client.ForceFlush(t.Context()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	exported := spans.GetSpans()
+	if len(exported) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(exported))
+	}
+
+	if !slices.Contains(exported[0].Attributes, attribute.Bool("processed", true)) {
+		t.Error("exported span lacks the attribute set in OnStart, want processors to run before export")
+	}
+
+	if err := shutdown(t.Context()); err != nil {
+		t.Fatalf("shutdown error = %v", err)
+	}
+
+	want := []string{"start handle", "end handle", "flush", "shutdown"}
+	for _, p := range []*recordingProcessor{first, second} {
+		if got := p.Calls(); !slices.Equal(got, want) {
+			t.Errorf("processor calls = %v, want %v", got, want)
+		}
+	}
+}
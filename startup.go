@@ -0,0 +1,76 @@
+package silgotel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// checkEndpoint sends an empty OTLP trace export to the configured endpoint
+// to verify the collector is reachable before any provider is started.
+func (c *Client) checkEndpoint(ctx context.Context) error {
+	if c.options.startupCheckTimeout <= 0 || c.stdoutMode() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.options.startupCheckTimeout)
+	defer cancel()
+
+	endpoint := c.signalURL(c.TracesURL, tracesPath)
+	if c.useGRPC() {
+		return c.checkGRPCEndpoint(ctx, endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(nil))
+	if err != nil {
+		return fmt.Errorf("silgotel: startup check against %s: %w", endpoint, err)
+	}
+
+	for key, value := range c.otlpHeaders() {
+		req.Header.Set(key, value)
+	}
+
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := c.startupCheckClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("silgotel: startup check against %s: %w", endpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf( //nolint: err113
+			"silgotel: startup check against %s: unexpected status %s",
+			endpoint, resp.Status,
+		)
+	}
+
+	return nil
+}
+
+// startupCheckClient returns the exporters' HTTP client, or one using their
+// TLS and proxy configuration.
+func (c *Client) startupCheckClient() *http.Client {
+	if c.options.exporterHTTPClient != nil {
+		return c.options.exporterHTTPClient
+	}
+
+	proxy := c.proxyFunc()
+	if c.tlsConfig == nil && proxy == nil {
+		return http.DefaultClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = c.tlsConfig
+
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+
+	return &http.Client{Transport: transport}
+}
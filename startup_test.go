@@ -0,0 +1,91 @@
+package silgotel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestStartupCheck(t *testing.T) {
+	var got *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if got == nil {
+			got = r.Clone(r.Context())
+		}
+	}))
+	defer server.Close()
+
+	client := newClient(t)
+	client.OTLPBaseURL = server.URL
+	client.Headers = map[string]string{"Authorization": "Bearer secret"}
+
+	startSDK(t, client, silgotel.WithStartupCheck(time.Second))
+
+	if got == nil {
+		t.Fatal("the collector received no startup check")
+	}
+
+	if got.Method != http.MethodPost || got.URL.Path != "/v1/traces" {
+		t.Errorf("startup check = %s %s, want POST /v1/traces", got.Method, got.URL.Path)
+	}
+
+	if auth := got.Header.Get("Authorization"); auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the client's header", auth)
+	}
+}
+
+func TestStartupCheckFails(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	wrongPath := httptest.NewServer(http.NotFoundHandler())
+	defer wrongPath.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	for name, url := range map[string]string{
+		"error status": failing.URL,
+		"wrong path":   wrongPath.URL + "/otlp",
+		"unreachable":  unreachable.URL,
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t)
+			client.OTLPBaseURL = url
+
+			_, err := silgotel.NewOtelSDK(t.Context(), client,
+				silgotel.WithoutGlobalRegistration(),
+				silgotel.WithStartupCheck(time.Second),
+			)
+			if err == nil || !strings.Contains(err.Error(), "startup check") {
+				t.Errorf("NewOtelSDK() error = %v, want a startup check error", err)
+			}
+		})
+	}
+}
+
+func TestStartupCheckSkippedInStdoutMode(t *testing.T) {
+	client := &silgotel.Client{
+		Mode:        silgotel.ModeStdout,
+		ServiceName: "test-service",
+		Environment: "test",
+		Version:     "1.0.0",
+	}
+
+	shutdown, err := silgotel.NewOtelSDK(t.Context(), client,
+		silgotel.WithoutGlobalRegistration(),
+		silgotel.WithStartupCheck(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("NewOtelSDK() error = %v", err)
+	}
+
+	_ = shutdown(t.Context())
+}
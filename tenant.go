@@ -0,0 +1,119 @@
+package silgotel
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TenantIDKey is the attribute, and baggage member, carrying the tenant.
+const TenantIDKey = attribute.Key("tenant.id")
+
+type tenantCtxKey struct{}
+
+// ContextWithTenant returns a copy of ctx carrying tenantID. Spans started
+// and records logged with the returned context get a tenant.id attribute.
+// The tenant stays in-process; use ContextWithPropagatedTenant to send it to
+// downstream services.
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenantID)
+}
+
+// ContextWithPropagatedTenant is like ContextWithTenant but also adds the
+// tenant as a tenant.id baggage member, which the propagator sends with every
+// outgoing call made with the returned context, third-party ones included.
+// Use it only where downstream services are trusted with the tenant.
+func ContextWithPropagatedTenant(ctx context.Context, tenantID string) context.Context {
+	ctx = ContextWithTenant(ctx, tenantID)
+
+	member, err := baggage.NewMember(string(TenantIDKey), tenantID)
+	if err != nil {
+		return ctx
+	}
+
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// TenantFromContext returns the tenant set by ContextWithTenant or
+// ContextWithPropagatedTenant, falling back to the tenant.id baggage member
+// propagated by an upstream service.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	if tenantID, ok := ctx.Value(tenantCtxKey{}).(string); ok {
+		return tenantID, true
+	}
+
+	member := baggage.FromContext(ctx).Member(string(TenantIDKey))
+	if member.Value() == "" {
+		return "", false
+	}
+
+	return member.Value(), true
+}
+
+// TenantAttributes returns the tenant.id attribute for ctx, or nothing when
+// ctx carries no tenant. Metrics cannot be stamped at the resource level per
+// request, so append it to the attributes of each measurement:
+//
+//	counter.Add(ctx, 1, metric.WithAttributes(silgotel.TenantAttributes(ctx)...))
+func TenantAttributes(ctx context.Context) []attribute.KeyValue {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return []attribute.KeyValue{TenantIDKey.String(tenantID)}
+}
+
+// tenantSpanProcessor stamps tenant.id on spans started with a tenant in
+// their context.
+type tenantSpanProcessor struct{}
+
+func (tenantSpanProcessor) OnStart(ctx context.Context, s trace.ReadWriteSpan) {
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		s.SetAttributes(TenantIDKey.String(tenantID))
+	}
+}
+
+func (tenantSpanProcessor) OnEnd(trace.ReadOnlySpan) {}
+
+func (tenantSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (tenantSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// tenantLogProcessor stamps tenant.id on log records emitted with a tenant
+// in their context.
+type tenantLogProcessor struct {
+	log.Processor
+}
+
+func (p *tenantLogProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		record.AddAttributes(otelLog.String(string(TenantIDKey), tenantID))
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// tenantAttr returns the tenant.id slog attribute for ctx.
+func tenantAttr(ctx context.Context) (slog.Attr, bool) {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return slog.Attr{}, false
+	}
+
+	return slog.String(string(TenantIDKey), tenantID), true
+}
@@ -0,0 +1,131 @@
+package silgotel_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// tenantsOnSignals emits a span, a counter sample and a log record with ctx
+// and returns the tenant.id each carries, empty when absent, along with the
+// tenant.id of the console line.
+func tenantsOnSignals(t *testing.T, ctx context.Context) (span, metric, record, console string) {
+	t.Helper()
+
+	sdk := startSDK(t, newClient(t))
+
+	ctx, s := sdk.client.Tracer("tenant").Start(ctx, "op")
+
+	counter, err := sdk.client.Meter("tenant").Int64Counter("tenant.requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter.Add(ctx, 1, otelMetric.WithAttributes(silgotel.TenantAttributes(ctx)...))
+
+	var buf bytes.Buffer
+
+	slog.New(sdk.client.NewSlogHandler(slog.NewJSONHandler(&buf, nil))).InfoContext(ctx, "served")
+	s.End()
+
+	spans := sdk.Spans(t)
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+
+	for _, kv := range spans[0].Attributes {
+		if kv.Key == silgotel.TenantIDKey {
+			span = kv.Value.AsString()
+		}
+	}
+
+	m, ok := findMetric(sdk.Metrics(t), "tenant.requests")
+	if !ok {
+		t.Fatal("tenant.requests was not recorded")
+	}
+
+	value, _ := m.Data.(metricdata.Sum[int64]).DataPoints[0].Attributes.Value(silgotel.TenantIDKey) //nolint:forcetypeassert
+	metric = value.AsString()
+
+	records := sdk.Logs(t)
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+
+	if v, ok := logAttr(records[0], string(silgotel.TenantIDKey)); ok {
+		record = v.AsString()
+	}
+
+	lines := decodeJSONLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("console wrote %d lines, want 1", len(lines))
+	}
+
+	console, _ = lines[0][string(silgotel.TenantIDKey)].(string)
+
+	return span, metric, record, console
+}
+
+func TestTenantOnEverySignal(t *testing.T) {
+	span, metric, record, console := tenantsOnSignals(t, silgotel.ContextWithTenant(t.Context(), "acme"))
+
+	if span != "acme" || metric != "acme" || record != "acme" || console != "acme" {
+		t.Errorf("tenant.id on span, metric, log, console = %q, %q, %q, %q, want acme on all",
+			span, metric, record, console)
+	}
+}
+
+func TestTenantMissing(t *testing.T) {
+	span, metric, record, console := tenantsOnSignals(t, t.Context())
+
+	if span != "" || metric != "" || record != "" || console != "" {
+		t.Errorf("tenant.id on span, metric, log, console = %q, %q, %q, %q, want it left out",
+			span, metric, record, console)
+	}
+}
+
+func TestTenantFromUpstreamBaggage(t *testing.T) {
+	member, _ := baggage.NewMember("tenant.id", "globex")
+	bag, _ := baggage.New(member)
+
+	span, _, record, _ := tenantsOnSignals(t, baggage.ContextWithBaggage(t.Context(), bag))
+
+	if span != "globex" || record != "globex" {
+		t.Errorf("tenant.id on span, log = %q, %q, want the upstream baggage's globex", span, record)
+	}
+}
+
+func TestTenantPropagation(t *testing.T) {
+	usePropagator(t)
+
+	tests := map[string]struct {
+		ctx  context.Context
+		want string
+	}{
+		"in-process only": {ctx: silgotel.ContextWithTenant(t.Context(), "acme")},
+		"propagated":      {ctx: silgotel.ContextWithPropagatedTenant(t.Context(), "acme"), want: "tenant.id=acme"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{}
+			otel.GetTextMapPropagator().Inject(tt.ctx, carrier)
+
+			if got := carrier.Get("baggage"); got != tt.want {
+				t.Errorf("outgoing baggage = %q, want %q", got, tt.want)
+			}
+
+			if tenantID, ok := silgotel.TenantFromContext(tt.ctx); !ok || tenantID != "acme" {
+				t.Errorf("TenantFromContext() = %q, %v, want acme", tenantID, ok)
+			}
+		})
+	}
+}
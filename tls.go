@@ -0,0 +1,122 @@
+package silgotel
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// newTLSConfig builds the TLS configuration shared by the exporters and the
+// startup check, starting from WithTLSConfig when given, then adding the CA
+// bundle and client certificate. It returns nil when no TLS setting is
+// configured, leaving the exporters on their defaults.
+func (c *Client) newTLSConfig() (*tls.Config, error) {
+	if c.options.tlsConfig == nil && c.CAFile == "" &&
+		c.options.clientCertificate == nil && c.ClientCertFile == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.options.tlsConfig != nil {
+		config = c.options.tlsConfig.Clone()
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("silgotel: reading CA bundle %s: %w", c.CAFile, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("silgotel: no certificates found in CA bundle %s", c.CAFile) //nolint: err113
+		}
+
+		config.RootCAs = pool
+	}
+
+	switch {
+	case c.options.clientCertificate != nil:
+		config.Certificates = []tls.Certificate{*c.options.clientCertificate}
+	case c.ClientCertFile != "":
+		reloader, err := newCertReloader(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.GetClientCertificate = reloader.getClientCertificate
+	}
+
+	return config, nil
+}
+
+// certReloader serves a client certificate from disk and reloads it when
+// either file changes, so certificates rotated in place by tools such as
+// cert-manager are picked up without a restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+
+	err := r.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// reload loads the key pair when the files changed since the last load.
+func (r *certReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("silgotel: reading client certificate %s: %w", r.certFile, err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("silgotel: reading client key %s: %w", r.keyFile, err)
+	}
+
+	modTimes := [2]time.Time{certInfo.ModTime(), keyInfo.ModTime()}
+	if r.cert != nil && modTimes == r.modTimes {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("silgotel: loading client key pair %s and %s: %w", r.certFile, r.keyFile, err)
+	}
+
+	r.cert = &cert
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// getClientCertificate implements tls.Config.GetClientCertificate. A failed
+// reload is reported to the OTel error handler and the previous certificate
+// is kept.
+func (r *certReloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.reload()
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return r.cert, nil
+}
@@ -0,0 +1,249 @@
+package silgotel_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+// clientCertificate is a self-signed client certificate and its PEM files.
+type clientCertificate struct {
+	cert     *x509.Certificate
+	certPEM  []byte
+	keyPEM   []byte
+	certFile string
+	keyFile  string
+}
+
+func newClientCertificate(t *testing.T, commonName string) *clientCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+
+	return &clientCertificate{
+		cert:     cert,
+		certPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		certFile: filepath.Join(dir, "client.pem"),
+		keyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+}
+
+// writeTo writes the certificate and key to certFile and keyFile, stamping
+// them with modTime.
+func (c *clientCertificate) writeTo(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+
+	for file, data := range map[string][]byte{certFile: c.certPEM, keyFile: c.keyPEM} {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// mtlsCollector is a fake OTLP/HTTPS collector requiring a client
+// certificate signed by one of trusted. It closes every connection so that
+// each export performs a new handshake.
+type mtlsCollector struct {
+	*httptest.Server
+
+	caFile string
+
+	mu          sync.Mutex
+	commonNames []string
+}
+
+func newMTLSCollector(t *testing.T, trusted ...*x509.Certificate) *mtlsCollector {
+	t.Helper()
+
+	c := &mtlsCollector{}
+
+	c.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		c.mu.Lock()
+		c.commonNames = append(c.commonNames, r.TLS.PeerCertificates[0].Subject.CommonName)
+		c.mu.Unlock()
+
+		w.Header().Set("Connection", "close")
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+
+	pool := x509.NewCertPool()
+	for _, cert := range trusted {
+		pool.AddCert(cert)
+	}
+
+	c.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool, MinVersion: tls.VersionTLS12}
+	c.Config.ErrorLog = log.New(io.Discard, "", 0)
+	c.StartTLS()
+	t.Cleanup(c.Close)
+
+	c.caFile = filepath.Join(t.TempDir(), "ca.pem")
+
+	err := os.WriteFile(c.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Certificate().Raw}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// CommonNames returns the client certificate names seen so far.
+func (c *mtlsCollector) CommonNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.commonNames...)
+}
+
+// mtlsClient returns a client exporting to collector and trusting its
+// certificate.
+func mtlsClient(t *testing.T, collector *mtlsCollector) *silgotel.Client {
+	t.Helper()
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.CAFile = collector.caFile
+
+	return client
+}
+
+// exportSpan exports one span through client and returns the flush error.
+func exportSpan(t *testing.T, client *silgotel.Client) error {
+	t.Helper()
+
+	_, span := client.Tracer("tls").Start(t.Context(), "export")
+	span.End()
+
+	return client.ForceFlush(t.Context())
+}
+
+func TestClientCertificate(t *testing.T) {
+	cert := newClientCertificate(t, "orders")
+	cert.writeTo(t, cert.certFile, cert.keyFile, time.Now())
+
+	collector := newMTLSCollector(t, cert.cert)
+
+	client := mtlsClient(t, collector)
+	client.ClientCertFile = cert.certFile
+	client.ClientKeyFile = cert.keyFile
+
+	startSDK(t, client)
+
+	if err := exportSpan(t, client); err != nil {
+		t.Fatalf("ForceFlush() error = %v, want exports accepted with the client certificate", err)
+	}
+
+	if names := collector.CommonNames(); len(names) == 0 || names[0] != "orders" {
+		t.Errorf("collector saw client certificates %v, want orders", names)
+	}
+}
+
+func TestClientCertificateMissing(t *testing.T) {
+	cert := newClientCertificate(t, "orders")
+	collector := newMTLSCollector(t, cert.cert)
+
+	client := mtlsClient(t, collector)
+	startSDK(t, client, silgotel.WithErrorHandler(func(error) {}))
+
+	err := exportSpan(t, client)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("ForceFlush() error = %v, want the TLS handshake failure", err)
+	}
+}
+
+func TestClientCertificateReload(t *testing.T) {
+	first := newClientCertificate(t, "orders-v1")
+	second := newClientCertificate(t, "orders-v2")
+
+	first.writeTo(t, first.certFile, first.keyFile, time.Now().Add(-time.Minute))
+
+	collector := newMTLSCollector(t, first.cert, second.cert)
+
+	client := mtlsClient(t, collector)
+	client.ClientCertFile = first.certFile
+	client.ClientKeyFile = first.keyFile
+
+	startSDK(t, client)
+
+	if err := exportSpan(t, client); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	// Rotate the files in place, as cert-manager does.
+	second.writeTo(t, first.certFile, first.keyFile, time.Now())
+
+	if err := exportSpan(t, client); err != nil {
+		t.Fatalf("ForceFlush() after rotation error = %v", err)
+	}
+
+	names := collector.CommonNames()
+	if names[0] != "orders-v1" || names[len(names)-1] != "orders-v2" {
+		t.Errorf("collector saw client certificates %v, want orders-v1 then orders-v2", names)
+	}
+}
+
+func TestClientCertificateUnreadable(t *testing.T) {
+	cert := newClientCertificate(t, "orders")
+	cert.writeTo(t, cert.certFile, cert.keyFile, time.Now())
+
+	client := newClient(t)
+	client.ClientCertFile = cert.certFile
+	client.ClientKeyFile = filepath.Join(t.TempDir(), "missing-key.pem")
+
+	_, err := silgotel.NewOtelSDK(t.Context(), client, silgotel.WithoutGlobalRegistration())
+	if err == nil || !strings.Contains(err.Error(), client.ClientKeyFile) {
+		t.Errorf("NewOtelSDK() error = %v, want one naming %s", err, client.ClientKeyFile)
+	}
+}
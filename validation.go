@@ -0,0 +1,177 @@
+package silgotel
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single invalid Client field.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "otlpBaseURL".
+	Field string
+	// Tag is the validation rule that failed, e.g. "required".
+	Tag string
+	// Message is a human readable explanation.
+	Message string
+}
+
+// ValidationError lists every invalid field of a Client. Retrieve it with
+// errors.As.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + " " + f.Message
+	}
+
+	return "silgotel: invalid client configuration: " + strings.Join(msgs, "; ")
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(jsonFieldName)
+
+	err := v.RegisterValidation("httpurl", func(fl validator.FieldLevel) bool {
+		return isHTTPURL(fl.Field().String())
+	})
+	if err != nil {
+		panic(fmt.Sprintf("silgotel: registering httpurl validation: %v", err))
+	}
+
+	err = v.RegisterValidation("otlpendpoint", func(fl validator.FieldLevel) bool {
+		parent := fl.Parent()
+		if !parent.CanAddr() {
+			return true
+		}
+
+		client, ok := parent.Addr().Interface().(*Client)
+
+		return !ok || client.stdoutMode() || client.hasEndpoints()
+	}, true)
+	if err != nil {
+		panic(fmt.Sprintf("silgotel: registering otlpendpoint validation: %v", err))
+	}
+
+	err = v.RegisterValidation("ltequeue", func(fl validator.FieldLevel) bool {
+		parent := fl.Parent()
+		if !parent.CanAddr() {
+			return true
+		}
+
+		client, ok := parent.Addr().Interface().(*Client)
+
+		return !ok || client.logBatchSize() <= client.logQueueSize()
+	})
+	if err != nil {
+		panic(fmt.Sprintf("silgotel: registering ltequeue validation: %v", err))
+	}
+
+	return v
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL with a host.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Validate checks the client configuration, returning a *ValidationError
+// naming every invalid field.
+func (c *Client) Validate() error {
+	err := validate.Struct(c)
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	verr := &ValidationError{Fields: make([]FieldError, len(fieldErrs))}
+	for i, fe := range fieldErrs {
+		verr.Fields[i] = FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Tag:     fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+
+	return verr
+}
+
+// fieldPath drops the root struct name from a validator namespace such as
+// "Client.otlpBaseURL".
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+
+	return namespace
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required unless " + strings.Join(clientFieldNames(fe.Param()), ", ") + " are all set"
+	case "required_with":
+		return "is required when " + strings.Join(clientFieldNames(fe.Param()), ", ") + " is set"
+	case "otlpendpoint":
+		return "is required unless tracesURL, metricsURL and logsURL are all set, " +
+			envOTLPEndpoint + " is set or mode is stdout"
+	case "ltequeue":
+		return fmt.Sprintf("must not exceed logMaxQueueSize, which defaults to %d, got %v", defaultLogMaxQueueSize, fe.Value())
+	case "url":
+		return fmt.Sprintf("must be a valid URL, got %q", fe.Value())
+	case "httpurl":
+		return fmt.Sprintf("must be an absolute http:// or https:// URL, got %q", fe.Value())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s], got %q", fe.Param(), fe.Value())
+	case "gte":
+		return "must be greater than or equal to " + fe.Param()
+	case "lte":
+		return "must be less than or equal to " + fe.Param()
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
+
+// clientFieldNames converts space separated Client field names, as used in
+// validator parameters, to their JSON names.
+func clientFieldNames(param string) []string {
+	names := strings.Fields(param)
+	clientType := reflect.TypeFor[Client]()
+
+	for i, name := range names {
+		if field, ok := clientType.FieldByName(name); ok {
+			names[i] = jsonFieldName(field)
+		}
+	}
+
+	return names
+}
+
+//nolint:gocritic
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
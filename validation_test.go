@@ -0,0 +1,136 @@
+package silgotel_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+)
+
+func TestValidateReportsEveryField(t *testing.T) {
+	client := &silgotel.Client{
+		OTLPBaseURL:   "collector:4318",
+		Environment:   "test",
+		SamplingRatio: 2,
+		Mode:          "file",
+	}
+
+	err := client.Validate()
+
+	var verr *silgotel.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+
+	want := map[string]string{
+		"otlpBaseURL":   "httpurl",
+		"serviceName":   "required",
+		"version":       "required",
+		"samplingRatio": "lte",
+		"mode":          "oneof",
+	}
+
+	got := make(map[string]string)
+	for _, field := range verr.Fields {
+		got[field.Field] = field.Tag
+
+		if field.Message == "" {
+			t.Errorf("%s has no message", field.Field)
+		}
+	}
+
+	for field, tag := range want {
+		if got[field] != tag {
+			t.Errorf("%s failed %q, want %q (all: %v)", field, got[field], tag, got)
+		}
+	}
+
+	msg := err.Error()
+	for _, part := range []string{
+		"serviceName is required",
+		`otlpBaseURL must be an absolute http:// or https:// URL, got "collector:4318"`,
+		`mode must be one of [otlp stdout], got "file"`,
+	} {
+		if !strings.Contains(msg, part) {
+			t.Errorf("Error() = %q, want it to contain %q", msg, part)
+		}
+	}
+
+	if strings.Contains(msg, "Client.") || strings.Contains(msg, "OTLPBaseURL") {
+		t.Errorf("Error() = %q, want JSON field names only", msg)
+	}
+}
+
+func TestValidateCrossFieldRules(t *testing.T) {
+	client := newClient(t)
+	client.ClientCertFile = "client.pem"
+
+	var verr *silgotel.ValidationError
+	if !errors.As(client.Validate(), &verr) || len(verr.Fields) != 1 {
+		t.Fatalf("Validate() = %v, want one field error", verr)
+	}
+
+	if field := verr.Fields[0]; field.Field != "clientKeyFile" || field.Message != "is required when clientCertFile is set" {
+		t.Errorf("field error = %+v, want clientKeyFile required with clientCertFile", field)
+	}
+}
+
+func TestValidateValidClient(t *testing.T) {
+	if err := newClient(t).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestValidateLogBatchFields(t *testing.T) {
+	tests := map[string]struct {
+		configure func(*silgotel.Client)
+		field     string
+	}{
+		"batch over queue": {
+			configure: func(c *silgotel.Client) { c.LogMaxQueueSize, c.LogMaxBatchSize = 100, 200 },
+			field:     "logMaxBatchSize",
+		},
+		"batch over default queue": {
+			configure: func(c *silgotel.Client) { c.LogMaxBatchSize = 4096 },
+			field:     "logMaxBatchSize",
+		},
+		"negative queue": {
+			configure: func(c *silgotel.Client) { c.LogMaxQueueSize = -1 },
+			field:     "logMaxQueueSize",
+		},
+		"negative interval": {
+			configure: func(c *silgotel.Client) { c.LogExportInterval = -time.Second },
+			field:     "logExportInterval",
+		},
+		"negative timeout": {
+			configure: func(c *silgotel.Client) { c.LogExportTimeout = -time.Second },
+			field:     "logExportTimeout",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t)
+			tt.configure(client)
+
+			var verr *silgotel.ValidationError
+			if err := client.Validate(); !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+
+			if len(verr.Fields) != 1 || verr.Fields[0].Field != tt.field {
+				t.Errorf("Validate() fields = %v, want only %s", verr.Fields, tt.field)
+			}
+		})
+	}
+
+	client := newClient(t)
+	client.LogMaxQueueSize, client.LogMaxBatchSize = 4096, 1024
+	client.LogExportInterval, client.LogExportTimeout = time.Second, 10*time.Second
+
+	if err := client.Validate(); err != nil {
+		t.Errorf("Validate() = %v for sane log batch settings, want nil", err)
+	}
+}
@@ -0,0 +1,106 @@
+package silgotel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// deniedHTTPAttributes are unbounded request attributes that must never end
+// up on the request duration histogram.
+//
+//nolint:gochecknoglobals
+var deniedHTTPAttributes = []attribute.Key{
+	"url.query",
+	"url.full",
+	"http.target",
+}
+
+// metricViews combines the HTTP views with any attribute allow lists so that
+// a single stream is produced per instrument.
+//
+//nolint:ireturn
+func (c *Client) metricViews() sdkmetric.Option {
+	views := httpViews()
+
+	allowLists := c.options.attributeAllowLists
+	if len(allowLists) == 0 {
+		return sdkmetric.WithView(views...)
+	}
+
+	return sdkmetric.WithView(func(inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		stream, matched := matchView(views, inst)
+
+		keys, ok := allowLists[inst.Name]
+		if !ok {
+			return stream, matched
+		}
+
+		if !matched {
+			stream = sdkmetric.Stream{
+				Name:        inst.Name,
+				Description: inst.Description,
+				Unit:        inst.Unit,
+			}
+		}
+
+		stream.AttributeFilter = allFilters(stream.AttributeFilter, attribute.NewAllowKeysFilter(keys...))
+
+		return stream, true
+	})
+}
+
+func matchView(views []sdkmetric.View, inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+	for _, view := range views {
+		if stream, ok := view(inst); ok {
+			return stream, true
+		}
+	}
+
+	return sdkmetric.Stream{}, false
+}
+
+// allFilters keeps an attribute only when every non-nil filter keeps it.
+func allFilters(filters ...attribute.Filter) attribute.Filter {
+	return func(kv attribute.KeyValue) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(kv) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// exemplarFilter maps the configured exemplar filter name to its SDK filter.
+func (c *Client) exemplarFilter() exemplar.Filter {
+	switch c.options.exemplarFilter {
+	case ExemplarsAlwaysOn:
+		return exemplar.AlwaysOnFilter
+	case ExemplarsAlwaysOff:
+		return exemplar.AlwaysOffFilter
+	default:
+		return exemplar.TraceBasedFilter
+	}
+}
+
+// temporalitySelector returns the delta selector when delta temporality was
+// requested and the SDK's cumulative default otherwise.
+func (c *Client) temporalitySelector() sdkmetric.TemporalitySelector {
+	if c.MetricTemporality != "delta" {
+		return sdkmetric.DefaultTemporalitySelector
+	}
+
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		switch kind { //nolint:exhaustive
+		case sdkmetric.InstrumentKindCounter,
+			sdkmetric.InstrumentKindObservableCounter,
+			sdkmetric.InstrumentKindHistogram:
+			return metricdata.DeltaTemporality
+		default:
+			return metricdata.CumulativeTemporality
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package silgotel_test
+
+import (
+	"slices"
+	"testing"
+
+	silgotel "github.com/savannahghi/sil-gotel"
+	"go.opentelemetry.io/otel/attribute"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// attributeKeys returns the sorted keys of set.
+func attributeKeys(set attribute.Set) []string {
+	var keys []string
+
+	for _, kv := range set.ToSlice() {
+		keys = append(keys, string(kv.Key))
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func TestAttributeAllowList(t *testing.T) {
+	sdk := startSDK(t, newClient(t), silgotel.WithAttributeAllowList("orders.placed", "region"))
+
+	counter, err := sdk.client.Meter("views").Int64Counter("orders.placed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []string{"u1", "u2", "u3"} {
+		counter.Add(t.Context(), 1, otelMetric.WithAttributes(
+			attribute.String("region", "eu"),
+			attribute.String("user.id", user),
+		))
+	}
+
+	m, ok := findMetric(sdk.Metrics(t), "orders.placed")
+	if !ok {
+		t.Fatal("orders.placed was not exported")
+	}
+
+	points := m.Data.(metricdata.Sum[int64]).DataPoints //nolint:forcetypeassert
+	if len(points) != 1 {
+		t.Fatalf("orders.placed has %d data points, want user.id dropped and 1 left", len(points))
+	}
+
+	if keys := attributeKeys(points[0].Attributes); !slices.Equal(keys, []string{"region"}) {
+		t.Errorf("data point keys = %v, want [region]", keys)
+	}
+
+	if points[0].Value != 3 {
+		t.Errorf("orders.placed = %d, want 3", points[0].Value)
+	}
+}
+
+func TestAttributeAllowListComposesWithHTTPViews(t *testing.T) {
+	sdk := startSDK(t, newClient(t),
+		silgotel.WithAttributeAllowList("http.server.request.duration", "http.route", "url.full"),
+	)
+
+	histogram, err := sdk.client.Meter("views").Float64Histogram("http.server.request.duration")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	histogram.Record(t.Context(), 0.2, otelMetric.WithAttributes(
+		attribute.String("http.route", "/orders/{id}"),
+		attribute.String("http.request.method", "GET"),
+		attribute.String("url.full", "https://example.com/orders/1?token=x"),
+	))
+
+	m, ok := findMetric(sdk.Metrics(t), "http.server.request.duration")
+	if !ok {
+		t.Fatal("http.server.request.duration was not exported")
+	}
+
+	hist := m.Data.(metricdata.Histogram[float64]) //nolint:forcetypeassert
+
+	// url.full is allowed by the list but still denied by the HTTP view.
+	if keys := attributeKeys(hist.DataPoints[0].Attributes); !slices.Equal(keys, []string{"http.route"}) {
+		t.Errorf("data point keys = %v, want [http.route]", keys)
+	}
+
+	if bounds := hist.DataPoints[0].Bounds; len(bounds) == 0 || bounds[0] != 0.005 {
+		t.Errorf("bucket boundaries = %v, want the HTTP view's", bounds)
+	}
+}
+
+// exemplarCounts records one measurement inside a sampled span and one
+// outside any span with the given exemplar filter, and returns how many
+// exemplars each carries.
+func exemplarCounts(t *testing.T, opts ...silgotel.Option) (inSpan, outside int) {
+	t.Helper()
+
+	sdk := startSDK(t, newClient(t), opts...)
+
+	histogram, err := sdk.client.Meter("exemplars").Float64Histogram("latency")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := sdk.client.Tracer("exemplars").Start(t.Context(), "op")
+	histogram.Record(ctx, 1, otelMetric.WithAttributes(attribute.Bool("in_span", true)))
+	span.End()
+
+	histogram.Record(t.Context(), 1, otelMetric.WithAttributes(attribute.Bool("in_span", false)))
+
+	m, ok := findMetric(sdk.Metrics(t), "latency")
+	if !ok {
+		t.Fatal("latency was not exported")
+	}
+
+	for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints { //nolint:forcetypeassert
+		if value, _ := point.Attributes.Value("in_span"); value.AsBool() {
+			inSpan = len(point.Exemplars)
+		} else {
+			outside = len(point.Exemplars)
+		}
+	}
+
+	return inSpan, outside
+}
+
+func TestExemplars(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []silgotel.Option
+		inSpan, outside int
+	}{
+		{name: "default", inSpan: 1, outside: 0},
+		{name: "trace based", opts: []silgotel.Option{silgotel.WithExemplars(silgotel.ExemplarsTraceBased)}, inSpan: 1},
+		{name: "always on", opts: []silgotel.Option{silgotel.WithExemplars(silgotel.ExemplarsAlwaysOn)}, inSpan: 1, outside: 1},
+		{name: "always off", opts: []silgotel.Option{silgotel.WithExemplars(silgotel.ExemplarsAlwaysOff)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inSpan, outside := exemplarCounts(t, tt.opts...)
+			if inSpan != tt.inSpan || outside != tt.outside {
+				t.Errorf("exemplars in span, outside = %d, %d, want %d, %d", inSpan, outside, tt.inSpan, tt.outside)
+			}
+		})
+	}
+}
+
+func TestExemplarsUnknownFilter(t *testing.T) {
+	_, err := silgotel.NewOtelSDK(t.Context(), newClient(t), silgotel.WithExemplars("sometimes"))
+	if err == nil {
+		t.Error("NewOtelSDK() = nil, want an error for the unknown filter")
+	}
+}
+
+// exportedTemporalities records on a counter, a histogram and an up-down
+// counter and returns the aggregation temporality each was exported with.
+func exportedTemporalities(t *testing.T, temporality string) map[string]metricspb.AggregationTemporality {
+	t.Helper()
+
+	collector := newRecordingCollector(t)
+
+	client := newClient(t)
+	client.OTLPBaseURL = collector.URL
+	client.MetricTemporality = temporality
+
+	sdk := startSDK(t, client)
+	meter := sdk.client.Meter("temporality")
+
+	counter, _ := meter.Int64Counter("requests")
+	counter.Add(t.Context(), 1)
+
+	histogram, _ := meter.Float64Histogram("latency")
+	histogram.Record(t.Context(), 1)
+
+	upDown, _ := meter.Int64UpDownCounter("in_flight")
+	upDown.Add(t.Context(), 1)
+
+	sdk.flush(t)
+
+	got := make(map[string]metricspb.AggregationTemporality)
+
+	for _, m := range collector.Metrics(t) {
+		switch data := m.GetData().(type) {
+		case *metricspb.Metric_Sum:
+			got[m.GetName()] = data.Sum.GetAggregationTemporality()
+		case *metricspb.Metric_Histogram:
+			got[m.GetName()] = data.Histogram.GetAggregationTemporality()
+		}
+	}
+
+	return got
+}
+
+func TestMetricTemporality(t *testing.T) {
+	const (
+		cumulative = metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+		delta      = metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	)
+
+	tests := map[string]map[string]metricspb.AggregationTemporality{
+		"":           {"requests": cumulative, "latency": cumulative, "in_flight": cumulative},
+		"cumulative": {"requests": cumulative, "latency": cumulative, "in_flight": cumulative},
+		"delta":      {"requests": delta, "latency": delta, "in_flight": cumulative},
+	}
+
+	for temporality, want := range tests {
+		t.Run(temporality, func(t *testing.T) {
+			got := exportedTemporalities(t, temporality)
+
+			for name, temporality := range want {
+				if got[name] != temporality {
+					t.Errorf("%s exported as %v, want %v", name, got[name], temporality)
+				}
+			}
+		})
+	}
+}
+
+func TestMetricTemporalityInvalid(t *testing.T) {
+	client := newClient(t)
+	client.MetricTemporality = "sometimes"
+
+	if err := client.Validate(); err == nil {
+		t.Error("Validate() = nil, want an error for the unknown temporality")
+	}
+}